@@ -3,28 +3,33 @@ package alipay
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/pkg/util"
 )
 
 // ant.merchant.expand.shop.modify(修改蚂蚁店铺)
 // 文档地址：https://opendocs.alipay.com/apis/014tmb
 func (a *Client) AntMerchantShopModify(ctx context.Context, bm gopay.BodyMap) (aliRsp *AntMerchantShopModifyRsp, err error) {
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "ant.merchant.expand.shop.modify"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "ant.merchant.expand.shop.modify"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(AntMerchantShopModifyRsp)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // ant.merchant.expand.shop.create(蚂蚁店铺创建)
@@ -35,19 +40,22 @@ func (a *Client) AntMerchantShopCreate(ctx context.Context, bm gopay.BodyMap) (a
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "ant.merchant.expand.shop.create"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "ant.merchant.expand.shop.create"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(AntMerchantShopCreateRsp)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // ant.merchant.expand.shop.consult(蚂蚁店铺创建咨询)
@@ -58,19 +66,22 @@ func (a *Client) AntMerchantShopConsult(ctx context.Context, bm gopay.BodyMap) (
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "ant.merchant.expand.shop.consult"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "ant.merchant.expand.shop.consult"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(AntMerchantShopConsultRsp)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // ant.merchant.expand.order.query(商户申请单查询)
@@ -81,55 +92,238 @@ func (a *Client) AntMerchantOrderQuery(ctx context.Context, bm gopay.BodyMap) (a
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "ant.merchant.expand.order.query"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "ant.merchant.expand.order.query"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(AntMerchantOrderQueryRsp)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // ant.merchant.expand.shop.query(店铺查询接口)
 // 文档地址：https://opendocs.alipay.com/apis/api_1/ant.merchant.expand.shop.query
 func (a *Client) AntMerchantShopQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *AntMerchantShopQueryRsp, err error) {
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "ant.merchant.expand.shop.query"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "ant.merchant.expand.shop.query"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(AntMerchantShopQueryRsp)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // ant.merchant.expand.shop.close(蚂蚁店铺关闭)
 // 文档地址：https://opendocs.alipay.com/apis/api_1/ant.merchant.expand.shop.close
 func (a *Client) AntMerchantShopClose(ctx context.Context, bm gopay.BodyMap) (aliRsp *AntMerchantShopCloseRsp, err error) {
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "ant.merchant.expand.shop.close"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "ant.merchant.expand.shop.close"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(AntMerchantShopCloseRsp)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
+	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
+		return aliRsp, err
+	}
+	return aliRsp, nil
+}
+
+// ant.merchant.expand.indirect.zft.create(直付通二级商户创建)
+func (a *Client) AntMerchantZftCreate(ctx context.Context, bm gopay.BodyMap) (aliRsp *AntMerchantZftCreateRsp, err error) {
+	err = bm.CheckEmptyError("external_id", "merchant_type", "name", "alias_name", "mcc", "service")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(ctx, bm, "ant.merchant.expand.indirect.zft.create"); err != nil && !isVerifyErr(err) {
+		return nil, err
+	}
+	verifyErr := err
+	aliRsp = new(AntMerchantZftCreateRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
+		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
+	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
+	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
+		return aliRsp, err
+	}
+	return aliRsp, nil
+}
+
+// ant.merchant.expand.indirect.zft.simplecreate(直付通二级商户免证照进件)
+func (a *Client) AntMerchantZftSimpleCreate(ctx context.Context, bm gopay.BodyMap) (aliRsp *AntMerchantZftSimpleCreateRsp, err error) {
+	err = bm.CheckEmptyError("external_id", "alias_name", "mcc", "service")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(ctx, bm, "ant.merchant.expand.indirect.zft.simplecreate"); err != nil && !isVerifyErr(err) {
+		return nil, err
+	}
+	verifyErr := err
+	aliRsp = new(AntMerchantZftSimpleCreateRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
+		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
+	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
+	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
+		return aliRsp, err
+	}
+	return aliRsp, nil
+}
+
+// ant.merchant.expand.indirect.zft.modify(直付通二级商户修改)
+func (a *Client) AntMerchantZftModify(ctx context.Context, bm gopay.BodyMap) (aliRsp *AntMerchantZftModifyRsp, err error) {
+	err = bm.CheckEmptyError("external_id")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(ctx, bm, "ant.merchant.expand.indirect.zft.modify"); err != nil && !isVerifyErr(err) {
+		return nil, err
+	}
+	verifyErr := err
+	aliRsp = new(AntMerchantZftModifyRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
+		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
+	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
+	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
+		return aliRsp, err
+	}
+	return aliRsp, nil
+}
+
+// ant.merchant.expand.indirect.zft.consult(直付通商户创建预校验咨询)
+func (a *Client) AntMerchantZftConsult(ctx context.Context, bm gopay.BodyMap) (aliRsp *AntMerchantZftConsultRsp, err error) {
+	err = bm.CheckEmptyError("external_id", "merchant_type", "name", "alias_name", "mcc", "service")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(ctx, bm, "ant.merchant.expand.indirect.zft.consult"); err != nil && !isVerifyErr(err) {
+		return nil, err
+	}
+	verifyErr := err
+	aliRsp = new(AntMerchantZftConsultRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
+		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
+	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
+	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
+		return aliRsp, err
+	}
+	return aliRsp, nil
+}
+
+// ant.merchant.expand.indirect.zftorder.query(直付通商户进件申请单查询)
+func (a *Client) AntMerchantZftOrderQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *AntMerchantZftOrderQueryRsp, err error) {
+	if bm.GetString("external_id") == util.NULL && bm.GetString("order_id") == util.NULL {
+		return nil, errors.New("external_id and order_id are not allowed to be null at the same time")
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(ctx, bm, "ant.merchant.expand.indirect.zftorder.query"); err != nil && !isVerifyErr(err) {
+		return nil, err
+	}
+	verifyErr := err
+	aliRsp = new(AntMerchantZftOrderQueryRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
+		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
+	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
+	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
+		return aliRsp, err
+	}
+	return aliRsp, nil
+}
+
+// ant.merchant.expand.indirect.zft.upgrade(直付通二级商户免证照升级)
+func (a *Client) AntMerchantZftUpgrade(ctx context.Context, bm gopay.BodyMap) (aliRsp *AntMerchantZftUpgradeRsp, err error) {
+	err = bm.CheckEmptyError("external_id")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(ctx, bm, "ant.merchant.expand.indirect.zft.upgrade"); err != nil && !isVerifyErr(err) {
+		return nil, err
+	}
+	verifyErr := err
+	aliRsp = new(AntMerchantZftUpgradeRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
+		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
+	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
+	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
+		return aliRsp, err
+	}
+	return aliRsp, nil
+}
+
+// ant.merchant.expand.indirect.zft.delete(直付通二级商户作废)
+func (a *Client) AntMerchantZftDelete(ctx context.Context, bm gopay.BodyMap) (aliRsp *AntMerchantZftDeleteRsp, err error) {
+	err = bm.CheckEmptyError("smid")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(ctx, bm, "ant.merchant.expand.indirect.zft.delete"); err != nil && !isVerifyErr(err) {
+		return nil, err
+	}
+	verifyErr := err
+	aliRsp = new(AntMerchantZftDeleteRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
+		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
+	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
@@ -112,3 +112,40 @@ func TestAntMerchantShopClose(t *testing.T) {
 	}
 	xlog.Debug("aliRsp:", *aliRsp)
 }
+
+func TestAntMerchantZftOrderQuery(t *testing.T) {
+	// 请求参数
+	bm := make(gopay.BodyMap)
+	bm.Set("external_id", "2088301155943087")
+
+	aliRsp, err := client.AntMerchantZftOrderQuery(ctx, bm)
+	if err != nil {
+		if bizErr, ok := IsBizError(err); ok {
+			xlog.Errorf("%+v", bizErr)
+			// do something
+			return
+		}
+		xlog.Errorf("client.AntMerchantZftOrderQuery(%+v),error:%+v", bm, err)
+		return
+	}
+	xlog.Debug("aliRsp:", *aliRsp)
+}
+
+func TestParseZftAuditNotify(t *testing.T) {
+	bm := make(gopay.BodyMap)
+	bm.Set("notify_id", "2020092100222161127031571487658").
+		Set("msg_method", NotifyZftPassed).
+		Set("biz_content", `{"external_id":"NO0001","order_id":"2017112200502000000004754299","smid":"2088301155943087","card_alias_no":"c0d2f9d9e4a54f2aa7c0a2b5b5e1e7b1"}`)
+	notify, err := ParseZftAuditNotify(bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !notify.Passed() || notify.BizContent.Smid != "2088301155943087" {
+		t.Fatalf("ParseZftAuditNotify() = %+v", notify.BizContent)
+	}
+	bm.Set("msg_method", NotifyZftRejected).
+		Set("biz_content", `{"external_id":"NO0001","order_id":"2017112200502000000004754299","reason":"营业执照模糊"}`)
+	if notify, err = ParseZftAuditNotify(bm); err != nil || notify.Passed() || notify.BizContent.Reason == "" {
+		t.Fatalf("ParseZftAuditNotify() = %+v, %v", notify, err)
+	}
+}
@@ -0,0 +1,429 @@
+package alipay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/pkg/xlog"
+)
+
+const (
+	defaultTokenRefreshAhead    = time.Hour * 24 * 7 // 默认令牌过期前刷新提前量
+	defaultTokenRefreshInterval = time.Hour * 12     // 默认令牌轮询检查间隔
+
+	notifyAppAuthCancelled = "alipay.open.auth.appauth.cancelled" // 取消应用授权通知
+)
+
+// AppAuthTokenInfo 商户应用授权令牌
+type AppAuthTokenInfo struct {
+	AuthAppId       string    `json:"auth_app_id"`       // 授权商户的appid
+	UserId          string    `json:"user_id"`           // 授权商户的user_id
+	AppAuthToken    string    `json:"app_auth_token"`    // 应用授权令牌
+	AppRefreshToken string    `json:"app_refresh_token"` // 刷新令牌
+	ExpiresAt       time.Time `json:"expires_at"`        // 应用授权令牌过期时间
+	ReExpiresAt     time.Time `json:"re_expires_at"`     // 刷新令牌过期时间
+}
+
+// AppAuthTokenStore 应用授权令牌存储，可自行实现（如 Redis、数据库）以在多实例间共享
+type AppAuthTokenStore interface {
+	// Get 获取商户令牌，不存在时返回 nil, nil
+	Get(ctx context.Context, authAppId string) (token *AppAuthTokenInfo, err error)
+	// Set 保存商户令牌
+	Set(ctx context.Context, token *AppAuthTokenInfo) (err error)
+	// Delete 删除商户令牌
+	Delete(ctx context.Context, authAppId string) (err error)
+	// List 获取全部商户令牌
+	List(ctx context.Context) (tokens []*AppAuthTokenInfo, err error)
+}
+
+// MemoryAppAuthTokenStore 内存令牌存储，仅适用于单实例
+type MemoryAppAuthTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]*AppAuthTokenInfo
+}
+
+// NewMemoryAppAuthTokenStore 初始化内存令牌存储
+func NewMemoryAppAuthTokenStore() *MemoryAppAuthTokenStore {
+	return &MemoryAppAuthTokenStore{tokens: make(map[string]*AppAuthTokenInfo)}
+}
+
+func (s *MemoryAppAuthTokenStore) Get(_ context.Context, authAppId string) (*AppAuthTokenInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if t, ok := s.tokens[authAppId]; ok {
+		token := *t
+		return &token, nil
+	}
+	return nil, nil
+}
+
+func (s *MemoryAppAuthTokenStore) Set(_ context.Context, token *AppAuthTokenInfo) error {
+	if token == nil || token.AuthAppId == "" {
+		return errors.New("token or auth_app_id is empty")
+	}
+	t := *token
+	s.mu.Lock()
+	s.tokens[t.AuthAppId] = &t
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemoryAppAuthTokenStore) Delete(_ context.Context, authAppId string) error {
+	s.mu.Lock()
+	delete(s.tokens, authAppId)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemoryAppAuthTokenStore) List(_ context.Context) ([]*AppAuthTokenInfo, error) {
+	s.mu.RLock()
+	tokens := make([]*AppAuthTokenInfo, 0, len(s.tokens))
+	for _, t := range s.tokens {
+		token := *t
+		tokens = append(tokens, &token)
+	}
+	s.mu.RUnlock()
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].AuthAppId < tokens[j].AuthAppId })
+	return tokens, nil
+}
+
+// AppAuthTokenManager 第三方应用授权令牌管理器
+// 负责换取、保存、过期前刷新商户的 app_auth_token，处理授权变更通知，并为代商户调用提供对应令牌
+type AppAuthTokenManager struct {
+	client       *Client // 服务商（第三方应用）client
+	store        AppAuthTokenStore
+	refreshAhead time.Duration
+	interval     time.Duration
+
+	onRefreshFailure func(authAppId string, err error)
+
+	refreshMu sync.Mutex
+	mu        sync.Mutex
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// NewAppAuthTokenManager 初始化应用授权令牌管理器
+// client：服务商（第三方应用）client
+// store：令牌存储，为 nil 时使用内存存储
+func NewAppAuthTokenManager(client *Client, store AppAuthTokenStore) (m *AppAuthTokenManager) {
+	if store == nil {
+		store = NewMemoryAppAuthTokenStore()
+	}
+	return &AppAuthTokenManager{
+		client:       client,
+		store:        store,
+		refreshAhead: defaultTokenRefreshAhead,
+		interval:     defaultTokenRefreshInterval,
+	}
+}
+
+// SetRefreshAhead 设置令牌过期前刷新的提前量，默认 7 天
+func (m *AppAuthTokenManager) SetRefreshAhead(ahead time.Duration) *AppAuthTokenManager {
+	if ahead > 0 {
+		m.refreshAhead = ahead
+	}
+	return m
+}
+
+// SetInterval 设置定时检查令牌的间隔，默认 12 小时
+func (m *AppAuthTokenManager) SetInterval(interval time.Duration) *AppAuthTokenManager {
+	if interval > 0 {
+		m.interval = interval
+	}
+	return m
+}
+
+// OnRefreshFailure 设置令牌刷新失败回调
+func (m *AppAuthTokenManager) OnRefreshFailure(fn func(authAppId string, err error)) *AppAuthTokenManager {
+	m.onRefreshFailure = fn
+	return m
+}
+
+// Store 获取令牌存储
+func (m *AppAuthTokenManager) Store() AppAuthTokenStore {
+	return m.store
+}
+
+// ExchangeCode 使用商户授权后回调的 app_auth_code 换取令牌并保存
+// 批量授权时返回多个商户的令牌
+func (m *AppAuthTokenManager) ExchangeCode(ctx context.Context, appAuthCode string) (tokens []*AppAuthTokenInfo, err error) {
+	if appAuthCode == "" {
+		return nil, errors.New("app_auth_code is empty")
+	}
+	bm := make(gopay.BodyMap)
+	bm.Set("grant_type", "authorization_code").
+		Set("code", appAuthCode)
+	return m.requestToken(ctx, bm)
+}
+
+// Refresh 立即使用 app_refresh_token 刷新商户令牌并保存
+func (m *AppAuthTokenManager) Refresh(ctx context.Context, authAppId string) (token *AppAuthTokenInfo, err error) {
+	m.refreshMu.Lock()
+	defer m.refreshMu.Unlock()
+	old, err := m.store.Get(ctx, authAppId)
+	if err != nil {
+		return nil, err
+	}
+	return m.refresh(ctx, old, authAppId)
+}
+
+// Token 获取商户有效的 app_auth_token，令牌即将过期时自动刷新
+// 刷新失败但令牌尚未过期时，返回原令牌
+func (m *AppAuthTokenManager) Token(ctx context.Context, authAppId string) (appAuthToken string, err error) {
+	token, err := m.store.Get(ctx, authAppId)
+	if err != nil {
+		return "", err
+	}
+	if token == nil {
+		return "", fmt.Errorf("auth_app_id [%s] app_auth_token not found", authAppId)
+	}
+	if !m.needRefresh(token) {
+		return token.AppAuthToken, nil
+	}
+	newToken, err := m.refreshIfNeeded(ctx, authAppId)
+	if err != nil {
+		if m.onRefreshFailure != nil {
+			m.onRefreshFailure(authAppId, err)
+		}
+		if token.ExpiresAt.IsZero() || time.Now().Before(token.ExpiresAt) {
+			return token.AppAuthToken, nil
+		}
+		return "", err
+	}
+	return newToken.AppAuthToken, nil
+}
+
+// Client 返回代商户调用的 client，app_auth_token 为该商户的有效令牌，不修改服务商 client
+func (m *AppAuthTokenManager) Client(ctx context.Context, authAppId string) (client *Client, err error) {
+	token, err := m.Token(ctx, authAppId)
+	if err != nil {
+		return nil, err
+	}
+	return m.client.WithOptions(WithAppAuthToken(token)), nil
+}
+
+// Context 返回携带商户有效令牌的 ctx，使用该 ctx 通过服务商 client 发起的请求均代该商户调用
+func (m *AppAuthTokenManager) Context(ctx context.Context, authAppId string) (context.Context, error) {
+	token, err := m.Token(ctx, authAppId)
+	if err != nil {
+		return nil, err
+	}
+	return ContextWithOptions(ctx, WithAppAuthToken(token)), nil
+}
+
+// HandleNotify 处理应用授权变更通知（授权成功、令牌刷新、取消授权）
+// bm：通知参数，请先调用 alipay.VerifySign() 或 alipay.VerifySignWithCert() 验签，内容加密时请先调用 alipay.DecryptNotifyBizContent() 解密
+// 返回参数notify：解析后的通知内容
+func (m *AppAuthTokenManager) HandleNotify(ctx context.Context, bm gopay.BodyMap) (notify *AppAuthNotify, err error) {
+	notify, err = ParseAppAuthNotify(bm)
+	if err != nil {
+		return nil, err
+	}
+	detail := notify.BizContent.Detail
+	if detail == nil || detail.AuthAppId == "" {
+		return notify, nil
+	}
+	if notify.MsgMethod == notifyAppAuthCancelled {
+		return notify, m.store.Delete(ctx, detail.AuthAppId)
+	}
+	if detail.AppAuthToken == "" {
+		return notify, nil
+	}
+	token := &AppAuthTokenInfo{
+		AuthAppId:       detail.AuthAppId,
+		UserId:          detail.UserId,
+		AppAuthToken:    detail.AppAuthToken,
+		AppRefreshToken: detail.AppRefreshToken,
+	}
+	now := time.Now()
+	if detail.ExpiresIn > 0 {
+		token.ExpiresAt = now.Add(time.Duration(detail.ExpiresIn) * time.Second)
+	}
+	if detail.ReExpiresIn > 0 {
+		token.ReExpiresAt = now.Add(time.Duration(detail.ReExpiresIn) * time.Second)
+	}
+	return notify, m.store.Set(ctx, token)
+}
+
+// Start 按检查间隔定时刷新即将过期的商户令牌
+// ctx 取消或调用 Stop() 后停止
+func (m *AppAuthTokenManager) Start(ctx context.Context) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.cancel != nil {
+		return errors.New("app auth token manager already started")
+	}
+	ctx, m.cancel = context.WithCancel(ctx)
+	m.done = make(chan struct{})
+	go m.loop(ctx, m.done)
+	return nil
+}
+
+// Stop 停止定时刷新，并等待协程退出
+func (m *AppAuthTokenManager) Stop() {
+	m.mu.Lock()
+	cancel, done := m.cancel, m.done
+	m.cancel, m.done = nil, nil
+	m.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// RefreshAll 立即刷新全部即将过期的商户令牌
+func (m *AppAuthTokenManager) RefreshAll(ctx context.Context) (err error) {
+	tokens, err := m.store.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if !m.needRefresh(token) {
+			continue
+		}
+		if _, e := m.refreshIfNeeded(ctx, token.AuthAppId); e != nil {
+			if m.onRefreshFailure != nil {
+				m.onRefreshFailure(token.AuthAppId, e)
+			}
+			err = e
+		}
+	}
+	return err
+}
+
+func (m *AppAuthTokenManager) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.tick(ctx)
+		}
+	}
+}
+
+func (m *AppAuthTokenManager) tick(ctx context.Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			buf := make([]byte, 64<<10)
+			buf = buf[:runtime.Stack(buf, false)]
+			xlog.Errorf("AppAuthTokenManager: panic recovered: %s\n%s", r, buf)
+		}
+	}()
+	if err := m.RefreshAll(ctx); err != nil {
+		xlog.Errorf("AppAuthTokenManager.RefreshAll(), err:%+v", err)
+	}
+}
+
+func (m *AppAuthTokenManager) needRefresh(token *AppAuthTokenInfo) bool {
+	return !token.ExpiresAt.IsZero() && time.Until(token.ExpiresAt) < m.refreshAhead
+}
+
+// 加锁后重新读取令牌，已被其他协程刷新时直接返回，避免重复刷新
+func (m *AppAuthTokenManager) refreshIfNeeded(ctx context.Context, authAppId string) (token *AppAuthTokenInfo, err error) {
+	m.refreshMu.Lock()
+	defer m.refreshMu.Unlock()
+	if token, err = m.store.Get(ctx, authAppId); err != nil {
+		return nil, err
+	}
+	if token != nil && !m.needRefresh(token) {
+		return token, nil
+	}
+	return m.refresh(ctx, token, authAppId)
+}
+
+func (m *AppAuthTokenManager) refresh(ctx context.Context, old *AppAuthTokenInfo, authAppId string) (token *AppAuthTokenInfo, err error) {
+	if old == nil || old.AppRefreshToken == "" {
+		return nil, fmt.Errorf("auth_app_id [%s] app_refresh_token not found", authAppId)
+	}
+	if !old.ReExpiresAt.IsZero() && time.Now().After(old.ReExpiresAt) {
+		return nil, fmt.Errorf("auth_app_id [%s] app_refresh_token expired, merchant needs to re-authorize", authAppId)
+	}
+	bm := make(gopay.BodyMap)
+	bm.Set("grant_type", "refresh_token").
+		Set("refresh_token", old.AppRefreshToken)
+	tokens, err := m.requestToken(ctx, bm)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tokens {
+		if t.AuthAppId == authAppId {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("auth_app_id [%s] not found in refresh response", authAppId)
+}
+
+// 换取或刷新令牌，并保存到 store
+func (m *AppAuthTokenManager) requestToken(ctx context.Context, bm gopay.BodyMap) (tokens []*AppAuthTokenInfo, err error) {
+	// 服务商自身调用，忽略 client 配置的 app_auth_token
+	aliRsp, err := m.client.WithOptions(WithAppAuthToken("")).OpenAuthTokenApp(ctx, bm)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	items := aliRsp.Response.Tokens
+	if len(items) == 0 {
+		items = []*Token{{
+			AuthAppId:       aliRsp.Response.AuthAppId,
+			AppAuthToken:    aliRsp.Response.AppAuthToken,
+			ExpiresIn:       aliRsp.Response.ExpiresIn,
+			AppRefreshToken: aliRsp.Response.AppRefreshToken,
+			ReExpiresIn:     aliRsp.Response.ReExpiresIn,
+			UserId:          aliRsp.Response.UserId,
+		}}
+	}
+	tokens = make([]*AppAuthTokenInfo, 0, len(items))
+	for _, v := range items {
+		if v == nil || v.AuthAppId == "" || v.AppAuthToken == "" {
+			continue
+		}
+		token := &AppAuthTokenInfo{
+			AuthAppId:       v.AuthAppId,
+			UserId:          v.UserId,
+			AppAuthToken:    v.AppAuthToken,
+			AppRefreshToken: v.AppRefreshToken,
+		}
+		if v.ExpiresIn > 0 {
+			token.ExpiresAt = now.Add(time.Duration(v.ExpiresIn) * time.Second)
+		}
+		if v.ReExpiresIn > 0 {
+			token.ReExpiresAt = now.Add(time.Duration(v.ReExpiresIn) * time.Second)
+		}
+		if err = m.store.Set(ctx, token); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("[%w], app_auth_token is empty", gopay.UnmarshalErr)
+	}
+	return tokens, nil
+}
+
+// ParseAppAuthNotify 解析应用授权变更通知参数
+func ParseAppAuthNotify(bm gopay.BodyMap) (notify *AppAuthNotify, err error) {
+	notify = &AppAuthNotify{
+		NotifyId:     bm.GetString("notify_id"),
+		UtcTimestamp: bm.GetString("utc_timestamp"),
+		MsgMethod:    bm.GetString("msg_method"),
+		AppId:        bm.GetString("app_id"),
+		Version:      bm.GetString("version"),
+		NotifyType:   bm.GetString("notify_type"),
+	}
+	if err = unmarshalBizContent(bm, &notify.BizContent); err != nil {
+		return nil, err
+	}
+	return notify, nil
+}
@@ -0,0 +1,63 @@
+package alipay
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/misu99/gopay"
+)
+
+func TestAppAuthTokenManager(t *testing.T) {
+	c := &Client{AppId: "appid", Charset: UTF8, SignType: RSA2, privateKey: client.privateKey}
+	m := NewAppAuthTokenManager(c, nil)
+
+	if _, err := m.Token(ctx, "2019090800000001"); err == nil {
+		t.Fatal("Token() should return error when not authorized")
+	}
+	// 授权成功通知
+	bm := make(gopay.BodyMap)
+	bm.Set("notify_id", "2019090800222083402058890501").
+		Set("msg_method", "alipay.open.auth.token.app").
+		Set("biz_content", `{"notify_context":{"trigger":"user"},"detail":{"auth_app_id":"2019090800000001","user_id":"2088102150527498","app_auth_token":"token1","app_refresh_token":"refresh1","expires_in":31536000,"re_expires_in":32140800}}`)
+	notify, err := m.HandleNotify(ctx, bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if notify.BizContent.Detail.AppAuthToken != "token1" {
+		t.Fatalf("HandleNotify() = %+v", notify.BizContent.Detail)
+	}
+	token, err := m.Token(ctx, "2019090800000001")
+	if err != nil || token != "token1" {
+		t.Fatalf("Token() = %s, %v", token, err)
+	}
+	merchant, err := m.Client(ctx, "2019090800000001")
+	if err != nil || merchant.AppAuthToken != "token1" || c.AppAuthToken != "" {
+		t.Fatalf("Client() = %+v, %v", merchant, err)
+	}
+	mctx, err := m.Context(context.Background(), "2019090800000001")
+	if err != nil || c.withContext(mctx).AppAuthToken != "token1" {
+		t.Fatalf("Context() err: %v", err)
+	}
+
+	// 令牌即将过期且刷新失败时，返回未过期的原令牌
+	info, _ := m.Store().Get(ctx, "2019090800000001")
+	info.AppRefreshToken = ""
+	info.ExpiresAt = time.Now().Add(time.Hour)
+	_ = m.Store().Set(ctx, info)
+	var failed string
+	m.OnRefreshFailure(func(authAppId string, err error) { failed = authAppId })
+	if token, err = m.Token(ctx, "2019090800000001"); err != nil || token != "token1" || failed != "2019090800000001" {
+		t.Fatalf("Token() = %s, %v, failed: %s", token, err, failed)
+	}
+
+	// 取消授权通知
+	bm.Set("msg_method", "alipay.open.auth.appauth.cancelled").
+		Set("biz_content", `{"detail":{"auth_app_id":"2019090800000001","user_id":"2088102150527498","auth_time":1567928623000}}`)
+	if _, err = m.HandleNotify(ctx, bm); err != nil {
+		t.Fatal(err)
+	}
+	if tokens, _ := m.Store().List(ctx); len(tokens) != 0 {
+		t.Fatalf("token should be deleted after cancelled: %+v", tokens)
+	}
+}
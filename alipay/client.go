@@ -28,10 +28,15 @@ type Client struct {
 	IsProd             bool
 	bodySize           int // http response body size(MB), default is 10MB
 	privateKey         *rsa.PrivateKey
-	aliPayPublicKey    *rsa.PublicKey // 支付宝证书公钥内容 alipayPublicCert.crt
+	aliPayPublicKey    *rsa.PublicKey // 支付宝公钥（公钥模式）或支付宝公钥证书 alipayPublicCert.crt 中的公钥（证书模式）
 	autoSign           bool
+	autoSignByKey      bool             // 自动验签是否为公钥模式
+	aesKey             []byte           // 接口内容加密密钥
+	timeout            time.Duration    // http 请求超时时间
+	publicCerts        *publicCertStore // 支付宝公钥证书，按 alipay_cert_sn 保存
 	DebugSwitch        gopay.DebugSwitch
 	location           *time.Location
+	gatewayUrl         string // 网关地址，为空时按 IsProd 使用正式或沙箱环境
 }
 
 // 初始化支付宝客户端
@@ -55,12 +60,13 @@ func NewClient(appid, privateKey string, isProd bool) (client *Client, err error
 		IsProd:      isProd,
 		privateKey:  priKey,
 		DebugSwitch: gopay.DebugOff,
+		publicCerts: newPublicCertStore(),
 	}
 	return client, nil
 }
 
-// 开启请求完自动验签功能（默认不开启，推荐开启，只支持证书模式）
-// 注意：只支持证书模式
+// 开启请求完自动验签功能（默认不开启，推荐开启，公钥证书模式）
+// 注意：公钥模式请使用 client.AutoVerifySignByPublicKey()
 // alipayPublicKeyContent：支付宝公钥证书文件内容[]byte
 func (a *Client) AutoVerifySign(alipayPublicKeyContent []byte) {
 	pubKey, err := xpem.DecodePublicKey(alipayPublicKeyContent)
@@ -70,9 +76,28 @@ func (a *Client) AutoVerifySign(alipayPublicKeyContent []byte) {
 	if pubKey != nil {
 		a.aliPayPublicKey = pubKey
 		a.autoSign = true
+		a.autoSignByKey = false
+		if sn, err := GetCertSN(alipayPublicKeyContent); err == nil {
+			a.publicCerts.set(sn, pubKey)
+		}
 	}
 }
 
+// AutoVerifySignByPublicKey 开启请求完自动验签功能（默认不开启，推荐开启，公钥模式）
+// 注意：开启后所有接口的同步响应均会自动验签，验签失败返回 gopay.VerifySignatureErr，
+// 缺少 sign 返回 gopay.MissSignatureErr，响应中出现 alipay_cert_sn 返回 gopay.CertNotMatchErr
+// alipayPublicKey：支付宝平台获取的支付宝公钥
+func (a *Client) AutoVerifySignByPublicKey(alipayPublicKey string) (err error) {
+	pubKey, err := xpem.DecodePublicKey([]byte(xrsa.FormatAlipayPublicKey(alipayPublicKey)))
+	if err != nil {
+		return fmt.Errorf("AutoVerifySignByPublicKey(%s)：%w", alipayPublicKey, err)
+	}
+	a.aliPayPublicKey = pubKey
+	a.autoSign = true
+	a.autoSignByKey = true
+	return nil
+}
+
 // SetBodySize 设置http response body size(MB)
 func (a *Client) SetBodySize(sizeMB int) {
 	if sizeMB > 0 {
@@ -85,13 +110,14 @@ func (a *Client) SetBodySize(sizeMB int) {
 // 示例：请参考 client_test.go 的 TestClient_PostAliPayAPISelf() 方法
 func (a *Client) PostAliPayAPISelf(ctx context.Context, bm gopay.BodyMap, method string, aliRsp any) (err error) {
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, method); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, method); err != nil && !isVerifyErr(err) {
 		return err
 	}
+	verifyErr := err
 	if err = json.Unmarshal(bs, aliRsp); err != nil {
 		return err
 	}
-	return nil
+	return verifyErr
 }
 
 // Deprecated
@@ -120,6 +146,10 @@ func (a *Client) RequestParam(bm gopay.BodyMap, method string) (string, error) {
 
 	// check public parameter
 	a.checkPublicParam(bm)
+	// 接口内容加密
+	if err = a.encryptBizContent(bm); err != nil {
+		return "", err
+	}
 
 	// check sign
 	if bm.GetString("sign") == "" {
@@ -152,23 +182,30 @@ func (a *Client) PostAliPayAPISelfV2(ctx context.Context, bm gopay.BodyMap, meth
 		bm.Set("biz_content", string(bodyBs))
 	}
 
-	if bs, err = a.doAliPaySelf(ctx, bm, method); err != nil {
+	if bs, err = a.doAliPaySelf(ctx, bm, method); err != nil && !isVerifyErr(err) {
 		return err
 	}
+	verifyErr := err
 	if err = json.Unmarshal(bs, aliRsp); err != nil {
 		return err
 	}
-	return nil
+	return verifyErr
 }
 
 // 向支付宝发送自定义请求
 func (a *Client) doAliPaySelf(ctx context.Context, bm gopay.BodyMap, method string) (bs []byte, err error) {
+	// 单次请求参数
+	a = a.withContext(ctx)
 	var (
 		url, sign string
 	)
 	bm.Set("method", method)
 	// check public parameter
 	a.checkPublicParam(bm)
+	// 接口内容加密
+	if err = a.encryptBizContent(bm); err != nil {
+		return nil, err
+	}
 	// check sign
 	if bm.GetString("sign") == "" {
 		sign, err = a.getRsaSign(bm, bm.GetString("sign_type"), a.privateKey)
@@ -181,15 +218,8 @@ func (a *Client) doAliPaySelf(ctx context.Context, bm gopay.BodyMap, method stri
 		xlog.Debugf("Alipay_Request: %s", bm.JsonBody())
 	}
 
-	httpClient := xhttp.NewClient()
-	if a.bodySize > 0 {
-		httpClient.SetBodySize(a.bodySize)
-	}
-	if a.IsProd {
-		url = baseUrlUtf8
-	} else {
-		url = sandboxBaseUrlUtf8
-	}
+	httpClient := a.newHttpClient()
+	url = a.gateway()
 	res, bs, err := httpClient.Type(xhttp.TypeForm).Post(url).SendString(bm.EncodeURLParams()).EndBytes(ctx)
 	if err != nil {
 		return nil, err
@@ -200,11 +230,16 @@ func (a *Client) doAliPaySelf(ctx context.Context, bm gopay.BodyMap, method stri
 	if res.StatusCode != 200 {
 		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
 	}
-	return bs, nil
+	if err = a.autoVerifySign(ctx, bs, method); err != nil && !isVerifyErr(err) {
+		return nil, err
+	}
+	return a.decryptVerifiedResponse(bs, err)
 }
 
 // 向支付宝发送请求
 func (a *Client) doAliPay(ctx context.Context, bm gopay.BodyMap, method string, authToken ...string) (bs []byte, err error) {
+	// 单次请求参数
+	a = a.withContext(ctx)
 	var (
 		bizContent, url string
 		bodyBs          []byte
@@ -241,14 +276,8 @@ func (a *Client) doAliPay(ctx context.Context, bm gopay.BodyMap, method string,
 		}
 		return []byte(baseUrl + "?" + param), nil
 	default:
-		httpClient := xhttp.NewClient()
-		if a.bodySize > 0 {
-			httpClient.SetBodySize(a.bodySize)
-		}
-		url = baseUrlUtf8
-		if !a.IsProd {
-			url = sandboxBaseUrlUtf8
-		}
+		httpClient := a.newHttpClient()
+		url = a.gateway()
 		res, bs, err := httpClient.Type(xhttp.TypeForm).Post(url).SendString(param).EndBytes(ctx)
 		if err != nil {
 			return nil, err
@@ -259,12 +288,17 @@ func (a *Client) doAliPay(ctx context.Context, bm gopay.BodyMap, method string,
 		if res.StatusCode != 200 {
 			return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
 		}
-		return bs, nil
+		if err = a.autoVerifySign(ctx, bs, method); err != nil && !isVerifyErr(err) {
+			return nil, err
+		}
+		return a.decryptVerifiedResponse(bs, err)
 	}
 }
 
 // 向支付宝发送请求
 func (a *Client) DoAliPay(ctx context.Context, bm gopay.BodyMap, method string, authToken ...string) (bs []byte, err error) {
+	// 单次请求参数
+	a = a.withContext(ctx)
 	var (
 		bizContent, url string
 		bodyBs          []byte
@@ -301,14 +335,8 @@ func (a *Client) DoAliPay(ctx context.Context, bm gopay.BodyMap, method string,
 		}
 		return []byte(baseUrl + "?" + param), nil
 	default:
-		httpClient := xhttp.NewClient()
-		if a.bodySize > 0 {
-			httpClient.SetBodySize(a.bodySize)
-		}
-		url = baseUrlUtf8
-		if !a.IsProd {
-			url = sandboxBaseUrlUtf8
-		}
+		httpClient := a.newHttpClient()
+		url = a.gateway()
 		res, bs, err := httpClient.Type(xhttp.TypeForm).Post(url).SendString(param).EndBytes(ctx)
 		if err != nil {
 			return nil, err
@@ -319,12 +347,17 @@ func (a *Client) DoAliPay(ctx context.Context, bm gopay.BodyMap, method string,
 		if res.StatusCode != 200 {
 			return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
 		}
-		return bs, nil
+		if err = a.autoVerifySign(ctx, bs, method); err != nil && !isVerifyErr(err) {
+			return nil, err
+		}
+		return a.decryptVerifiedResponse(bs, err)
 	}
 }
 
 // 保持和官方 SDK 命名方式一致
 func (a *Client) PageExecute(ctx context.Context, bm gopay.BodyMap, method string, authToken ...string) (url string, err error) {
+	// 单次请求参数
+	a = a.withContext(ctx)
 	var (
 		bizContent string
 		bodyBs     []byte
@@ -359,6 +392,28 @@ func (a *Client) PageExecute(ctx context.Context, bm gopay.BodyMap, method strin
 	return baseUrl + "?" + param, nil
 }
 
+// 同步请求的网关地址
+func (a *Client) gateway() string {
+	if a.gatewayUrl != util.NULL {
+		return a.gatewayUrl
+	}
+	if a.IsProd {
+		return baseUrlUtf8
+	}
+	return sandboxBaseUrlUtf8
+}
+
+func (a *Client) newHttpClient() *xhttp.Client {
+	httpClient := xhttp.NewClient()
+	if a.bodySize > 0 {
+		httpClient.SetBodySize(a.bodySize)
+	}
+	if a.timeout > 0 {
+		httpClient.SetTimeout(a.timeout)
+	}
+	return httpClient
+}
+
 // 公共参数处理
 func (a *Client) pubParamsHandle(bm gopay.BodyMap, method, bizContent string, authToken ...string) (param string, err error) {
 	pubBody := make(gopay.BodyMap)
@@ -411,6 +466,10 @@ func (a *Client) pubParamsHandle(bm gopay.BodyMap, method, bizContent string, au
 	if bizContent != util.NULL {
 		pubBody.Set("biz_content", bizContent)
 	}
+	// 接口内容加密
+	if err = a.encryptBizContent(pubBody); err != nil {
+		return "", err
+	}
 	// sign
 	sign, err := a.getRsaSign(pubBody, pubBody.GetString("sign_type"), a.privateKey)
 	if err != nil {
@@ -457,6 +516,18 @@ func (a *Client) checkPublicParam(bm gopay.BodyMap) {
 
 // 文件上传
 func (a *Client) FileRequest(ctx context.Context, bm gopay.BodyMap, file *util.File, method string) (bs []byte, err error) {
+	return a.fileRequest(ctx, bm, file, method)
+}
+
+// 文件上传（流式上传），文件内容按块读取，不整体载入内存
+func (a *Client) FileReaderRequest(ctx context.Context, bm gopay.BodyMap, file *util.FileReader, method string) (bs []byte, err error) {
+	return a.fileRequest(ctx, bm, file, method)
+}
+
+// file：*util.File 或 *util.FileReader
+func (a *Client) fileRequest(ctx context.Context, bm gopay.BodyMap, file any, method string) (bs []byte, err error) {
+	// 单次请求参数
+	a = a.withContext(ctx)
 	var (
 		bodyStr string
 		bodyBs  []byte
@@ -518,8 +589,8 @@ func (a *Client) FileRequest(ctx context.Context, bm gopay.BodyMap, file *util.F
 	param := pubBody.EncodeURLParams()
 	url := baseUrlUtf8 + "&" + param
 	bm.Reset()
-	bm.SetFormFile("file_content", file)
-	httpClient := xhttp.NewClient()
+	bm.Set("file_content", file)
+	httpClient := a.newHttpClient()
 	res, bs, err := httpClient.Type(xhttp.TypeMultipartFormData).Post(url).
 		SendMultipartBodyMap(bm).EndBytes(ctx)
 	if err != nil {
@@ -531,5 +602,8 @@ func (a *Client) FileRequest(ctx context.Context, bm gopay.BodyMap, file *util.F
 	if res.StatusCode != 200 {
 		return nil, fmt.Errorf("HTTP Request Error, StatusCode = %d", res.StatusCode)
 	}
-	return bs, nil
+	if err = a.autoVerifySign(ctx, bs, method); err != nil && !isVerifyErr(err) {
+		return nil, err
+	}
+	return bs, err
 }
@@ -42,7 +42,7 @@ func TestMain(m *testing.M) {
 		SetReturnUrl("https://www.fmm.ink").
 		SetNotifyUrl("https://www.fmm.ink")
 
-	// 自动同步验签（只支持证书模式）
+	// 自动同步验签（证书模式），公钥模式请使用 client.AutoVerifySignByPublicKey()
 	// 传入 支付宝公钥证书 alipayPublicCert.crt 内容
 	client.AutoVerifySign(cert.AlipayPublicContentRSA2)
 
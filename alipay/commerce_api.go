@@ -16,19 +16,22 @@ func (a *Client) CommerceTransportNfccardSend(ctx context.Context, bm gopay.Body
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.commerce.transport.nfccard.send"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.commerce.transport.nfccard.send"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(CommerceTransportNfccardSendRsp)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // alipay.commerce.air.callcenter.trade.apply(航司电话订票待申请接口)
@@ -39,19 +42,22 @@ func (a *Client) CommerceAirCallcenterTradeApply(ctx context.Context, bm gopay.B
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.commerce.air.callcenter.trade.apply"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.commerce.air.callcenter.trade.apply"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(CommerceAirCallcenterTradeApplyRsp)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // alipay.commerce.operation.gamemarketing.benefit.apply(申请权益发放)
@@ -62,19 +68,22 @@ func (a *Client) CommerceBenefitApply(ctx context.Context, bm gopay.BodyMap) (al
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.commerce.operation.gamemarketing.benefit.apply"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.commerce.operation.gamemarketing.benefit.apply"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(CommerceBenefitApplyRsp)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // alipay.commerce.operation.gamemarketing.benefit.verify(权益核销)
@@ -85,17 +94,20 @@ func (a *Client) CommerceBenefitVerify(ctx context.Context, bm gopay.BodyMap) (a
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.commerce.operation.gamemarketing.benefit.verify"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.commerce.operation.gamemarketing.benefit.verify"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(CommerceBenefitVerifyRsp)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
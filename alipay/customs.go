@@ -19,19 +19,22 @@ func (a *Client) TradeCustomsDeclare(ctx context.Context, bm gopay.BodyMap) (ali
 	}
 	var bs []byte
 
-	if bs, err = a.doAliPay(ctx, bm, "alipay.trade.customs.declare"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.trade.customs.declare"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(TradeCustomsDeclareRsp)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // alipay.acquire.customs(报关接口)
@@ -79,7 +82,7 @@ func (a *Client) doAliPayCustoms(ctx context.Context, bm gopay.BodyMap, service
 		xlog.Debugf("Alipay_Request: %s", bm.JsonBody())
 	}
 	// request
-	httpClient := xhttp.NewClient()
+	httpClient := a.newHttpClient()
 	res, bs, err := httpClient.Type(xhttp.TypeForm).Post("https://mapi.alipay.com/gateway.do").SendString(bm.EncodeURLParams()).EndBytes(ctx)
 	if err != nil {
 		return nil, err
@@ -14,19 +14,22 @@ import (
 // 文档地址：https://opendocs.alipay.com/apis/api_15/alipay.data.bill.balance.query
 func (a *Client) DataBillBalanceQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *DataBillBalanceQueryResponse, err error) {
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.data.bill.balance.query"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.data.bill.balance.query"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(DataBillBalanceQueryResponse)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // alipay.data.dataservice.bill.downloadurl.query(查询对账单下载地址)
@@ -37,17 +40,20 @@ func (a *Client) DataBillDownloadUrlQuery(ctx context.Context, bm gopay.BodyMap)
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.data.dataservice.bill.downloadurl.query"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.data.dataservice.bill.downloadurl.query"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(DataBillDownloadUrlQueryResponse)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
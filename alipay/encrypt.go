@@ -0,0 +1,153 @@
+package alipay
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/misu99/gopay"
+	xaes "github.com/misu99/gopay/pkg/aes"
+	"github.com/misu99/gopay/pkg/util"
+)
+
+const (
+	encryptTypeAES = "AES"
+)
+
+// 接口内容加密，AES/CBC/PKCS5Padding，偏移量为 16 位 0
+var aesIv = []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+
+// AESEncrypt 接口内容加密，返回 Base64 密文
+// content：待加密内容，如 biz_content
+// aesKey：接口内容加密密钥，支付宝开放平台获取
+func AESEncrypt(content, aesKey string) (encrypted string, err error) {
+	key, err := decodeAESKey(aesKey)
+	if err != nil {
+		return util.NULL, err
+	}
+	return aesEncrypt(content, key)
+}
+
+// AESDecrypt 接口内容解密
+// encrypted：Base64 密文，如加密后的 biz_content 或同步响应中的 xxx_response
+// aesKey：接口内容加密密钥，支付宝开放平台获取
+func AESDecrypt(encrypted, aesKey string) (content []byte, err error) {
+	key, err := decodeAESKey(aesKey)
+	if err != nil {
+		return nil, err
+	}
+	return aesDecrypt(encrypted, key)
+}
+
+// 解码 Base64 接口内容加密密钥，并校验密钥长度（16、24、32 字节）
+// 注意：错误信息中不包含密钥内容
+func decodeAESKey(aesKey string) (key []byte, err error) {
+	if aesKey == util.NULL {
+		return nil, errors.New("aesKey is empty")
+	}
+	if key, err = base64.StdEncoding.DecodeString(aesKey); err != nil {
+		return nil, fmt.Errorf("aesKey base64 decode error：%w", err)
+	}
+	switch len(key) {
+	case 16, 24, 32:
+		return key, nil
+	}
+	return nil, fmt.Errorf("aesKey length must be 16, 24 or 32 bytes, got %d", len(key))
+}
+
+// DecryptNotifyBizContent 解密异步通知中加密的 biz_content 到 BodyMap
+// 注意：请先使用原始通知参数验签，验签通过后再解密
+// bm：alipay.ParseNotifyToBodyMap() 解析得到的通知参数
+// aesKey：接口内容加密密钥，支付宝开放平台获取
+func DecryptNotifyBizContent(bm gopay.BodyMap, aesKey string) (bizContent gopay.BodyMap, err error) {
+	encrypted := bm.GetString("biz_content")
+	if encrypted == util.NULL {
+		return nil, errors.New("biz_content is empty")
+	}
+	if encryptType := bm.GetString("encrypt_type"); encryptType != util.NULL && encryptType != encryptTypeAES {
+		return nil, fmt.Errorf("encrypt_type [%s] not support", encryptType)
+	}
+	content, err := AESDecrypt(encrypted, aesKey)
+	if err != nil {
+		return nil, err
+	}
+	bizContent = make(gopay.BodyMap)
+	if err = json.Unmarshal(content, &bizContent); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(content))
+	}
+	return bizContent, nil
+}
+
+func aesEncrypt(content string, key []byte) (encrypted string, err error) {
+	bs, err := xaes.CBCEncrypt([]byte(content), key, aesIv)
+	if err != nil {
+		return util.NULL, fmt.Errorf("xaes.CBCEncrypt：%w", err)
+	}
+	return base64.StdEncoding.EncodeToString(bs), nil
+}
+
+func aesDecrypt(encrypted string, key []byte) (content []byte, err error) {
+	secretData, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return nil, fmt.Errorf("base64.StdEncoding.DecodeString(%s)：%w", encrypted, err)
+	}
+	if len(secretData) == 0 || len(secretData)%len(aesIv) != 0 {
+		return nil, fmt.Errorf("encrypted content is error: %s", encrypted)
+	}
+	if content, err = xaes.CBCDecrypt(secretData, key, aesIv); err != nil {
+		return nil, fmt.Errorf("xaes.CBCDecrypt：%w", err)
+	}
+	return content, nil
+}
+
+// 设置了接口内容加密密钥时，加密 biz_content 并设置 encrypt_type
+func (a *Client) encryptBizContent(bm gopay.BodyMap) (err error) {
+	bizContent := bm.GetString("biz_content")
+	if a.aesKey == nil || bizContent == util.NULL || bm.GetString("encrypt_type") != util.NULL {
+		return nil
+	}
+	if bizContent, err = aesEncrypt(bizContent, a.aesKey); err != nil {
+		return err
+	}
+	bm.Set("biz_content", bizContent).Set("encrypt_type", encryptTypeAES)
+	return nil
+}
+
+// 设置了接口内容加密密钥时，解密同步响应中加密的 xxx_response，并替换为明文
+// 注意：需在对密文验签之后调用，错误响应不加密，原样返回
+func (a *Client) decryptResponse(bs []byte) ([]byte, error) {
+	if a.aesKey == nil {
+		return bs, nil
+	}
+	rsp := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(bs, &rsp); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	for k, v := range rsp {
+		if !strings.HasSuffix(k, "_response") || len(v) == 0 || v[0] != '"' {
+			continue
+		}
+		var encrypted string
+		if err := json.Unmarshal(v, &encrypted); err != nil {
+			return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+		}
+		content, err := aesDecrypt(encrypted, a.aesKey)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.Replace(bs, v, content, 1), nil
+	}
+	return bs, nil
+}
+
+// 解密同步响应，verifyErr 为自动验签失败的错误，解密成功时连同响应内容一并返回，由调用方解析后返回给开发者
+func (a *Client) decryptVerifiedResponse(bs []byte, verifyErr error) ([]byte, error) {
+	bs, err := a.decryptResponse(bs)
+	if err != nil {
+		return nil, err
+	}
+	return bs, verifyErr
+}
@@ -0,0 +1,92 @@
+package alipay
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/misu99/gopay"
+)
+
+func TestAESEncryptDecrypt(t *testing.T) {
+	aesKey := base64.StdEncoding.EncodeToString([]byte("1234567890abcdef"))
+	bizContent := `{"out_trade_no":"GZ201901301040361012","total_amount":"0.01","subject":"商品"}`
+
+	encrypted, err := AESEncrypt(bizContent, aesKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := AESDecrypt(encrypted, aesKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != bizContent {
+		t.Fatalf("AESDecrypt() = %s, want %s", content, bizContent)
+	}
+
+	bm := make(gopay.BodyMap)
+	bm.Set("biz_content", encrypted).Set("encrypt_type", "AES")
+	bz, err := DecryptNotifyBizContent(bm, aesKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bz.GetString("out_trade_no") != "GZ201901301040361012" {
+		t.Fatalf("DecryptNotifyBizContent() = %s", bz.JsonBody())
+	}
+}
+
+func TestClient_decryptResponse(t *testing.T) {
+	aesKey := base64.StdEncoding.EncodeToString([]byte("1234567890abcdef"))
+	c := &Client{}
+	if err := c.SetAESKey(aesKey); err != nil {
+		t.Fatal(err)
+	}
+	plain := `{"code":"10000","msg":"Success","trade_no":"2019082922001432790585537960"}`
+	encrypted, _ := aesEncrypt(plain, c.aesKey)
+
+	bs, err := c.decryptResponse([]byte(`{"alipay_trade_query_response":"` + encrypted + `","sign":"xxx"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"alipay_trade_query_response":` + plain + `,"sign":"xxx"}`; string(bs) != want {
+		t.Fatalf("decryptResponse() = %s, want %s", bs, want)
+	}
+	// 错误响应不加密，原样返回
+	errRsp := `{"alipay_trade_query_response":{"code":"40004","msg":"Business Failed"},"sign":"xxx"}`
+	if bs, err = c.decryptResponse([]byte(errRsp)); err != nil || string(bs) != errRsp {
+		t.Fatalf("decryptResponse() = %s, err: %v", bs, err)
+	}
+}
+
+func TestSetAESKey(t *testing.T) {
+	c := &Client{}
+	for _, size := range []int{16, 24, 32} {
+		if err := c.SetAESKey(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", size)))); err != nil || len(c.aesKey) != size {
+			t.Fatalf("SetAESKey(%d bytes) err: %v", size, err)
+		}
+	}
+	for _, aesKey := range []string{
+		"",
+		"not-base64-secret!",
+		base64.StdEncoding.EncodeToString([]byte("short-secret")),
+		base64.StdEncoding.EncodeToString([]byte(strings.Repeat("s", 33))),
+	} {
+		err := c.SetAESKey(aesKey)
+		if err == nil {
+			t.Fatalf("SetAESKey(%q) should return error", aesKey)
+		}
+		// 错误信息中不包含密钥
+		if aesKey != "" && strings.Contains(err.Error(), aesKey) {
+			t.Fatalf("SetAESKey() error leaks key: %v", err)
+		}
+		if len(c.aesKey) != 32 {
+			t.Fatalf("invalid key should not replace the previous key, len: %d", len(c.aesKey))
+		}
+		if _, err = AESEncrypt("content", aesKey); err == nil || (aesKey != "" && strings.Contains(err.Error(), aesKey)) {
+			t.Fatalf("AESEncrypt() err: %v", err)
+		}
+		if _, err = AESDecrypt("Y29udGVudA==", aesKey); err == nil || (aesKey != "" && strings.Contains(err.Error(), aesKey)) {
+			t.Fatalf("AESDecrypt() err: %v", err)
+		}
+	}
+}
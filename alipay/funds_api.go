@@ -3,6 +3,7 @@ package alipay
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/misu99/gopay"
@@ -17,19 +18,22 @@ func (a *Client) FundTransUniTransfer(ctx context.Context, bm gopay.BodyMap) (al
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.trans.uni.transfer"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.trans.uni.transfer"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(FundTransUniTransferResponse)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // alipay.fund.account.query(支付宝资金账户资产查询接口)
@@ -40,38 +44,44 @@ func (a *Client) FundAccountQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.account.query"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.account.query"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(FundAccountQueryResponse)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // alipay.fund.trans.common.query(转账业务单据查询接口)
 // 文档地址：https://opendocs.alipay.com/open/02byup
 func (a *Client) FundTransCommonQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundTransCommonQueryResponse, err error) {
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.trans.common.query"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.trans.common.query"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(FundTransCommonQueryResponse)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // alipay.fund.trans.order.query(查询转账订单接口)
@@ -85,20 +95,23 @@ func (a *Client) FundTransOrderQuery(ctx context.Context, bm gopay.BodyMap) (ali
 	}
 
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.trans.order.query"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.trans.order.query"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 
 	aliRsp = new(FundTransOrderQueryResponse)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // alipay.fund.trans.refund(资金退回接口)
@@ -109,19 +122,22 @@ func (a *Client) FundTransRefund(ctx context.Context, bm gopay.BodyMap) (aliRsp
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.trans.refund"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.trans.refund"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(FundTransRefundResponse)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // alipay.fund.auth.order.freeze(资金授权冻结接口)
@@ -132,19 +148,22 @@ func (a *Client) FundAuthOrderFreeze(ctx context.Context, bm gopay.BodyMap) (ali
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.auth.order.freeze"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.auth.order.freeze"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(FundAuthOrderFreezeResponse)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // alipay.fund.auth.order.voucher.create(资金授权发码接口)
@@ -155,19 +174,22 @@ func (a *Client) FundAuthOrderVoucherCreate(ctx context.Context, bm gopay.BodyMa
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.auth.order.voucher.create"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.auth.order.voucher.create"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(FundAuthOrderVoucherCreateResponse)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // alipay.fund.auth.order.app.freeze(线上资金授权冻结接口)
@@ -193,38 +215,44 @@ func (a *Client) FundAuthOrderUnfreeze(ctx context.Context, bm gopay.BodyMap) (a
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.auth.order.unfreeze"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.auth.order.unfreeze"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(FundAuthOrderUnfreezeResponse)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // alipay.fund.auth.operation.detail.query(资金授权操作查询接口)
 // 文档地址: https://opendocs.alipay.com/open/02fkbd
 func (a *Client) FundAuthOperationDetailQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundAuthOperationDetailQueryResponse, err error) {
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.auth.operation.detail.query"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.auth.operation.detail.query"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(FundAuthOperationDetailQueryResponse)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // alipay.fund.auth.operation.cancel(资金授权撤销接口)
@@ -235,19 +263,22 @@ func (a *Client) FundAuthOperationCancel(ctx context.Context, bm gopay.BodyMap)
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.auth.operation.cancel"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.auth.operation.cancel"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(FundAuthOperationCancelResponse)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // alipay.fund.batch.create(批次下单接口)
@@ -258,19 +289,22 @@ func (a *Client) FundBatchCreate(ctx context.Context, bm gopay.BodyMap) (aliRsp
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.batch.create"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.batch.create"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(FundBatchCreateResponse)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // alipay.fund.batch.close(批量转账关单接口)
@@ -281,19 +315,22 @@ func (a *Client) FundBatchClose(ctx context.Context, bm gopay.BodyMap) (aliRsp *
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.batch.close"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.batch.close"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(FundBatchCloseResponse)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // alipay.fund.batch.detail.query(批量转账明细查询接口)
@@ -304,19 +341,22 @@ func (a *Client) FundBatchDetailQuery(ctx context.Context, bm gopay.BodyMap) (al
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.batch.detail.query"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.batch.detail.query"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(FundBatchDetailQueryResponse)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // alipay.fund.trans.app.pay(现金红包无线支付接口)
@@ -327,19 +367,22 @@ func (a *Client) FundTransAppPay(ctx context.Context, bm gopay.BodyMap) (aliRsp
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.trans.app.pay"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.trans.app.pay"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(FundTransAppPayResponse)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // alipay.fund.trans.payee.bind.query(资金收款账号绑定关系查询)
@@ -350,19 +393,22 @@ func (a *Client) FundTransPayeeBindQuery(ctx context.Context, bm gopay.BodyMap)
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.trans.payee.bind.query"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.trans.payee.bind.query"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(FundTransPayeeBindQueryRsp)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
 }
 
 // alipay.fund.trans.page.pay(资金转账页面支付接口)
@@ -373,17 +419,129 @@ func (a *Client) FundTransPagePay(ctx context.Context, bm gopay.BodyMap) (aliRsp
 		return nil, err
 	}
 	var bs []byte
-	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.trans.page.pay"); err != nil {
+	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.trans.page.pay"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(FundTransPagePayRsp)
 	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
 		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
+	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
+		return aliRsp, err
+	}
+	return aliRsp, nil
+}
+
+// alipay.fund.accountbook.create(资金记账本开通)
+func (a *Client) FundAccountBookCreate(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundAccountBookCreateRsp, err error) {
+	err = bm.CheckEmptyError("out_biz_no", "merchant_user_id", "merchant_user_type", "scene_code")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.accountbook.create"); err != nil && !isVerifyErr(err) {
+		return nil, err
+	}
+	verifyErr := err
+	aliRsp = new(FundAccountBookCreateRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
+		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
+	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
 		return aliRsp, err
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
+}
+
+// alipay.fund.accountbook.query(资金记账本查询)
+func (a *Client) FundAccountBookQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundAccountBookQueryRsp, err error) {
+	err = bm.CheckEmptyError("account_book_id", "scene_code")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.accountbook.query"); err != nil && !isVerifyErr(err) {
+		return nil, err
+	}
+	verifyErr := err
+	aliRsp = new(FundAccountBookQueryRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
+		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
+	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
+	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
+		return aliRsp, err
+	}
+	return aliRsp, nil
+}
+
+// alipay.fund.expandindirect.create(安全发间连商户进件)
+func (a *Client) FundExpandIndirectCreate(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundExpandIndirectCreateRsp, err error) {
+	err = bm.CheckEmptyError("out_biz_no")
+	if err != nil {
+		return nil, err
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.expandindirect.create"); err != nil && !isVerifyErr(err) {
+		return nil, err
+	}
+	verifyErr := err
+	aliRsp = new(FundExpandIndirectCreateRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
+		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
+	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
+	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
+		return aliRsp, err
+	}
+	return aliRsp, nil
+}
+
+// alipay.fund.expandindirect.query(安全发间连商户进件查询)
+func (a *Client) FundExpandIndirectQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *FundExpandIndirectQueryRsp, err error) {
+	if bm.GetString("out_biz_no") == util.NULL && bm.GetString("order_id") == util.NULL {
+		return nil, errors.New("out_biz_no and order_id are not allowed to be null at the same time")
+	}
+	var bs []byte
+	if bs, err = a.doAliPay(ctx, bm, "alipay.fund.expandindirect.query"); err != nil && !isVerifyErr(err) {
+		return nil, err
+	}
+	verifyErr := err
+	aliRsp = new(FundExpandIndirectQueryRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil || aliRsp.Response == nil {
+		return nil, fmt.Errorf("[%w], bytes: %s", gopay.UnmarshalErr, string(bs))
+	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
+	if err = bizErrCheck(aliRsp.Response.ErrorResponse); err != nil {
+		return aliRsp, err
+	}
+	return aliRsp, nil
+}
+
+// alipay.fund.trans.page.pay(资金记账本充值)
+// 返回参数payUrl：充值页面URL，payee_info 中 identity 为记账本ID（account_book_id），identity_type 为 ACCOUNT_BOOK_ID
+func (a *Client) FundAccountBookDeposit(ctx context.Context, bm gopay.BodyMap) (payUrl string, err error) {
+	err = bm.CheckEmptyError("out_biz_no", "trans_amount", "product_code", "biz_scene", "payee_info")
+	if err != nil {
+		return util.NULL, err
+	}
+	return a.PageExecute(ctx, bm, "alipay.fund.trans.page.pay")
 }
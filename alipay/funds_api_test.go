@@ -1,6 +1,7 @@
 package alipay
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/misu99/gopay"
@@ -103,3 +104,37 @@ func TestClient_FundTransPagePay(t *testing.T) {
 	}
 	xlog.Debug("aliRsp:", *aliRsp)
 }
+
+func TestClient_FundAccountBookDeposit(t *testing.T) {
+	bm := make(gopay.BodyMap)
+	bm.Set("out_biz_no", "2018062800002").
+		Set("trans_amount", "100.00").
+		Set("product_code", "FUND_ACCOUNT_BOOK").
+		Set("biz_scene", "SATF_DEPOSIT").
+		SetBodyMap("payee_info", func(b gopay.BodyMap) {
+			b.Set("identity", "2088301409188095").
+				Set("identity_type", "ACCOUNT_BOOK_ID")
+		})
+
+	payUrl, err := client.FundAccountBookDeposit(ctx, bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(payUrl, "method=alipay.fund.trans.page.pay") {
+		t.Fatalf("FundAccountBookDeposit() = %s", payUrl)
+	}
+}
+
+func TestParseFundAccountBookNotify(t *testing.T) {
+	bm := make(gopay.BodyMap)
+	bm.Set("notify_id", "2020092100222161127031571487658").
+		Set("msg_method", NotifyAccountBookChanged).
+		Set("biz_content", `{"account_book_id":"2088301409188095","scene_code":"SATF_FUND_BOOK","action_type":"DEPOSIT","amount":"100.00","available_amount":"300.00"}`)
+	notify, err := ParseFundAccountBookNotify(bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if notify.BizContent.AccountBookId != "2088301409188095" || notify.BizContent.AvailableAmount != "300.00" {
+		t.Fatalf("ParseFundAccountBookNotify() = %+v", notify.BizContent)
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 
 	"github.com/misu99/gopay"
 	"github.com/misu99/gopay/pkg/util"
 )
 
+const merchantItemFileMaxSize = 10 << 20 // 商品文件大小限制 10MB
+
 // alipay.merchant.item.file.upload(商品文件上传接口)
 // 文档地址：https://opendocs.alipay.com/apis/api_4/alipay.merchant.item.file.upload
 func (a *Client) MerchantItemFileUpload(ctx context.Context, file *util.File) (aliRsp *MerchantItemFileUploadRsp, err error) {
@@ -16,18 +19,69 @@ func (a *Client) MerchantItemFileUpload(ctx context.Context, file *util.File) (a
 	bm.Set("scene", "SYNC_ORDER") //素材固定值
 
 	var bs []byte
-	if bs, err = a.FileRequest(ctx, bm, file, "alipay.merchant.item.file.upload"); err != nil {
+	if bs, err = a.FileRequest(ctx, bm, file, "alipay.merchant.item.file.upload"); err != nil && !isVerifyErr(err) {
 		return nil, err
 	}
+	verifyErr := err
 	aliRsp = new(MerchantItemFileUploadRsp)
 	if err = json.Unmarshal(bs, aliRsp); err != nil {
 		return nil, err
 	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	if verifyErr != nil {
+		return aliRsp, verifyErr
+	}
 	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
 		info := aliRsp.Response
 		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
 	}
-	signData, signDataErr := a.getSignData(bs, aliRsp.AlipayCertSn)
-	aliRsp.SignData = signData
-	return aliRsp, a.autoVerifySignByCert(aliRsp.Sign, signData, signDataErr)
+	return aliRsp, nil
+}
+
+// alipay.merchant.item.file.upload(商品文件上传接口)，流式上传
+// 注意：文件不能超过10MB
+// file：util.NewFileReader() 或 util.OpenFile() 初始化
+// 文档地址：https://opendocs.alipay.com/apis/api_4/alipay.merchant.item.file.upload
+func (a *Client) MerchantItemFileUploadStream(ctx context.Context, file *util.FileReader) (aliRsp *MerchantItemFileUploadRsp, err error) {
+	if file == nil || file.Reader == nil {
+		return nil, fmt.Errorf("[%w]: file", gopay.MissParamErr)
+	}
+	if file.Size > merchantItemFileMaxSize {
+		return nil, fmt.Errorf("file size %d exceeds the limit of %d bytes", file.Size, merchantItemFileMaxSize)
+	}
+	upload := file
+	if file.Size < 0 {
+		upload = util.NewFileReader(file.Name, &sizeLimitReader{r: file.Reader, remain: merchantItemFileMaxSize}, -1)
+	}
+	bm := make(gopay.BodyMap)
+	bm.Set("scene", "SYNC_ORDER") //素材固定值
+
+	var bs []byte
+	if bs, err = a.FileReaderRequest(ctx, bm, upload, "alipay.merchant.item.file.upload"); err != nil {
+		return nil, err
+	}
+	aliRsp = new(MerchantItemFileUploadRsp)
+	if err = json.Unmarshal(bs, aliRsp); err != nil {
+		return nil, err
+	}
+	if aliRsp.Response != nil && aliRsp.Response.Code != "10000" {
+		info := aliRsp.Response
+		return aliRsp, fmt.Errorf(`{"code":"%s","msg":"%s","sub_code":"%s","sub_msg":"%s"}`, info.Code, info.Msg, info.SubCode, info.SubMsg)
+	}
+	aliRsp.SignData, _ = a.getSignData(bs, aliRsp.AlipayCertSn)
+	return aliRsp, nil
+}
+
+// 大小未知的文件，读取超过限制时返回错误，中断上传
+type sizeLimitReader struct {
+	r      io.Reader
+	remain int64
+}
+
+func (l *sizeLimitReader) Read(p []byte) (n int, err error) {
+	n, err = l.r.Read(p)
+	if l.remain -= int64(n); l.remain < 0 {
+		return 0, fmt.Errorf("file size exceeds the limit of %d bytes", merchantItemFileMaxSize)
+	}
+	return n, err
 }
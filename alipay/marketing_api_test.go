@@ -22,3 +22,39 @@ func TestOpenAppQrcodeCreate(t *testing.T) {
 	}
 	xlog.Debug("aliRsp.Response:", aliRsp.Response)
 }
+
+func TestMarketingActivityOrderVoucherQuery(t *testing.T) {
+	// 请求参数
+	bm := make(gopay.BodyMap)
+	bm.Set("activity_id", "20200817000000000000000000000001")
+
+	// 发起请求
+	aliRsp, err := client.MarketingActivityOrderVoucherQuery(ctx, bm)
+	if err != nil {
+		if bizErr, ok := IsBizError(err); ok {
+			xlog.Errorf("%+v", bizErr)
+			// do something
+			return
+		}
+		xlog.Error(err)
+		return
+	}
+	xlog.Debug("aliRsp.Response:", aliRsp.Response)
+}
+
+func TestParseMarketingVoucherUsedNotify(t *testing.T) {
+	bm := make(gopay.BodyMap)
+	bm.Set("notify_id", "2020092100222161127031571487658").
+		Set("msg_method", NotifyVoucherUsed).
+		Set("biz_content", `{"activity_id":"20200817000000000000000000000001","voucher_id":"2020081700073002339609WT4AT4","voucher_code":"ABC123","user_id":"2088102150527498","biz_type":"V_USE","total_fee":"100.00","bill_details":[{"trade_no":"2020081722001427490501234567","amount":"10.00"}]}`)
+	notify, err := ParseMarketingVoucherUsedNotify(bm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if notify.MsgMethod != NotifyVoucherUsed || notify.BizContent.VoucherCode != "ABC123" || len(notify.BizContent.BillDetails) != 1 {
+		t.Fatalf("ParseMarketingVoucherUsedNotify() = %+v", notify.BizContent)
+	}
+	if _, err = ParseMarketingVoucherUsedNotify(make(gopay.BodyMap)); err == nil {
+		t.Fatal("empty biz_content should return error")
+	}
+}
@@ -112,6 +112,34 @@ type FundTransPagePayRsp struct {
 	Sign         string            `json:"sign"`
 }
 
+type FundAccountBookCreateRsp struct {
+	Response     *FundAccountBookCreate `json:"alipay_fund_accountbook_create_response"`
+	AlipayCertSn string                 `json:"alipay_cert_sn,omitempty"`
+	SignData     string                 `json:"-"`
+	Sign         string                 `json:"sign"`
+}
+
+type FundAccountBookQueryRsp struct {
+	Response     *FundAccountBookQuery `json:"alipay_fund_accountbook_query_response"`
+	AlipayCertSn string                `json:"alipay_cert_sn,omitempty"`
+	SignData     string                `json:"-"`
+	Sign         string                `json:"sign"`
+}
+
+type FundExpandIndirectCreateRsp struct {
+	Response     *FundExpandIndirectCreate `json:"alipay_fund_expandindirect_create_response"`
+	AlipayCertSn string                    `json:"alipay_cert_sn,omitempty"`
+	SignData     string                    `json:"-"`
+	Sign         string                    `json:"sign"`
+}
+
+type FundExpandIndirectQueryRsp struct {
+	Response     *FundExpandIndirectQuery `json:"alipay_fund_expandindirect_query_response"`
+	AlipayCertSn string                   `json:"alipay_cert_sn,omitempty"`
+	SignData     string                   `json:"-"`
+	Sign         string                   `json:"sign"`
+}
+
 // =========================================================分割=========================================================
 
 type TransUniTransfer struct {
@@ -346,3 +374,60 @@ type FundTransPagePay struct {
 	OrderID  string `json:"order_id,omitempty"`
 	Status   string `json:"status"`
 }
+
+type FundAccountBookCreate struct {
+	ErrorResponse
+	AccountBookId string       `json:"account_book_id"`         // 资金记账本ID
+	ExtCardInfo   *ExtCardInfo `json:"ext_card_info,omitempty"` // 记账本绑定的卡信息
+}
+
+type FundAccountBookQuery struct {
+	ErrorResponse
+	AccountBookId   string       `json:"account_book_id"`         // 资金记账本ID
+	AvailableAmount string       `json:"available_amount"`        // 记账本可用余额，单位元
+	ExtCardInfo     *ExtCardInfo `json:"ext_card_info,omitempty"` // 记账本绑定的卡信息
+}
+
+type FundExpandIndirectCreate struct {
+	ErrorResponse
+	OrderId  string `json:"order_id"`           // 进件申请单号
+	OutBizNo string `json:"out_biz_no"`         // 商户请求号
+	Status   string `json:"status,omitempty"`   // 申请单状态
+	SignUrl  string `json:"sign_url,omitempty"` // 签约确认链接
+}
+
+type FundExpandIndirectQuery struct {
+	ErrorResponse
+	OrderId    string `json:"order_id"`              // 进件申请单号
+	OutBizNo   string `json:"out_biz_no"`            // 商户请求号
+	Status     string `json:"status"`                // 申请单状态
+	SubMchId   string `json:"sub_mch_id,omitempty"`  // 子商户ID，进件成功时返回
+	SignUrl    string `json:"sign_url,omitempty"`    // 签约确认链接
+	FailReason string `json:"fail_reason,omitempty"` // 失败原因
+}
+
+const (
+	NotifyAccountBookChanged = "alipay.fund.accountbook.notify" // 资金记账本余额变动通知
+)
+
+// 资金记账本余额变动通知
+type FundAccountBookNotify struct {
+	NotifyId     string                        `json:"notify_id,omitempty"`
+	UtcTimestamp string                        `json:"utc_timestamp,omitempty"`
+	MsgMethod    string                        `json:"msg_method,omitempty"`
+	AppId        string                        `json:"app_id,omitempty"`
+	Version      string                        `json:"version,omitempty"`
+	BizContent   *FundAccountBookNotifyContent `json:"biz_content"`
+}
+
+type FundAccountBookNotifyContent struct {
+	AccountBookId   string `json:"account_book_id"`            // 资金记账本ID
+	SceneCode       string `json:"scene_code,omitempty"`       // 业务场景码
+	MerchantUserId  string `json:"merchant_user_id,omitempty"` // 外部商户ID
+	ActionType      string `json:"action_type,omitempty"`      // 变动类型，如 DEPOSIT：充值，TRANSFER：转出，REFUND：退回
+	Amount          string `json:"amount,omitempty"`           // 变动金额，单位元
+	AvailableAmount string `json:"available_amount,omitempty"` // 变动后可用余额，单位元
+	OutBizNo        string `json:"out_biz_no,omitempty"`       // 商户业务单号
+	OrderId         string `json:"order_id,omitempty"`         // 支付宝业务单号
+	BizTime         string `json:"biz_time,omitempty"`         // 变动时间
+}
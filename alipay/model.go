@@ -8,6 +8,8 @@ const (
 	baseUrlUtf8    = "https://openapi.alipay.com/gateway.do?charset=utf-8"
 	//sandboxBaseUrlUtf8 = "https://openapi.alipaydev.com/gateway.do?charset=utf-8"
 	sandboxBaseUrlUtf8 = "https://openapi-sandbox.dl.alipaydev.com/gateway.do?charset=utf-8"
+	GatewayUrl         = baseUrlUtf8        // 支付宝网关地址（正式环境，utf-8）
+	SandboxGatewayUrl  = sandboxBaseUrlUtf8 // 支付宝网关地址（沙箱环境，utf-8）
 
 	LocationShanghai          = "Asia/Shanghai"
 	PKCS1            PKCSType = 1 // 非Java
@@ -166,6 +168,38 @@ type AuthTokenAppQuery struct {
 	Status      string   `json:"status"`       //valid：有效状态；invalid：无效状态
 }
 
+// 应用授权变更通知
+type AppAuthNotify struct {
+	NotifyId     string                  `json:"notify_id,omitempty"`
+	UtcTimestamp string                  `json:"utc_timestamp,omitempty"`
+	MsgMethod    string                  `json:"msg_method,omitempty"` // alipay.open.auth.appauth.cancelled：取消授权
+	AppId        string                  `json:"app_id,omitempty"`
+	Version      string                  `json:"version,omitempty"`
+	NotifyType   string                  `json:"notify_type,omitempty"`
+	BizContent   AppAuthNotifyBizContent `json:"biz_content"`
+}
+
+type AppAuthNotifyBizContent struct {
+	NotifyContext *AppAuthNotifyContext `json:"notify_context,omitempty"`
+	Detail        *AppAuthNotifyDetail  `json:"detail,omitempty"`
+}
+
+type AppAuthNotifyContext struct {
+	Trigger        string         `json:"trigger,omitempty"`         //触发方
+	TriggerContext map[string]any `json:"trigger_context,omitempty"` //触发上下文
+}
+
+type AppAuthNotifyDetail struct {
+	AuthAppId       string `json:"auth_app_id,omitempty"`       //授权商户的appid
+	AppId           string `json:"app_id,omitempty"`            //第三方应用appid
+	UserId          string `json:"user_id,omitempty"`           //授权商户的user_id
+	AppAuthToken    string `json:"app_auth_token,omitempty"`    //应用授权令牌
+	AppRefreshToken string `json:"app_refresh_token,omitempty"` //刷新令牌
+	ExpiresIn       int    `json:"expires_in,omitempty"`        //令牌有效期，单位秒
+	ReExpiresIn     int    `json:"re_expires_in,omitempty"`     //刷新令牌有效期，单位秒
+	AuthTime        any    `json:"auth_time,omitempty"`         //授权时间
+}
+
 // ===================================================
 type UserInfoAuthResponse struct {
 	Response     *ErrorResponse `json:"alipay_user_info_auth_response"`
@@ -42,6 +42,55 @@ type AntMerchantShopCloseRsp struct {
 	Sign         string                `json:"sign"`
 }
 
+type AntMerchantZftCreateRsp struct {
+	Response     *AntMerchantZftCreate `json:"ant_merchant_expand_indirect_zft_create_response"`
+	AlipayCertSn string                `json:"alipay_cert_sn,omitempty"`
+	SignData     string                `json:"-"`
+	Sign         string                `json:"sign"`
+}
+
+type AntMerchantZftSimpleCreateRsp struct {
+	Response     *AntMerchantZftSimpleCreate `json:"ant_merchant_expand_indirect_zft_simplecreate_response"`
+	AlipayCertSn string                      `json:"alipay_cert_sn,omitempty"`
+	SignData     string                      `json:"-"`
+	Sign         string                      `json:"sign"`
+}
+
+type AntMerchantZftModifyRsp struct {
+	Response     *AntMerchantZftModify `json:"ant_merchant_expand_indirect_zft_modify_response"`
+	AlipayCertSn string                `json:"alipay_cert_sn,omitempty"`
+	SignData     string                `json:"-"`
+	Sign         string                `json:"sign"`
+}
+
+type AntMerchantZftConsultRsp struct {
+	Response     *AntMerchantZftConsult `json:"ant_merchant_expand_indirect_zft_consult_response"`
+	AlipayCertSn string                 `json:"alipay_cert_sn,omitempty"`
+	SignData     string                 `json:"-"`
+	Sign         string                 `json:"sign"`
+}
+
+type AntMerchantZftOrderQueryRsp struct {
+	Response     *AntMerchantZftOrderQuery `json:"ant_merchant_expand_indirect_zftorder_query_response"`
+	AlipayCertSn string                    `json:"alipay_cert_sn,omitempty"`
+	SignData     string                    `json:"-"`
+	Sign         string                    `json:"sign"`
+}
+
+type AntMerchantZftUpgradeRsp struct {
+	Response     *AntMerchantZftUpgrade `json:"ant_merchant_expand_indirect_zft_upgrade_response"`
+	AlipayCertSn string                 `json:"alipay_cert_sn,omitempty"`
+	SignData     string                 `json:"-"`
+	Sign         string                 `json:"sign"`
+}
+
+type AntMerchantZftDeleteRsp struct {
+	Response     *AntMerchantZftDelete `json:"ant_merchant_expand_indirect_zft_delete_response"`
+	AlipayCertSn string                `json:"alipay_cert_sn,omitempty"`
+	SignData     string                `json:"-"`
+	Sign         string                `json:"sign"`
+}
+
 // =========================================================分割=========================================================
 
 type AntMerchantShopModify struct {
@@ -130,3 +179,89 @@ type AntMerchantShopQuery struct {
 type AntMerchantShopClose struct {
 	ErrorResponse
 }
+
+type AntMerchantZftCreate struct {
+	ErrorResponse
+	OrderId string `json:"order_id"` // 申请单id
+}
+
+type AntMerchantZftSimpleCreate struct {
+	ErrorResponse
+	OrderId string `json:"order_id"` // 申请单id
+}
+
+type AntMerchantZftModify struct {
+	ErrorResponse
+	OrderId string `json:"order_id"` // 申请单id
+}
+
+type AntMerchantZftConsult struct {
+	ErrorResponse
+	OrderId string `json:"order_id"` // 申请单id
+}
+
+type AntMerchantZftUpgrade struct {
+	ErrorResponse
+	OrderId string `json:"order_id"` // 申请单id
+}
+
+type AntMerchantZftDelete struct {
+	ErrorResponse
+	OrderId string `json:"order_id,omitempty"` // 申请单id
+}
+
+type AntMerchantZftOrderQuery struct {
+	ErrorResponse
+	Orders []*ZftOrder `json:"orders,omitempty"` // 申请单列表
+}
+
+type ZftOrder struct {
+	OrderId      string `json:"order_id"`                // 申请单id
+	ExternalId   string `json:"external_id"`             // 进件时填写的商户编号
+	MerchantName string `json:"merchant_name"`           // 进件时填写的商户名称
+	Status       string `json:"status"`                  // 申请总体状态：99：已完结，-1：失败，031：审核中
+	ApplyTime    string `json:"apply_time"`              // 申请单创建时间
+	FkAudit      string `json:"fk_audit,omitempty"`      // 风控审核状态：CREATE、SKIP、PASS、REJECT
+	FkAuditMemo  string `json:"fk_audit_memo,omitempty"` // 风控审批备注
+	KzAudit      string `json:"kz_audit,omitempty"`      // 客资审核状态：CREATE、SKIP、PASS、REJECT
+	KzAuditMemo  string `json:"kz_audit_memo,omitempty"` // 客资审批备注
+	SubConfirm   string `json:"sub_confirm,omitempty"`   // 二级商户确认状态：CREATE、SKIP、FAIL、NOT_CONFIRM、FINISH
+	CardAliasNo  string `json:"card_alias_no,omitempty"` // 进件生成的卡编号
+	Smid         string `json:"smid,omitempty"`          // 二级商户id
+	ApplyType    string `json:"apply_type,omitempty"`    // 申请单类型：ZHIFUTONG_CONSULT、ZHIFUTONG_CREATE、ZHIFUTONG_MODIFY
+	AppPreAuth   string `json:"app_pre_auth,omitempty"`  // 是否开启预授权
+	FacePreAuth  string `json:"face_pre_auth,omitempty"` // 是否开启刷脸预授权
+	IsFaceLimit  string `json:"is_face_limit,omitempty"` // 是否有刷脸限制
+	Reason       string `json:"reason,omitempty"`        // 申请单处理失败原因
+}
+
+const (
+	NotifyZftPassed   = "ant.merchant.expand.indirect.zft.passed"   // 直付通二级商户进件审核通过
+	NotifyZftRejected = "ant.merchant.expand.indirect.zft.rejected" // 直付通二级商户进件审核驳回
+)
+
+// 直付通二级商户进件审核结果通知
+type ZftAuditNotify struct {
+	NotifyId     string                    `json:"notify_id,omitempty"`
+	UtcTimestamp string                    `json:"utc_timestamp,omitempty"`
+	MsgMethod    string                    `json:"msg_method,omitempty"` // ant.merchant.expand.indirect.zft.passed：审核通过，ant.merchant.expand.indirect.zft.rejected：审核驳回
+	AppId        string                    `json:"app_id,omitempty"`
+	Version      string                    `json:"version,omitempty"`
+	BizContent   *ZftAuditNotifyBizContent `json:"biz_content"`
+}
+
+type ZftAuditNotifyBizContent struct {
+	ExternalId  string `json:"external_id"`             // 进件时填写的商户编号
+	OrderId     string `json:"order_id"`                // 申请单id
+	Smid        string `json:"smid,omitempty"`          // 二级商户id，审核通过时返回
+	CardAliasNo string `json:"card_alias_no,omitempty"` // 进件生成的卡编号，审核通过时返回
+	SubConfirm  string `json:"sub_confirm,omitempty"`   // 二级商户确认状态
+	ApplyType   string `json:"apply_type,omitempty"`    // 申请单类型
+	Memo        string `json:"memo,omitempty"`          // 审核备注
+	Reason      string `json:"reason,omitempty"`        // 审核驳回原因，审核驳回时返回
+}
+
+// Passed 是否审核通过
+func (n *ZftAuditNotify) Passed() bool {
+	return n.MsgMethod == NotifyZftPassed
+}
@@ -0,0 +1,294 @@
+package alipay
+
+type MarketingActivityOrderVoucherCreateRsp struct {
+	Response     *MarketingActivityOrderVoucherCreate `json:"alipay_marketing_activity_ordervoucher_create_response"`
+	AlipayCertSn string                               `json:"alipay_cert_sn,omitempty"`
+	SignData     string                               `json:"-"`
+	Sign         string                               `json:"sign"`
+}
+
+type MarketingActivityOrderVoucherModifyRsp struct {
+	Response     *MarketingActivityOrderVoucherModify `json:"alipay_marketing_activity_ordervoucher_modify_response"`
+	AlipayCertSn string                               `json:"alipay_cert_sn,omitempty"`
+	SignData     string                               `json:"-"`
+	Sign         string                               `json:"sign"`
+}
+
+type MarketingActivityOrderVoucherQueryRsp struct {
+	Response     *MarketingActivityOrderVoucherQuery `json:"alipay_marketing_activity_ordervoucher_query_response"`
+	AlipayCertSn string                              `json:"alipay_cert_sn,omitempty"`
+	SignData     string                              `json:"-"`
+	Sign         string                              `json:"sign"`
+}
+
+type MarketingActivityOrderVoucherStopRsp struct {
+	Response     *MarketingActivityOrderVoucherStop `json:"alipay_marketing_activity_ordervoucher_stop_response"`
+	AlipayCertSn string                             `json:"alipay_cert_sn,omitempty"`
+	SignData     string                             `json:"-"`
+	Sign         string                             `json:"sign"`
+}
+
+type MarketingActivityOrderVoucherAppendRsp struct {
+	Response     *MarketingActivityOrderVoucherAppend `json:"alipay_marketing_activity_ordervoucher_append_response"`
+	AlipayCertSn string                               `json:"alipay_cert_sn,omitempty"`
+	SignData     string                               `json:"-"`
+	Sign         string                               `json:"sign"`
+}
+
+type MarketingActivityOrderVoucherCodeUploadRsp struct {
+	Response     *MarketingActivityOrderVoucherCodeUpload `json:"alipay_marketing_activity_ordervoucher_codeupload_response"`
+	AlipayCertSn string                                   `json:"alipay_cert_sn,omitempty"`
+	SignData     string                                   `json:"-"`
+	Sign         string                                   `json:"sign"`
+}
+
+type MarketingActivityOrderVoucherUseRsp struct {
+	Response     *MarketingActivityOrderVoucherUse `json:"alipay_marketing_activity_ordervoucher_use_response"`
+	AlipayCertSn string                            `json:"alipay_cert_sn,omitempty"`
+	SignData     string                            `json:"-"`
+	Sign         string                            `json:"sign"`
+}
+
+type MarketingActivityOrderVoucherRefundRsp struct {
+	Response     *MarketingActivityOrderVoucherRefund `json:"alipay_marketing_activity_ordervoucher_refund_response"`
+	AlipayCertSn string                               `json:"alipay_cert_sn,omitempty"`
+	SignData     string                               `json:"-"`
+	Sign         string                               `json:"sign"`
+}
+
+type MarketingActivitySendRsp struct {
+	Response     *MarketingActivitySend `json:"alipay_marketing_activity_send_response"`
+	AlipayCertSn string                 `json:"alipay_cert_sn,omitempty"`
+	SignData     string                 `json:"-"`
+	Sign         string                 `json:"sign"`
+}
+
+type MarketingActivityQueryRsp struct {
+	Response     *MarketingActivityQuery `json:"alipay_marketing_activity_query_response"`
+	AlipayCertSn string                  `json:"alipay_cert_sn,omitempty"`
+	SignData     string                  `json:"-"`
+	Sign         string                  `json:"sign"`
+}
+
+type MarketingActivityBatchQueryRsp struct {
+	Response     *MarketingActivityBatchQuery `json:"alipay_marketing_activity_batchquery_response"`
+	AlipayCertSn string                       `json:"alipay_cert_sn,omitempty"`
+	SignData     string                       `json:"-"`
+	Sign         string                       `json:"sign"`
+}
+
+type MarketingActivityConsultRsp struct {
+	Response     *MarketingActivityConsult `json:"alipay_marketing_activity_consult_response"`
+	AlipayCertSn string                    `json:"alipay_cert_sn,omitempty"`
+	SignData     string                    `json:"-"`
+	Sign         string                    `json:"sign"`
+}
+
+type MarketingActivityUserBatchQueryVoucherRsp struct {
+	Response     *MarketingActivityUserBatchQueryVoucher `json:"alipay_marketing_activity_user_batchqueryvoucher_response"`
+	AlipayCertSn string                                  `json:"alipay_cert_sn,omitempty"`
+	SignData     string                                  `json:"-"`
+	Sign         string                                  `json:"sign"`
+}
+
+type MarketingActivityUserQueryVoucherRsp struct {
+	Response     *MarketingActivityUserQueryVoucher `json:"alipay_marketing_activity_user_queryvoucher_response"`
+	AlipayCertSn string                             `json:"alipay_cert_sn,omitempty"`
+	SignData     string                             `json:"-"`
+	Sign         string                             `json:"sign"`
+}
+
+// =========================================================分割=========================================================
+
+type MarketingActivityOrderVoucherCreate struct {
+	ErrorResponse
+	ActivityId string `json:"activity_id"` // 活动id
+}
+
+type MarketingActivityOrderVoucherModify struct {
+	ErrorResponse
+}
+
+type MarketingActivityOrderVoucherQuery struct {
+	ErrorResponse
+	MarketingActivityDetail
+}
+
+type MarketingActivityOrderVoucherStop struct {
+	ErrorResponse
+}
+
+type MarketingActivityOrderVoucherAppend struct {
+	ErrorResponse
+}
+
+type MarketingActivityOrderVoucherCodeUpload struct {
+	ErrorResponse
+	SuccessCount       int                    `json:"success_count"`                   // 同步成功的券码数量
+	FailCount          int                    `json:"fail_count"`                      // 同步失败的券码数量
+	FailCodeDetailList []*VoucherCodeFailInfo `json:"fail_code_detail_list,omitempty"` // 同步失败的券码详情
+}
+
+type VoucherCodeFailInfo struct {
+	VoucherCode string `json:"voucher_code"` // 券码
+	ErrorCode   string `json:"error_code"`   // 失败错误码
+	ErrorMsg    string `json:"error_msg"`    // 失败原因
+}
+
+type MarketingActivityOrderVoucherUse struct {
+	ErrorResponse
+}
+
+type MarketingActivityOrderVoucherRefund struct {
+	ErrorResponse
+}
+
+type MarketingActivitySend struct {
+	ErrorResponse
+	VoucherId string `json:"voucher_id"` // 券id
+}
+
+type MarketingActivityQuery struct {
+	ErrorResponse
+	MarketingActivityDetail
+}
+
+type MarketingActivityBatchQuery struct {
+	ErrorResponse
+	ActivityLiteInfos []*MarketingActivityLiteInfo `json:"activity_lite_infos,omitempty"` // 活动列表
+	PageNum           int                          `json:"page_num"`                      // 当前页码
+	PageSize          int                          `json:"page_size"`                     // 每页数据量
+	TotalSize         int                          `json:"total_size"`                    // 总数据量
+}
+
+type MarketingActivityConsult struct {
+	ErrorResponse
+	ConsultResultInfoList []*MarketingActivityConsultResult `json:"consult_result_info_list,omitempty"` // 咨询结果列表
+}
+
+type MarketingActivityUserBatchQueryVoucher struct {
+	ErrorResponse
+	UserVoucherInfoList []*UserVoucherInfo `json:"user_voucher_info_list,omitempty"` // 用户券列表
+	PageNum             int                `json:"page_num"`                         // 当前页码
+	PageSize            int                `json:"page_size"`                        // 每页数据量
+	TotalSize           int                `json:"total_size"`                       // 总数据量
+}
+
+type MarketingActivityUserQueryVoucher struct {
+	ErrorResponse
+	UserVoucherInfo
+}
+
+type MarketingActivityDetail struct {
+	ActivityBaseInfo          *MarketingActivityBaseInfo `json:"activity_base_info,omitempty"`           // 活动基本信息
+	VoucherSendModeInfo       map[string]any             `json:"voucher_send_mode_info,omitempty"`       // 券发放模式信息
+	VoucherDeductInfo         *VoucherDeductInfo         `json:"voucher_deduct_info,omitempty"`          // 券优惠信息
+	VoucherAvailableScope     map[string]any             `json:"voucher_available_scope,omitempty"`      // 券可用范围
+	VoucherUseRule            map[string]any             `json:"voucher_use_rule,omitempty"`             // 券核销规则
+	VoucherCustomerGuideInfo  map[string]any             `json:"voucher_customer_guide_info,omitempty"`  // 引导用户用券信息
+	VoucherDisplayPatternInfo map[string]any             `json:"voucher_display_pattern_info,omitempty"` // 券展示信息
+	VoucherInventoryInfo      *VoucherInventoryInfo      `json:"voucher_inventory_info,omitempty"`       // 券库存信息
+}
+
+type MarketingActivityBaseInfo struct {
+	ActivityId              string              `json:"activity_id"`                         // 活动id
+	ActivityName            string              `json:"activity_name"`                       // 活动名称
+	CodeMode                string              `json:"code_mode,omitempty"`                 // 券码模式：MERCHANT_UPLOAD、MERCHANT_API
+	ActivityStatus          string              `json:"activity_status,omitempty"`           // 活动状态：ACTIVE、PAUSE、STOP、EXPIRE
+	ActivityOperationStatus string              `json:"activity_operation_status,omitempty"` // 活动操作状态
+	BelongMerchantInfo      *BelongMerchantInfo `json:"belong_merchant_info,omitempty"`      // 活动归属商户信息
+}
+
+type BelongMerchantInfo struct {
+	MerchantId   string `json:"merchant_id"`             // 商户id
+	BusinessType string `json:"business_type,omitempty"` // 商户类型
+}
+
+type VoucherDeductInfo struct {
+	VoucherType     string           `json:"voucher_type"`               // 券类型：FIX_VOUCHER、DISCOUNT_VOUCHER、SPECIAL_VOUCHER
+	FixVoucher      *FixVoucher      `json:"fix_voucher,omitempty"`      // 满减券
+	DiscountVoucher *DiscountVoucher `json:"discount_voucher,omitempty"` // 折扣券
+	SpecialVoucher  *SpecialVoucher  `json:"special_voucher,omitempty"`  // 特价券
+}
+
+type FixVoucher struct {
+	AmountPerVoucher string `json:"amount_per_voucher"` // 券面额，单位元
+	FloorAmount      string `json:"floor_amount"`       // 消费门槛金额，单位元
+}
+
+type DiscountVoucher struct {
+	Discount      string `json:"discount"`                 // 折扣，如 0.9 为 9 折
+	CeilingAmount string `json:"ceiling_amount,omitempty"` // 单券最大优惠金额，单位元
+	FloorAmount   string `json:"floor_amount,omitempty"`   // 消费门槛金额，单位元
+}
+
+type SpecialVoucher struct {
+	SpecialAmount string `json:"special_amount"`         // 特价金额，单位元
+	FloorAmount   string `json:"floor_amount,omitempty"` // 消费门槛金额，单位元
+}
+
+type VoucherInventoryInfo struct {
+	SendCount int `json:"send_count"` // 已发券数量
+}
+
+type MarketingActivityLiteInfo struct {
+	ActivityId     string              `json:"activity_id"`                    // 活动id
+	ActivityName   string              `json:"activity_name"`                  // 活动名称
+	ActivityStatus string              `json:"activity_status,omitempty"`      // 活动状态
+	VoucherType    string              `json:"voucher_type,omitempty"`         // 券类型
+	BelongMerchant *BelongMerchantInfo `json:"belong_merchant_info,omitempty"` // 活动归属商户信息
+}
+
+type MarketingActivityConsultResult struct {
+	ActivityId        string `json:"activity_id"`                   // 活动id
+	ConsultResultCode string `json:"consult_result_code"`           // 咨询结果：CAN_RECEIVE、CAN_NOT_RECEIVE
+	ConsultResultDesc string `json:"consult_result_desc,omitempty"` // 咨询结果描述
+	RemainQuantity    int    `json:"remain_quantity,omitempty"`     // 用户还可领取的数量
+	ReceivedQuantity  int    `json:"received_quantity,omitempty"`   // 用户已领取的数量
+}
+
+type UserVoucherInfo struct {
+	ActivityId     string `json:"activity_id,omitempty"`      // 活动id
+	VoucherId      string `json:"voucher_id"`                 // 券id
+	VoucherCode    string `json:"voucher_code,omitempty"`     // 券码
+	VoucherName    string `json:"voucher_name,omitempty"`     // 券名称
+	VoucherStatus  string `json:"voucher_status"`             // 券状态：SENDED：可使用，USED：已使用，EXPIRED：已过期，DELETE：已删除
+	CreateTime     string `json:"create_time,omitempty"`      // 领券时间
+	ValidBeginTime string `json:"valid_begin_time,omitempty"` // 券生效时间
+	ValidEndTime   string `json:"valid_end_time,omitempty"`   // 券失效时间
+}
+
+const (
+	NotifyVoucherUsed = "alipay.marketing.activity.message.used" // 商家券核销消息
+)
+
+// 商家券核销异步通知
+type MarketingVoucherUsedNotify struct {
+	NotifyId     string                             `json:"notify_id,omitempty"`
+	UtcTimestamp string                             `json:"utc_timestamp,omitempty"`
+	MsgMethod    string                             `json:"msg_method,omitempty"` // alipay.marketing.activity.message.used
+	AppId        string                             `json:"app_id,omitempty"`
+	Version      string                             `json:"version,omitempty"`
+	BizContent   *MarketingVoucherUsedNotifyContent `json:"biz_content"`
+}
+
+type MarketingVoucherUsedNotifyContent struct {
+	ActivityId   string               `json:"activity_id"`             // 活动id
+	VoucherId    string               `json:"voucher_id"`              // 券id
+	VoucherCode  string               `json:"voucher_code,omitempty"`  // 券码
+	UserId       string               `json:"user_id,omitempty"`       // 用户id
+	OpenId       string               `json:"open_id,omitempty"`       // 用户open_id
+	PartnerId    string               `json:"partner_id,omitempty"`    // 券所属商户id
+	BizType      string               `json:"biz_type,omitempty"`      // 业务类型：V_USE：核销，V_REFUND：核销退款
+	TotalFee     string               `json:"total_fee,omitempty"`     // 订单总金额，单位元
+	ReductionFee string               `json:"reduction_fee,omitempty"` // 券优惠金额，单位元
+	BillDetails  []*VoucherBillDetail `json:"bill_details,omitempty"`  // 核销交易信息
+	GmtCreate    string               `json:"gmt_create,omitempty"`    // 消息创建时间
+}
+
+type VoucherBillDetail struct {
+	TradeNo  string `json:"trade_no"`            // 支付宝交易号
+	Amount   string `json:"amount"`              // 券优惠金额，单位元
+	PayTime  string `json:"pay_time,omitempty"`  // 支付时间
+	DetailId string `json:"detail_id,omitempty"` // 核销明细id
+}
@@ -42,6 +42,20 @@ type TradeOrderSettleQueryResponse struct {
 	Sign         string                 `json:"sign"`
 }
 
+type TradeOrderOnSettleQueryResponse struct {
+	Response     *TradeOrderOnSettleQuery `json:"alipay_trade_order_onsettle_query_response"`
+	AlipayCertSn string                   `json:"alipay_cert_sn,omitempty"`
+	SignData     string                   `json:"-"`
+	Sign         string                   `json:"sign"`
+}
+
+type TradeRoyaltyRateQueryResponse struct {
+	Response     *TradeRoyaltyRateQuery `json:"alipay_trade_royalty_rate_query_response"`
+	AlipayCertSn string                 `json:"alipay_cert_sn,omitempty"`
+	SignData     string                 `json:"-"`
+	Sign         string                 `json:"sign"`
+}
+
 // =========================================================分割=========================================================
 
 type TradeRelationBind struct {
@@ -90,3 +104,14 @@ type RoyaltyDetail struct {
 	ErrorCode     string `json:"error_code"`
 	ErrorDesc     string `json:"error_desc"`
 }
+
+type TradeOrderOnSettleQuery struct {
+	ErrorResponse
+	UnsettledAmount string `json:"unsettled_amount"` // 待分账金额，单位元
+}
+
+type TradeRoyaltyRateQuery struct {
+	ErrorResponse
+	UserId   string `json:"user_id"`   // 分账收款方的支付宝用户号
+	MaxRatio int    `json:"max_ratio"` // 可分账的最大比例，百分比
+}
@@ -2,6 +2,7 @@ package alipay
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -110,3 +111,67 @@ func ParseNotifyResult(req *http.Request) (notifyReq *NotifyRequest, err error)
 	}
 	return
 }
+
+// 解析直付通二级商户进件审核结果通知
+// bm：通知参数，请先调用 alipay.VerifySign() 或 alipay.VerifySignWithCert() 验签
+// 返回参数notify.Passed()：是否审核通过
+func ParseZftAuditNotify(bm gopay.BodyMap) (notify *ZftAuditNotify, err error) {
+	notify = &ZftAuditNotify{
+		NotifyId:     bm.GetString("notify_id"),
+		UtcTimestamp: bm.GetString("utc_timestamp"),
+		MsgMethod:    bm.GetString("msg_method"),
+		AppId:        bm.GetString("app_id"),
+		Version:      bm.GetString("version"),
+		BizContent:   new(ZftAuditNotifyBizContent),
+	}
+	if err = unmarshalBizContent(bm, notify.BizContent); err != nil {
+		return nil, err
+	}
+	return notify, nil
+}
+
+// 解析商家券核销异步通知
+// bm：通知参数，请先调用 alipay.VerifySign() 或 alipay.VerifySignWithCert() 验签
+func ParseMarketingVoucherUsedNotify(bm gopay.BodyMap) (notify *MarketingVoucherUsedNotify, err error) {
+	notify = &MarketingVoucherUsedNotify{
+		NotifyId:     bm.GetString("notify_id"),
+		UtcTimestamp: bm.GetString("utc_timestamp"),
+		MsgMethod:    bm.GetString("msg_method"),
+		AppId:        bm.GetString("app_id"),
+		Version:      bm.GetString("version"),
+		BizContent:   new(MarketingVoucherUsedNotifyContent),
+	}
+	if err = unmarshalBizContent(bm, notify.BizContent); err != nil {
+		return nil, err
+	}
+	return notify, nil
+}
+
+// 解析资金记账本余额变动通知
+// bm：通知参数，请先调用 alipay.VerifySign() 或 alipay.VerifySignWithCert() 验签
+func ParseFundAccountBookNotify(bm gopay.BodyMap) (notify *FundAccountBookNotify, err error) {
+	notify = &FundAccountBookNotify{
+		NotifyId:     bm.GetString("notify_id"),
+		UtcTimestamp: bm.GetString("utc_timestamp"),
+		MsgMethod:    bm.GetString("msg_method"),
+		AppId:        bm.GetString("app_id"),
+		Version:      bm.GetString("version"),
+		BizContent:   new(FundAccountBookNotifyContent),
+	}
+	if err = unmarshalBizContent(bm, notify.BizContent); err != nil {
+		return nil, err
+	}
+	return notify, nil
+}
+
+// 解析异步通知中的 biz_content 到结构体
+func unmarshalBizContent(bm gopay.BodyMap, ptr any) (err error) {
+	bizContent := bm.GetString("biz_content")
+	if bizContent == util.NULL {
+		return errors.New("biz_content is empty")
+	}
+	if err = json.Unmarshal([]byte(bizContent), ptr); err != nil {
+		return fmt.Errorf("[%w]: %v, biz_content: %s", gopay.UnmarshalErr, err, bizContent)
+	}
+	return nil
+}
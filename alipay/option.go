@@ -0,0 +1,84 @@
+package alipay
+
+import (
+	"context"
+	"time"
+)
+
+// Option 单次请求参数，覆盖 client 的公共参数配置
+// 使用方式：
+// 1、client.WithOptions() 返回设置了参数的新 client，不修改原 client
+// 2、alipay.ContextWithOptions() 将参数放入 ctx，仅对使用该 ctx 的请求生效
+// 优先级：bm 中设置的参数 > Option > client 配置
+type Option func(c *Client)
+
+type optionsCtxKey struct{}
+
+// WithAppAuthToken 设置应用授权 app_auth_token
+func WithAppAuthToken(appAuthToken string) Option {
+	return func(c *Client) {
+		c.AppAuthToken = appAuthToken
+	}
+}
+
+// WithNotifyUrl 设置异步通知地址 notify_url
+func WithNotifyUrl(url string) Option {
+	return func(c *Client) {
+		c.NotifyUrl = url
+	}
+}
+
+// WithReturnUrl 设置支付后的 return_url
+func WithReturnUrl(url string) Option {
+	return func(c *Client) {
+		c.ReturnUrl = url
+	}
+}
+
+// WithCharset 设置编码格式 charset
+func WithCharset(charset string) Option {
+	return func(c *Client) {
+		if charset != "" {
+			c.Charset = charset
+		}
+	}
+}
+
+// WithTimeout 设置 http 请求超时时间，默认 60 秒
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithOptions 返回设置了单次请求参数的新 client，与原 client 共享密钥、证书等配置，不修改原 client
+// 注意：client 的 SetXxx() 方法会修改 client 本身，多个 goroutine 共享 client 时，请在初始化时设置，
+// 请求级别的参数（如服务商代多个子商户调用时的 app_auth_token）请使用 client.WithOptions() 或 alipay.ContextWithOptions()
+func (a *Client) WithOptions(opts ...Option) (client *Client) {
+	c := *a
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// ContextWithOptions 返回携带单次请求参数的 ctx，使用该 ctx 的请求，参数覆盖 client 配置
+func ContextWithOptions(ctx context.Context, opts ...Option) context.Context {
+	if parent, ok := ctx.Value(optionsCtxKey{}).([]Option); ok {
+		opts = append(append(make([]Option, 0, len(parent)+len(opts)), parent...), opts...)
+	}
+	return context.WithValue(ctx, optionsCtxKey{}, opts)
+}
+
+// ctx 中携带单次请求参数时，返回设置了参数的新 client，否则返回原 client
+func (a *Client) withContext(ctx context.Context) *Client {
+	if ctx == nil {
+		return a
+	}
+	if opts, ok := ctx.Value(optionsCtxKey{}).([]Option); ok && len(opts) > 0 {
+		return a.WithOptions(opts...)
+	}
+	return a
+}
@@ -0,0 +1,43 @@
+package alipay
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/misu99/gopay"
+)
+
+func TestClient_WithOptions(t *testing.T) {
+	c := &Client{AppId: "appid", Charset: UTF8, SignType: RSA2, NotifyUrl: "https://www.fmm.ink", privateKey: client.privateKey}
+	derived := c.WithOptions(WithAppAuthToken("token"), WithNotifyUrl("https://notify.fmm.ink"), WithTimeout(time.Second))
+	if c.AppAuthToken != "" || c.NotifyUrl != "https://www.fmm.ink" || c.timeout != 0 {
+		t.Fatalf("WithOptions() should not modify origin client: %+v", c)
+	}
+	if derived.AppAuthToken != "token" || derived.NotifyUrl != "https://notify.fmm.ink" || derived.timeout != time.Second {
+		t.Fatalf("WithOptions() = %+v", derived)
+	}
+
+	ctx := ContextWithOptions(context.Background(), WithAppAuthToken("token1"))
+	ctx = ContextWithOptions(ctx, WithReturnUrl("https://return.fmm.ink"))
+	bm := make(gopay.BodyMap)
+	bm.Set("out_trade_no", "GZ201909081743431443")
+	payUrl, err := c.PageExecute(ctx, bm, "alipay.trade.page.pay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(payUrl)
+	if q := u.Query(); q.Get("app_auth_token") != "token1" || q.Get("return_url") != "https://return.fmm.ink" || q.Get("notify_url") != "https://www.fmm.ink" {
+		t.Fatalf("PageExecute() = %s", payUrl)
+	}
+	// bm 中设置的参数优先
+	bm.Set("app_auth_token", "token2")
+	payUrl, _ = c.PageExecute(ctx, bm, "alipay.trade.page.pay")
+	if u, _ = url.Parse(payUrl); u.Query().Get("app_auth_token") != "token2" {
+		t.Fatalf("PageExecute() = %s", payUrl)
+	}
+	if c.AppAuthToken != "" || c.ReturnUrl != "" {
+		t.Fatalf("ContextWithOptions() should not modify origin client: %+v", c)
+	}
+}
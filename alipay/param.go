@@ -2,6 +2,7 @@ package alipay
 
 import (
 	"fmt"
+	"io/ioutil"
 	"log"
 	"time"
 
@@ -74,29 +75,28 @@ func (a *Client) SetAliPayRootCertSN(aliPayRootCertSN string) (client *Client) {
 }
 
 // 通过应用公钥证书路径设置 app_cert_sn、alipay_root_cert_sn、alipay_cert_sn
+// 同时保存支付宝根证书及支付宝公钥证书，用于支付宝公钥证书轮换时自动下载新证书并校验证书链
 // appCertPath：应用公钥证书路径
 // aliPayRootCertPath：支付宝根证书文件路径
 // aliPayPublicCertPath：支付宝公钥证书文件路径
 func (a *Client) SetCertSnByPath(appCertPath, aliPayRootCertPath, aliPayPublicCertPath string) (err error) {
-	appCertSn, err := GetCertSN(appCertPath)
+	appCertContent, err := ioutil.ReadFile(appCertPath)
 	if err != nil {
 		return fmt.Errorf("get app_cert_sn return err, but alse return alipay client. err: %w", err)
 	}
-	rootCertSn, err := GetRootCertSN(aliPayRootCertPath)
+	aliPayRootCertContent, err := ioutil.ReadFile(aliPayRootCertPath)
 	if err != nil {
 		return fmt.Errorf("get alipay_root_cert_sn return err, but alse return alipay client. err: %w", err)
 	}
-	publicCertSn, err := GetCertSN(aliPayPublicCertPath)
+	aliPayPublicCertContent, err := ioutil.ReadFile(aliPayPublicCertPath)
 	if err != nil {
 		return fmt.Errorf("get alipay_cert_sn return err, but alse return alipay client. err: %w", err)
 	}
-	a.AppCertSN = appCertSn
-	a.AliPayRootCertSN = rootCertSn
-	a.AliPayPublicCertSN = publicCertSn
-	return nil
+	return a.SetCertSnByContent(appCertContent, aliPayRootCertContent, aliPayPublicCertContent)
 }
 
 // 通过应用公钥证书内容设置 app_cert_sn、alipay_root_cert_sn、alipay_cert_sn
+// 同时保存支付宝根证书及支付宝公钥证书，用于支付宝公钥证书轮换时自动下载新证书并校验证书链
 // appCertContent：应用公钥证书文件内容
 // aliPayRootCertContent：支付宝根证书文件内容
 // aliPayPublicCertContent：支付宝公钥证书文件内容
@@ -116,6 +116,11 @@ func (a *Client) SetCertSnByContent(appCertContent, aliPayRootCertContent, aliPa
 	a.AppCertSN = appCertSn
 	a.AliPayRootCertSN = rootCertSn
 	a.AliPayPublicCertSN = publicCertSn
+	a.publicCerts.setRoots(rootCertPool(aliPayRootCertContent))
+	// 本地配置的支付宝公钥证书，直接信任
+	if _, key, err := a.publicCerts.parse(aliPayPublicCertContent, false); err == nil {
+		a.publicCerts.set(publicCertSn, key)
+	}
 	return nil
 }
 
@@ -148,7 +153,20 @@ func (a *Client) SetSignType(signType string) (client *Client) {
 }
 
 // 设置应用授权
+// 注意：会修改 client 本身，服务商代多个子商户并发调用时，请使用 client.WithOptions(alipay.WithAppAuthToken()) 或 alipay.ContextWithOptions()
 func (a *Client) SetAppAuthToken(appAuthToken string) (client *Client) {
 	a.AppAuthToken = appAuthToken
 	return a
 }
+
+// 设置接口内容加密密钥（AES），设置后请求 biz_content 加密传输，同步响应在验签后自动解密
+// 注意：同步响应解密后，aliRsp.SignData 为明文内容，请使用自动验签（client.AutoVerifySign() 或 client.AutoVerifySignByPublicKey()）对密文验签
+// aesKey：支付宝开放平台获取的接口内容加密密钥（Base64，解码后为 16、24 或 32 字节），密钥无效时返回错误且不修改已设置的密钥
+func (a *Client) SetAESKey(aesKey string) (err error) {
+	key, err := decodeAESKey(aesKey)
+	if err != nil {
+		return err
+	}
+	a.aesKey = key
+	return nil
+}
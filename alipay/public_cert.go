@@ -0,0 +1,207 @@
+package alipay
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/pkg/util"
+	"github.com/misu99/gopay/pkg/xlog"
+)
+
+const (
+	publicCertDownloadMethod = "alipay.open.app.alipaycert.download"
+)
+
+// 支付宝公钥证书，按 alipay_cert_sn 保存，支持支付宝公钥证书无感知升级
+type publicCertStore struct {
+	mu        sync.RWMutex
+	keys      map[string]*rsa.PublicKey // alipay_cert_sn => 支付宝公钥
+	roots     *x509.CertPool            // 支付宝根证书，用于下载证书的证书链校验
+	downloads map[string]*certDownload  // 下载中的 alipay_cert_sn，同一SN并发验签只下载一次
+}
+
+// 下载中的支付宝公钥证书，下载完成后关闭 done，等待的调用共享 key、err
+type certDownload struct {
+	done chan struct{}
+	key  *rsa.PublicKey
+	err  error
+}
+
+func newPublicCertStore() *publicCertStore {
+	return &publicCertStore{keys: make(map[string]*rsa.PublicKey), downloads: make(map[string]*certDownload)}
+}
+
+func (s *publicCertStore) get(sn string) *rsa.PublicKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.keys[sn]
+}
+
+func (s *publicCertStore) set(sn string, key *rsa.PublicKey) {
+	s.mu.Lock()
+	s.keys[sn] = key
+	s.mu.Unlock()
+}
+
+// 获取 sn 对应的支付宝公钥，不存在时调用 download 下载并保存
+// 同一 sn 同时只执行一次 download，其余调用等待其结果，避免并发请求重复下载证书
+func (s *publicCertStore) load(ctx context.Context, sn string, download func() (*rsa.PublicKey, error)) (key *rsa.PublicKey, err error) {
+	s.mu.Lock()
+	if key = s.keys[sn]; key != nil {
+		s.mu.Unlock()
+		return key, nil
+	}
+	d, loading := s.downloads[sn]
+	if !loading {
+		d = &certDownload{done: make(chan struct{})}
+		s.downloads[sn] = d
+	}
+	s.mu.Unlock()
+	if loading {
+		select {
+		case <-d.done:
+			return d.key, d.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	d.key, d.err = download()
+	s.mu.Lock()
+	if d.err == nil {
+		s.keys[sn] = d.key
+	}
+	delete(s.downloads, sn)
+	s.mu.Unlock()
+	close(d.done)
+	return d.key, d.err
+}
+
+func (s *publicCertStore) hasRoots() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.roots != nil
+}
+
+func (s *publicCertStore) setRoots(roots *x509.CertPool) {
+	s.mu.Lock()
+	s.roots = roots
+	s.mu.Unlock()
+}
+
+// 解析支付宝公钥证书，verifyChain 为 true 且配置了支付宝根证书时，校验证书链
+func (s *publicCertStore) parse(certContent []byte, verifyChain bool) (sn string, key *rsa.PublicKey, err error) {
+	var certs []*x509.Certificate
+	for rest := certContent; ; {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return util.NULL, nil, fmt.Errorf("x509.ParseCertificate：%w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return util.NULL, nil, errors.New("failed to parse alipay public cert, please check your cert")
+	}
+	s.mu.RLock()
+	roots := s.roots
+	s.mu.RUnlock()
+	if verifyChain && roots != nil {
+		intermediates := x509.NewCertPool()
+		for _, c := range certs[1:] {
+			intermediates.AddCert(c)
+		}
+		opts := x509.VerifyOptions{Roots: roots, Intermediates: intermediates, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}}
+		if _, err = certs[0].Verify(opts); err != nil {
+			return util.NULL, nil, fmt.Errorf("[%w], 支付宝公钥证书链校验失败: %v", gopay.CertNotMatchErr, err)
+		}
+	}
+	key, ok := certs[0].PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return util.NULL, nil, errors.New("alipay public cert is not rsa public key")
+	}
+	if sn, err = GetCertSN(certContent); err != nil {
+		return util.NULL, nil, err
+	}
+	return sn, key, nil
+}
+
+// AddAliPayPublicCert 添加支付宝公钥证书，用于按网关响应中的 alipay_cert_sn 选择证书自动验签
+// 注意：已设置支付宝根证书（client.SetCertSnByContent() 或 client.SetCertSnByPath()）时，会先校验证书链
+// alipayPublicCertContent：支付宝公钥证书文件内容
+func (a *Client) AddAliPayPublicCert(alipayPublicCertContent []byte) (sn string, err error) {
+	sn, key, err := a.publicCerts.parse(alipayPublicCertContent, true)
+	if err != nil {
+		return util.NULL, err
+	}
+	a.publicCerts.set(sn, key)
+	return sn, nil
+}
+
+// AliPayPublicCertSNs 获取当前可用于自动验签的支付宝公钥证书SN，可用于监控证书轮换
+func (a *Client) AliPayPublicCertSNs() (sns []string) {
+	a.publicCerts.mu.RLock()
+	for sn := range a.publicCerts.keys {
+		sns = append(sns, sn)
+	}
+	a.publicCerts.mu.RUnlock()
+	sort.Strings(sns)
+	return sns
+}
+
+// 根据网关响应中的 alipay_cert_sn 获取支付宝公钥，本地不存在时，调用 PublicCertDownload 下载并校验证书链，同一SN并发时只下载一次
+func (a *Client) aliPayPublicKeyBySN(ctx context.Context, sn string) (key *rsa.PublicKey, err error) {
+	if key = a.publicCerts.get(sn); key != nil {
+		return key, nil
+	}
+	if sn == a.AliPayPublicCertSN && a.aliPayPublicKey != nil {
+		return a.aliPayPublicKey, nil
+	}
+	if !a.publicCerts.hasRoots() {
+		return nil, fmt.Errorf("[%w], 网关响应报文中的支付宝公钥证书SN[%s]不存在，且未设置支付宝根证书，无法下载并校验新证书", gopay.CertNotMatchErr, sn)
+	}
+	return a.publicCerts.load(ctx, sn, func() (*rsa.PublicKey, error) {
+		bm := make(gopay.BodyMap)
+		bm.Set("alipay_cert_sn", sn)
+		aliRsp, err := a.PublicCertDownload(ctx, bm)
+		if err != nil {
+			return nil, fmt.Errorf("[%w], 下载支付宝公钥证书SN[%s]失败: %v", gopay.CertNotMatchErr, sn, err)
+		}
+		certSN, key, err := a.publicCerts.parse([]byte(aliRsp.Response.AlipayCertContent), true)
+		if err != nil {
+			return nil, err
+		}
+		if certSN != sn {
+			return nil, fmt.Errorf("[%w], 下载的支付宝公钥证书SN[%s]与网关响应报文中的SN[%s]不匹配", gopay.CertNotMatchErr, certSN, sn)
+		}
+		if a.DebugSwitch == gopay.DebugOn {
+			xlog.Debugf("Alipay_PublicCertDownload: add alipay_cert_sn [%s]", sn)
+		}
+		return key, nil
+	})
+}
+
+// 支付宝根证书文件中包含多个根证书，跳过无法解析的证书
+func rootCertPool(rootCertContent []byte) *x509.CertPool {
+	pool := x509.NewCertPool()
+	for rest := rootCertContent; ; {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			pool.AddCert(cert)
+		}
+	}
+	return pool
+}
@@ -0,0 +1,210 @@
+package alipay
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/alipay/cert"
+)
+
+func genTestCert(t *testing.T, cn string, parent *x509.Certificate, parentKey *rsa.PrivateKey, isCA bool) (*x509.Certificate, *rsa.PrivateKey, []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	if parent == nil {
+		parent, parentKey = tpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestClient_AliPayPublicCertRotation(t *testing.T) {
+	root, rootKey, rootPem := genTestCert(t, "Alipay Root", nil, nil, true)
+	_, appKey, appPem := genTestCert(t, "app", root, rootKey, false)
+	_, oldKey, oldPem := genTestCert(t, "alipay old", root, rootKey, false)
+	_, newKey, newPem := genTestCert(t, "alipay new", root, rootKey, false)
+	_, _, otherRootPem := genTestCert(t, "Other Root", nil, nil, true)
+
+	c := &Client{SignType: RSA2, privateKey: appKey, publicCerts: newPublicCertStore()}
+	if err := c.SetCertSnByContent(appPem, rootPem, oldPem); err != nil {
+		t.Fatal(err)
+	}
+	c.AutoVerifySign(oldPem)
+	oldSN, _ := GetCertSN(oldPem)
+	if sns := c.AliPayPublicCertSNs(); len(sns) != 1 || sns[0] != oldSN {
+		t.Fatalf("AliPayPublicCertSNs() = %v, want [%s]", sns, oldSN)
+	}
+
+	// 非支付宝根证书签发的证书，证书链校验失败
+	if _, err := c.AddAliPayPublicCert(otherRootPem); !errors.Is(err, gopay.CertNotMatchErr) {
+		t.Fatalf("AddAliPayPublicCert(other root) err = %v", err)
+	}
+	newSN, err := c.AddAliPayPublicCert(newPem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sns := c.AliPayPublicCertSNs(); len(sns) != 2 {
+		t.Fatalf("AliPayPublicCertSNs() = %v", sns)
+	}
+
+	sign := func(key *rsa.PrivateKey, signData string) string {
+		h := sha256.Sum256([]byte(signData))
+		bs, _ := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, h[:])
+		return base64.StdEncoding.EncodeToString(bs)
+	}
+	signData := `{"code":"10000","msg":"Success","trade_no":"2019082922001432790585537960"}`
+	rsp := func(key *rsa.PrivateKey, sn string) []byte {
+		return []byte(`{"alipay_trade_query_response":` + signData + `,"alipay_cert_sn":"` + sn + `","sign":"` + sign(key, signData) + `"}`)
+	}
+	if err = c.autoVerifySign(ctx, rsp(oldKey, oldSN), "alipay.trade.query"); err != nil {
+		t.Fatalf("verify by old cert: %v", err)
+	}
+	if err = c.autoVerifySign(ctx, rsp(newKey, newSN), "alipay.trade.query"); err != nil {
+		t.Fatalf("verify by rotated cert: %v", err)
+	}
+	if err = c.autoVerifySign(ctx, rsp(oldKey, newSN), "alipay.trade.query"); !errors.Is(err, gopay.VerifySignatureErr) {
+		t.Fatalf("verify with wrong key, err = %v", err)
+	}
+}
+
+// 网关响应中出现未知的 alipay_cert_sn 时，自动调用 PublicCertDownload 下载证书
+func TestClient_AliPayPublicCertDownload(t *testing.T) {
+	root, rootKey, rootPem := genTestCert(t, "Alipay Root", nil, nil, true)
+	_, _, appPem := genTestCert(t, "app", root, rootKey, false)
+	_, _, oldPem := genTestCert(t, "alipay old", root, rootKey, false)
+	_, newKey, newPem := genTestCert(t, "alipay new", root, rootKey, false)
+	_, _, otherPem := genTestCert(t, "alipay other", root, rootKey, false)
+	_, otherRootKey, otherRootPem := genTestCert(t, "Other Root", nil, nil, true)
+	newSN, _ := GetCertSN(newPem)
+	otherRootSN, _ := GetCertSN(otherRootPem)
+
+	c, err := NewClient(cert.Appid, cert.PrivateKey, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.SetCertSnByContent(appPem, rootPem, oldPem); err != nil {
+		t.Fatal(err)
+	}
+	c.AutoVerifySign(oldPem)
+
+	const concurrency = 5
+	var (
+		mu                sync.Mutex
+		querySN           string                    // 交易查询响应中的 alipay_cert_sn
+		queryKey          *rsa.PrivateKey           // 交易查询响应的签名私钥
+		certs             = make(map[string][]byte) // alipay_cert_sn => 下载接口返回的证书
+		queries, download int32
+	)
+	signData := `{"code":"10000","msg":"Success","trade_no":"2019082922001432790585537960"}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		mu.Lock()
+		sn, key := querySN, queryKey
+		mu.Unlock()
+		if r.Form.Get("method") != publicCertDownloadMethod {
+			atomic.AddInt32(&queries, 1)
+			h := sha256.Sum256([]byte(signData))
+			bs, _ := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, h[:])
+			_, _ = w.Write([]byte(`{"alipay_trade_query_response":` + signData + `,"alipay_cert_sn":"` + sn + `","sign":"` + base64.StdEncoding.EncodeToString(bs) + `"}`))
+			return
+		}
+		atomic.AddInt32(&download, 1)
+		// 等待所有并发请求都收到响应并开始获取证书
+		for atomic.LoadInt32(&queries) < concurrency {
+			time.Sleep(5 * time.Millisecond)
+		}
+		time.Sleep(50 * time.Millisecond)
+		mu.Lock()
+		content := certs[sn]
+		mu.Unlock()
+		// 下载接口响应使用新证书签名，客户端尚无该证书，不验签（sign 无效）
+		_, _ = w.Write([]byte(`{"alipay_open_app_alipaycert_download_response":{"code":"10000","msg":"Success","alipay_cert_content":"` +
+			base64.StdEncoding.EncodeToString(content) + `"},"alipay_cert_sn":"` + sn + `","sign":"invalid"}`))
+	}))
+	defer srv.Close()
+	c.gatewayUrl = srv.URL + "/gateway.do?charset=utf-8"
+	bm := make(gopay.BodyMap)
+	bm.Set("trade_no", "2019082922001432790585537960")
+
+	// 并发请求同一未知SN，只下载一次
+	querySN, queryKey, certs[newSN] = newSN, newKey, newPem
+	var wg sync.WaitGroup
+	errs := make(chan error, concurrency)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bm := make(gopay.BodyMap)
+			bm.Set("trade_no", "2019082922001432790585537960")
+			_, err := c.TradeQuery(ctx, bm)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("TradeQuery() with rotated cert err = %v", err)
+		}
+	}
+	if n := atomic.LoadInt32(&download); n != 1 {
+		t.Fatalf("PublicCertDownload called %d times, want 1", n)
+	}
+	if sns := c.AliPayPublicCertSNs(); len(sns) != 2 {
+		t.Fatalf("AliPayPublicCertSNs() = %v", sns)
+	}
+
+	// 下载的证书SN与响应中的SN不一致
+	atomic.StoreInt32(&download, 0)
+	mu.Lock()
+	querySN, certs["unknown-sn"] = "unknown-sn", otherPem
+	mu.Unlock()
+	aliRsp, err := c.TradeQuery(ctx, bm)
+	if !errors.Is(err, gopay.CertNotMatchErr) || aliRsp == nil || atomic.LoadInt32(&download) != 1 {
+		t.Fatalf("TradeQuery() with mismatched cert = %+v, %v", aliRsp, err)
+	}
+	// 非支付宝根证书签发的证书，证书链校验失败，失败结果不缓存
+	mu.Lock()
+	querySN, queryKey, certs[otherRootSN] = otherRootSN, otherRootKey, otherRootPem
+	mu.Unlock()
+	for i := 0; i < 2; i++ {
+		if aliRsp, err = c.TradeQuery(ctx, bm); !errors.Is(err, gopay.CertNotMatchErr) || aliRsp == nil {
+			t.Fatalf("TradeQuery() with untrusted cert = %+v, %v", aliRsp, err)
+		}
+	}
+	if n := atomic.LoadInt32(&download); n != 3 {
+		t.Fatalf("PublicCertDownload called %d times, want 3", n)
+	}
+	if sns := c.AliPayPublicCertSNs(); len(sns) != 2 {
+		t.Fatalf("AliPayPublicCertSNs() = %v", sns)
+	}
+}
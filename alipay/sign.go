@@ -1,6 +1,7 @@
 package alipay
 
 import (
+	"context"
 	"crypto"
 	"crypto/md5"
 	"crypto/rand"
@@ -218,10 +219,7 @@ func (a *Client) getSignData(bs []byte, alipayCertSN string) (signData string, e
 	indexStart = indexStart + 11
 	bsLen := len(str)
 	if alipayCertSN != "" {
-		// 公钥证书模式
-		if alipayCertSN != a.AliPayPublicCertSN {
-			return gopay.NULL, fmt.Errorf("[%w], 当前使用的支付宝公钥证书SN[%s]与网关响应报文中的SN[%s]不匹配", gopay.CertNotMatchErr, a.AliPayPublicCertSN, alipayCertSN)
-		}
+		// 公钥证书模式，SN 校验及证书选择见 autoVerifySign()
 		indexEnd = strings.Index(str, `,"alipay_cert_sn":`)
 		if indexEnd > indexStart && bsLen > indexStart {
 			signData = str[indexStart:indexEnd]
@@ -281,27 +279,91 @@ func VerifySyncSignWithCert(alipayPublicKeyCert any, signData, sign string) (ok
 	return true, nil
 }
 
-func (a *Client) autoVerifySignByCert(sign, signData string, signDataErr error) (err error) {
-	if a.autoSign && a.aliPayPublicKey != nil {
-		if a.DebugSwitch == gopay.DebugOn {
-			xlog.Debugf("Alipay_SyncSignData: %s, Sign=[%s]", signData, sign)
+// 同步响应自动验签，开启自动验签后，在 doAliPay 中对所有接口的同步响应生效
+// 公钥模式：网关响应报文中不应包含 alipay_cert_sn
+// 公钥证书模式：按网关响应报文中的 alipay_cert_sn 选择支付宝公钥证书，本地不存在时自动下载并校验证书链
+func (a *Client) autoVerifySign(ctx context.Context, bs []byte, method string) (err error) {
+	if !a.autoSign || a.aliPayPublicKey == nil {
+		return nil
+	}
+	var (
+		rsp          = make(map[string]json.RawMessage)
+		sign, certSN string
+		pubKey       = a.aliPayPublicKey
+	)
+	if err = json.Unmarshal(bs, &rsp); err != nil {
+		return fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if v, ok := rsp["sign"]; ok {
+		_ = json.Unmarshal(v, &sign)
+	}
+	if v, ok := rsp["alipay_cert_sn"]; ok {
+		_ = json.Unmarshal(v, &certSN)
+	}
+	if a.autoSignByKey {
+		if certSN != util.NULL {
+			return fmt.Errorf("[%w], 当前为支付宝公钥模式，网关响应报文中不应包含支付宝公钥证书SN[%s]", gopay.CertNotMatchErr, certSN)
 		}
-		// 只有证书验签时，才可能出现此error
-		if signDataErr != nil {
-			return signDataErr
+	} else if certSN != util.NULL && certSN != a.AliPayPublicCertSN {
+		// 证书下载接口返回新SN时不验签，下载的证书通过支付宝根证书链校验
+		if method == publicCertDownloadMethod && a.publicCerts.hasRoots() && a.publicCerts.get(certSN) == nil {
+			return nil
 		}
-
-		signBytes, _ := base64.StdEncoding.DecodeString(sign)
-		hashs := crypto.SHA256
-		h := hashs.New()
-		h.Write([]byte(signData))
-		if err = rsa.VerifyPKCS1v15(a.aliPayPublicKey, hashs, h.Sum(nil), signBytes); err != nil {
-			return fmt.Errorf("[%w]: %v", gopay.VerifySignatureErr, err)
+		if pubKey, err = a.aliPayPublicKeyBySN(ctx, certSN); err != nil {
+			return err
+		}
+	}
+	if sign == util.NULL {
+		// 网关层面的错误（如缺少参数、应用不存在）支付宝以 error_response 返回且不带 sign，交由业务错误处理
+		// 其余响应（包括 xxx_response 中的业务错误）缺少 sign 均视为验签失败
+		if isGatewayError(rsp) {
+			return nil
 		}
+		return fmt.Errorf("[%w], bytes: %s", gopay.MissSignatureErr, string(bs))
+	}
+	signData, err := a.getSignData(bs, certSN)
+	if err != nil {
+		return err
+	}
+	if a.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Alipay_SyncSignData: %s, Sign=[%s]", signData, sign)
+	}
+	hashs := crypto.SHA256
+	if a.SignType == RSA {
+		hashs = crypto.SHA1
+	}
+	signBytes, _ := base64.StdEncoding.DecodeString(sign)
+	h := hashs.New()
+	h.Write([]byte(signData))
+	if err = rsa.VerifyPKCS1v15(pubKey, hashs, h.Sum(nil), signBytes); err != nil {
+		return fmt.Errorf("[%w]: %v", gopay.VerifySignatureErr, err)
 	}
 	return nil
 }
 
+// 是否为自动验签失败的错误，此时 doAliPay() 等方法仍返回响应内容，接口方法解析后连同该错误返回 aliRsp
+func isVerifyErr(err error) bool {
+	return errors.Is(err, gopay.VerifySignatureErr) || errors.Is(err, gopay.MissSignatureErr) || errors.Is(err, gopay.CertNotMatchErr)
+}
+
+// 网关响应报文是否仅为 error_response 网关错误，同时包含其他 xxx_response 时不视为网关错误
+func isGatewayError(rsp map[string]json.RawMessage) bool {
+	v, ok := rsp["error_response"]
+	if !ok {
+		return false
+	}
+	for k := range rsp {
+		if k != "error_response" && strings.HasSuffix(k, "_response") {
+			return false
+		}
+	}
+	errRsp := new(ErrorResponse)
+	if err := json.Unmarshal(v, errRsp); err != nil {
+		return false
+	}
+	return errRsp.Code != util.NULL && errRsp.Code != "10000"
+}
+
 // =============================== 异步验签 ===============================
 
 // VerifySign 支付宝异步通知验签（公钥模式）
@@ -1,10 +1,21 @@
 package alipay
 
 import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/alipay/cert"
 	"github.com/misu99/gopay/pkg/xlog"
 	"github.com/misu99/gopay/pkg/xrsa"
 )
@@ -146,3 +157,107 @@ func TestGetCertSN(t *testing.T) {
 	// 687b59193f3f462dd5336e5abf83c5d8_02941eef3187dddf3d3b83462e1dfcf6
 	// 687b59193f3f462dd5336e5abf83c5d8_02941eef3187dddf3d3b83462e1dfcf6
 }
+
+func TestAutoVerifySignByPublicKey(t *testing.T) {
+	priKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubBs, _ := x509.MarshalPKIXPublicKey(&priKey.PublicKey)
+	c := &Client{SignType: RSA2}
+	if err = c.AutoVerifySignByPublicKey(base64.StdEncoding.EncodeToString(pubBs)); err != nil {
+		t.Fatal(err)
+	}
+	signData := `{"code":"10000","msg":"Success","out_trade_no":"GZ201901301040361012"}`
+	h := sha256.Sum256([]byte(signData))
+	signBs, _ := rsa.SignPKCS1v15(rand.Reader, priKey, crypto.SHA256, h[:])
+	sign := base64.StdEncoding.EncodeToString(signBs)
+
+	rsp := `{"alipay_trade_query_response":` + signData + `,"sign":"` + sign + `"}`
+	if err = c.autoVerifySign(ctx, []byte(rsp), "alipay.trade.query"); err != nil {
+		t.Fatalf("autoVerifySign(): %v", err)
+	}
+	rsp = `{"alipay_trade_query_response":` + strings.Replace(signData, "GZ", "XX", 1) + `,"sign":"` + sign + `"}`
+	if err = c.autoVerifySign(ctx, []byte(rsp), "alipay.trade.query"); !errors.Is(err, gopay.VerifySignatureErr) {
+		t.Fatalf("sign mismatch, got: %v", err)
+	}
+	rsp = `{"alipay_trade_query_response":` + signData + `}`
+	if err = c.autoVerifySign(ctx, []byte(rsp), "alipay.trade.query"); !errors.Is(err, gopay.MissSignatureErr) {
+		t.Fatalf("missing sign, got: %v", err)
+	}
+	rsp = `{"alipay_trade_query_response":` + signData + `,"alipay_cert_sn":"abc","sign":"` + sign + `"}`
+	if err = c.autoVerifySign(ctx, []byte(rsp), "alipay.trade.query"); !errors.Is(err, gopay.CertNotMatchErr) {
+		t.Fatalf("unexpected alipay_cert_sn, got: %v", err)
+	}
+	// 网关层面错误无 sign，交由业务错误处理
+	rsp = `{"error_response":{"code":"40002","msg":"Invalid Arguments","sub_code":"isv.invalid-app-id"}}`
+	if err = c.autoVerifySign(ctx, []byte(rsp), "alipay.trade.query"); err != nil {
+		t.Fatalf("gateway error without sign, got: %v", err)
+	}
+	// 业务响应中的错误码不免验签
+	rsp = `{"alipay_trade_query_response":{"code":"40004","msg":"Business Failed","sub_code":"ACQ.TRADE_NOT_EXIST"}}`
+	if err = c.autoVerifySign(ctx, []byte(rsp), "alipay.trade.query"); !errors.Is(err, gopay.MissSignatureErr) {
+		t.Fatalf("business error without sign, got: %v", err)
+	}
+	rsp = `{"alipay_trade_query_response":` + signData + `,"error_response":{"code":"40002","msg":"Invalid Arguments"}}`
+	if err = c.autoVerifySign(ctx, []byte(rsp), "alipay.trade.query"); !errors.Is(err, gopay.MissSignatureErr) {
+		t.Fatalf("business response with error_response, got: %v", err)
+	}
+	rsp = `{"error_response":{"code":"10000","msg":"Success"}}`
+	if err = c.autoVerifySign(ctx, []byte(rsp), "alipay.trade.query"); !errors.Is(err, gopay.MissSignatureErr) {
+		t.Fatalf("error_response with success code, got: %v", err)
+	}
+}
+
+// 自动验签失败时，接口仍返回解析后的 aliRsp 及验签错误
+func TestAutoVerifySignResponse(t *testing.T) {
+	priKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubBs, _ := x509.MarshalPKIXPublicKey(&priKey.PublicKey)
+	c, err := NewClient(cert.Appid, cert.PrivateKey, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.AutoVerifySignByPublicKey(base64.StdEncoding.EncodeToString(pubBs)); err != nil {
+		t.Fatal(err)
+	}
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+	c.gatewayUrl = srv.URL + "/gateway.do?charset=utf-8"
+
+	signData := `{"code":"10000","msg":"Success","out_trade_no":"GZ201901301040361012","trade_status":"TRADE_SUCCESS"}`
+	h := sha256.Sum256([]byte(signData))
+	signBs, _ := rsa.SignPKCS1v15(rand.Reader, priKey, crypto.SHA256, h[:])
+	sign := base64.StdEncoding.EncodeToString(signBs)
+	bm := make(gopay.BodyMap)
+	bm.Set("out_trade_no", "GZ201901301040361012")
+
+	body = `{"alipay_trade_query_response":` + signData + `,"sign":"` + sign + `"}`
+	aliRsp, err := c.TradeQuery(ctx, bm)
+	if err != nil || aliRsp.Response.TradeStatus != "TRADE_SUCCESS" || aliRsp.SignData != signData {
+		t.Fatalf("TradeQuery() = %+v, %v", aliRsp, err)
+	}
+
+	body = `{"alipay_trade_query_response":` + strings.Replace(signData, "GZ", "XX", 1) + `,"sign":"` + sign + `"}`
+	aliRsp, err = c.TradeQuery(ctx, bm)
+	if !errors.Is(err, gopay.VerifySignatureErr) || aliRsp == nil || aliRsp.Response.OutTradeNo != "XX201901301040361012" {
+		t.Fatalf("sign mismatch, TradeQuery() = %+v, %v", aliRsp, err)
+	}
+	// 业务错误缺少 sign 时返回验签错误而不是业务错误
+	body = `{"alipay_trade_query_response":{"code":"40004","msg":"Business Failed","sub_code":"ACQ.TRADE_NOT_EXIST"}}`
+	aliRsp, err = c.TradeQuery(ctx, bm)
+	if !errors.Is(err, gopay.MissSignatureErr) || aliRsp == nil || aliRsp.Response.SubCode != "ACQ.TRADE_NOT_EXIST" {
+		t.Fatalf("missing sign, TradeQuery() = %+v, %v", aliRsp, err)
+	}
+
+	body = `{"alipay_trade_query_response":` + signData + `,"alipay_cert_sn":"abc","sign":"` + sign + `"}`
+	rsp := make(map[string]any)
+	if err = c.PostAliPayAPISelfV2(ctx, bm, "alipay.trade.query", &rsp); !errors.Is(err, gopay.CertNotMatchErr) || rsp["alipay_trade_query_response"] == nil {
+		t.Fatalf("unexpected alipay_cert_sn, PostAliPayAPISelfV2() = %v, %v", rsp, err)
+	}
+}
@@ -0,0 +1,301 @@
+package alipay
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/alipay"
+	"github.com/misu99/gopay/pkg/util"
+	"github.com/misu99/gopay/pkg/xhttp"
+	"github.com/misu99/gopay/pkg/xlog"
+	"github.com/misu99/gopay/pkg/xpem"
+	"github.com/misu99/gopay/pkg/xrsa"
+)
+
+// ClientV3 支付宝 V3
+type ClientV3 struct {
+	AppId              string
+	AppCertSN          string
+	AliPayPublicCertSN string
+	AliPayRootCertSN   string
+	AppAuthToken       string
+	IsProd             bool
+	autoSign           bool
+	bodySize           int // http response body size(MB), default is 10MB
+	privateKey         *rsa.PrivateKey
+	aliPayPublicKey    *rsa.PublicKey // 支付宝公钥（公钥模式）或支付宝公钥证书中的公钥（证书模式）
+	DebugSwitch        gopay.DebugSwitch
+}
+
+// NewClientV3 初始化支付宝客户端 V3
+// 注意：如果使用支付宝公钥证书验签，请设置证书 client.SetCert()
+// appid：应用ID
+// privateKey：应用私钥，支持PKCS1和PKCS8
+// isProd：是否是正式环境，沙箱环境请选择新版沙箱应用。
+func NewClientV3(appid, privateKey string, isProd bool) (client *ClientV3, err error) {
+	if appid == util.NULL || privateKey == util.NULL {
+		return nil, gopay.MissAlipayInitParamErr
+	}
+	key := xrsa.FormatAlipayPrivateKey(privateKey)
+	priKey, err := xpem.DecodePrivateKey([]byte(key))
+	if err != nil {
+		return nil, err
+	}
+	client = &ClientV3{
+		AppId:       appid,
+		IsProd:      isProd,
+		privateKey:  priKey,
+		DebugSwitch: gopay.DebugOff,
+	}
+	return client, nil
+}
+
+// SetCert 设置证书（公钥证书模式），设置 app_cert_sn、alipay_root_cert_sn、alipay_cert_sn
+// appCertContent：应用公钥证书文件内容
+// alipayRootCertContent：支付宝根证书文件内容
+// alipayPublicCertContent：支付宝公钥证书文件内容
+func (a *ClientV3) SetCert(appCertContent, alipayRootCertContent, alipayPublicCertContent []byte) (err error) {
+	appCertSn, err := alipay.GetCertSN(appCertContent)
+	if err != nil {
+		return fmt.Errorf("get app_cert_sn return err, err: %w", err)
+	}
+	rootCertSn, err := alipay.GetRootCertSN(alipayRootCertContent)
+	if err != nil {
+		return fmt.Errorf("get alipay_root_cert_sn return err, err: %w", err)
+	}
+	publicCertSn, err := alipay.GetCertSN(alipayPublicCertContent)
+	if err != nil {
+		return fmt.Errorf("get alipay_cert_sn return err, err: %w", err)
+	}
+	a.AppCertSN = appCertSn
+	a.AliPayRootCertSN = rootCertSn
+	a.AliPayPublicCertSN = publicCertSn
+	return nil
+}
+
+// AutoVerifySignByCert 开启请求完自动验签功能（默认不开启，推荐开启，公钥证书模式）
+// alipayPublicCertContent：支付宝公钥证书文件内容
+func (a *ClientV3) AutoVerifySignByCert(alipayPublicCertContent []byte) (err error) {
+	pubKey, err := xpem.DecodePublicKey(alipayPublicCertContent)
+	if err != nil {
+		return err
+	}
+	if a.AliPayPublicCertSN, err = alipay.GetCertSN(alipayPublicCertContent); err != nil {
+		return fmt.Errorf("get alipay_cert_sn return err, err: %w", err)
+	}
+	a.aliPayPublicKey = pubKey
+	a.autoSign = true
+	return nil
+}
+
+// AutoVerifySignByPublicKey 开启请求完自动验签功能（默认不开启，推荐开启，公钥模式）
+// alipayPublicKey：支付宝平台获取的支付宝公钥
+func (a *ClientV3) AutoVerifySignByPublicKey(alipayPublicKey string) (err error) {
+	pubKey, err := xpem.DecodePublicKey([]byte(xrsa.FormatAlipayPublicKey(alipayPublicKey)))
+	if err != nil {
+		return err
+	}
+	a.aliPayPublicKey = pubKey
+	a.autoSign = true
+	return nil
+}
+
+// SetAppAuthToken 设置应用授权，通过请求头 alipay-app-auth-token 传递
+// 注意：单次请求可通过 bm.Set("app_auth_token", "xxx") 指定
+func (a *ClientV3) SetAppAuthToken(appAuthToken string) (client *ClientV3) {
+	a.AppAuthToken = appAuthToken
+	return a
+}
+
+// SetBodySize 设置http response body size(MB)
+func (a *ClientV3) SetBodySize(sizeMB int) {
+	if sizeMB > 0 {
+		a.bodySize = sizeMB
+	}
+}
+
+// DoAliPayAPISelfV3 支付宝 V3 接口自行实现方法
+// method：MethodGet、MethodPost
+// path：接口路径，如 /v3/alipay/trade/query，GET 请求的查询参数通过 bm 传入
+// aliRsp：请求成功（HTTP 200）时，响应内容解析到的结构体指针
+// 返回参数 res：http 响应，请求失败时可根据 res.StatusCode 判断，错误信息解析到 ErrResponse
+func (a *ClientV3) DoAliPayAPISelfV3(ctx context.Context, method, path string, bm gopay.BodyMap, aliRsp any) (res *http.Response, errRsp *ErrResponse, err error) {
+	var (
+		si *SignInfo
+		bs []byte
+	)
+	aat := a.appAuthToken(bm)
+	switch method {
+	case MethodGet:
+		uri := path
+		if len(bm) > 0 {
+			uri += "?" + bm.EncodeURLParams()
+		}
+		authorization, err := a.authorization(MethodGet, uri, nil, aat)
+		if err != nil {
+			return nil, nil, err
+		}
+		if res, si, bs, err = a.doProdGet(ctx, uri, authorization, aat); err != nil {
+			return nil, nil, err
+		}
+	case MethodPost:
+		authorization, err := a.authorization(MethodPost, path, bm, aat)
+		if err != nil {
+			return nil, nil, err
+		}
+		if res, si, bs, err = a.doProdPost(ctx, bm, path, authorization, aat); err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported method: %s", method)
+	}
+	if res.StatusCode != http.StatusOK {
+		errRsp = new(ErrResponse)
+		_ = json.Unmarshal(bs, errRsp)
+		return res, errRsp, nil
+	}
+	if err = json.Unmarshal(bs, aliRsp); err != nil {
+		return nil, nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	return res, nil, a.verifySyncSign(si)
+}
+
+// FileUploadAPISelfV3 支付宝 V3 文件上传接口自行实现方法（multipart/form-data）
+// 注意：bm 中 *util.File 类型的参数作为文件上传，其余参数序列化为 JSON 后以 data 字段上传并参与签名
+// path：接口路径
+// aliRsp：请求成功（HTTP 200）时，响应内容解析到的结构体指针
+func (a *ClientV3) FileUploadAPISelfV3(ctx context.Context, path string, bm gopay.BodyMap, aliRsp any) (res *http.Response, errRsp *ErrResponse, err error) {
+	aat := a.appAuthToken(bm)
+	data, files := splitFileParams(bm)
+	authorization, err := a.authorization(MethodPost, path, data, aat)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, si, bs, err := a.doProdPostFile(ctx, data, files, path, authorization, aat)
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		errRsp = new(ErrResponse)
+		_ = json.Unmarshal(bs, errRsp)
+		return res, errRsp, nil
+	}
+	if err = json.Unmarshal(bs, aliRsp); err != nil {
+		return nil, nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	return res, nil, a.verifySyncSign(si)
+}
+
+func (a *ClientV3) host() string {
+	if a.IsProd {
+		return v3BaseUrl
+	}
+	return v3SandboxBaseUrl
+}
+
+// 获取 app_auth_token，bm 中指定的优先，并从请求体中移除
+func (a *ClientV3) appAuthToken(bm gopay.BodyMap) (aat string) {
+	aat = a.AppAuthToken
+	if bm != nil {
+		if v := bm.GetString("app_auth_token"); v != util.NULL {
+			aat = v
+		}
+		bm.Remove("app_auth_token")
+	}
+	return aat
+}
+
+// 将 bm 拆分为 data 参数及文件参数
+func splitFileParams(bm gopay.BodyMap) (data, files gopay.BodyMap) {
+	data, files = make(gopay.BodyMap), make(gopay.BodyMap)
+	for k, v := range bm {
+		switch v.(type) {
+		case *util.File, *util.FileReader:
+			files.Set(k, v)
+		default:
+			data.Set(k, v)
+		}
+	}
+	return data, files
+}
+
+func (a *ClientV3) newHttpClient(authorization, aat string) *xhttp.Client {
+	httpClient := xhttp.NewClient()
+	if a.bodySize > 0 {
+		httpClient.SetBodySize(a.bodySize)
+	}
+	httpClient.Header.Add(HeaderAuthorization, authorization)
+	httpClient.Header.Add(HeaderRequestID, fmt.Sprintf("%s-%d", util.RandomString(21), time.Now().Unix()))
+	if aat != util.NULL {
+		httpClient.Header.Add(HeaderAppAuthToken, aat)
+	}
+	if a.AliPayRootCertSN != util.NULL {
+		httpClient.Header.Add(HeaderRootCertSn, a.AliPayRootCertSN)
+	}
+	httpClient.Header.Add("Accept", "application/json")
+	return httpClient
+}
+
+func (a *ClientV3) doProdPost(ctx context.Context, bm gopay.BodyMap, path, authorization, aat string) (res *http.Response, si *SignInfo, bs []byte, err error) {
+	var url = a.host() + path
+	if a.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Alipay_V3_RequestBody: %s", bm.JsonBody())
+		xlog.Debugf("Alipay_V3_Authorization: %s", authorization)
+	}
+	res, bs, err = a.newHttpClient(authorization, aat).Type(xhttp.TypeJSON).Post(url).SendBodyMap(bm).EndBytes(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	si = a.signInfo(res, bs)
+	return res, si, bs, nil
+}
+
+func (a *ClientV3) doProdGet(ctx context.Context, uri, authorization, aat string) (res *http.Response, si *SignInfo, bs []byte, err error) {
+	var url = a.host() + uri
+	if a.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Alipay_V3_Url: %s", url)
+		xlog.Debugf("Alipay_V3_Authorization: %s", authorization)
+	}
+	res, bs, err = a.newHttpClient(authorization, aat).Type(xhttp.TypeJSON).Get(url).EndBytes(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	si = a.signInfo(res, bs)
+	return res, si, bs, nil
+}
+
+func (a *ClientV3) doProdPostFile(ctx context.Context, data, files gopay.BodyMap, path, authorization, aat string) (res *http.Response, si *SignInfo, bs []byte, err error) {
+	var url = a.host() + path
+	if a.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Alipay_V3_RequestData: %s", data.JsonBody())
+		xlog.Debugf("Alipay_V3_Authorization: %s", authorization)
+	}
+	files.Set("data", data.JsonBody())
+	res, bs, err = a.newHttpClient(authorization, aat).Type(xhttp.TypeMultipartFormData).Post(url).SendMultipartBodyMap(files).EndBytes(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	si = a.signInfo(res, bs)
+	return res, si, bs, nil
+}
+
+func (a *ClientV3) signInfo(res *http.Response, bs []byte) (si *SignInfo) {
+	si = &SignInfo{
+		HeaderTimestamp: res.Header.Get(HeaderTimestamp),
+		HeaderNonce:     res.Header.Get(HeaderNonce),
+		HeaderSignature: res.Header.Get(HeaderSignature),
+		HeaderSn:        res.Header.Get(HeaderSn),
+		HeaderTraceId:   res.Header.Get(HeaderTraceId),
+		SignBody:        string(bs),
+	}
+	if a.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Alipay_V3_Response: %d > %s", res.StatusCode, string(bs))
+		xlog.Debugf("Alipay_V3_SignInfo: %#v", si)
+	}
+	return si
+}
@@ -0,0 +1,65 @@
+package alipay
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/alipay/cert"
+)
+
+func TestClientV3_authorization(t *testing.T) {
+	client, err := NewClientV3(cert.Appid, cert.PrivateKey, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bm := make(gopay.BodyMap)
+	bm.Set("out_trade_no", "GZ201901301040361012").Set("app_auth_token", "token")
+	aat := client.appAuthToken(bm)
+	if aat != "token" || bm.GetString("app_auth_token") != "" {
+		t.Fatalf("appAuthToken() = %s, bm = %s", aat, bm.JsonBody())
+	}
+	authorization, err := client.authorization(MethodPost, v3TradeQuery, bm, aat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(authorization, SignTypeRSA+" app_id="+cert.Appid+",nonce=") {
+		t.Fatalf("authorization = %s", authorization)
+	}
+	idx := strings.Index(authorization, ",sign=")
+	authString := strings.TrimPrefix(authorization[:idx], SignTypeRSA+" ")
+	sign, _ := base64.StdEncoding.DecodeString(authorization[idx+6:])
+	h := sha256.Sum256([]byte(authString + "\nPOST\n" + v3TradeQuery + "\n" + bm.JsonBody() + "\ntoken\n"))
+	if err = rsa.VerifyPKCS1v15(&client.privateKey.PublicKey, crypto.SHA256, h[:], sign); err != nil {
+		t.Fatalf("verify authorization sign: %v", err)
+	}
+}
+
+func TestClientV3_verifySyncSign(t *testing.T) {
+	priKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &ClientV3{autoSign: true, aliPayPublicKey: &priKey.PublicKey}
+	body := `{"trade_no":"2019082922001432790585537960","out_trade_no":"GZ201901301040361012"}`
+	h := sha256.Sum256([]byte("1689764220120\nnonce\n" + body + "\n"))
+	signBs, _ := rsa.SignPKCS1v15(rand.Reader, priKey, crypto.SHA256, h[:])
+	si := &SignInfo{HeaderTimestamp: "1689764220120", HeaderNonce: "nonce", HeaderSignature: base64.StdEncoding.EncodeToString(signBs), SignBody: body}
+	if err = client.verifySyncSign(si); err != nil {
+		t.Fatal(err)
+	}
+	si.HeaderSn = "sn"
+	if err = client.verifySyncSign(si); !errors.Is(err, gopay.CertNotMatchErr) {
+		t.Fatalf("unexpected alipay-sn, err = %v", err)
+	}
+	si.HeaderSn, si.SignBody = "", body+" "
+	if err = client.verifySyncSign(si); !errors.Is(err, gopay.VerifySignatureErr) {
+		t.Fatalf("sign mismatch, err = %v", err)
+	}
+}
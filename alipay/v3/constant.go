@@ -0,0 +1,36 @@
+package alipay
+
+const (
+	Success = 0
+
+	MethodGet  = "GET"
+	MethodPost = "POST"
+
+	SignTypeRSA = "ALIPAY-SHA256withRSA"
+
+	HeaderAuthorization = "Authorization"
+	HeaderRequestID     = "alipay-request-id"
+	HeaderTimestamp     = "alipay-timestamp"
+	HeaderNonce         = "alipay-nonce"
+	HeaderSignature     = "alipay-signature"
+	HeaderSn            = "alipay-sn"
+	HeaderTraceId       = "alipay-trace-id"
+	HeaderAppAuthToken  = "alipay-app-auth-token"
+	HeaderRootCertSn    = "alipay-root-cert-sn"
+
+	v3BaseUrl        = "https://openapi.alipay.com"
+	v3SandboxBaseUrl = "https://openapi-sandbox.dl.alipaydev.com"
+
+	// 交易
+	v3TradePay                = "/v3/alipay/trade/pay"                  // 统一收单交易支付接口
+	v3TradePrecreate          = "/v3/alipay/trade/precreate"            // 统一收单线下交易预创建
+	v3TradeCreate             = "/v3/alipay/trade/create"               // 统一收单交易创建接口
+	v3TradeQuery              = "/v3/alipay/trade/query"                // 统一收单交易查询
+	v3TradeRefund             = "/v3/alipay/trade/refund"               // 统一收单交易退款接口
+	v3TradeFastPayRefundQuery = "/v3/alipay/trade/fastpay/refund/query" // 统一收单交易退款查询
+	v3TradeCancel             = "/v3/alipay/trade/cancel"               // 统一收单交易撤销接口
+	v3TradeClose              = "/v3/alipay/trade/close"                // 统一收单交易关闭接口
+
+	// 对账
+	v3DataBillDownloadUrlQuery = "/v3/alipay/data/dataservice/bill/downloadurl/query" // 查询对账单下载地址
+)
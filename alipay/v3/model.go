@@ -0,0 +1,261 @@
+package alipay
+
+type SignInfo struct {
+	HeaderTimestamp string `json:"alipay-timestamp"`
+	HeaderNonce     string `json:"alipay-nonce"`
+	HeaderSignature string `json:"alipay-signature"`
+	HeaderSn        string `json:"alipay-sn"`
+	HeaderTraceId   string `json:"alipay-trace-id"`
+	SignBody        string `json:"sign_body"`
+}
+
+// 请求失败（HTTP 状态码非 200）时的错误信息
+type ErrResponse struct {
+	Code    string     `json:"code"`
+	Message string     `json:"message"`
+	Links   []*ErrLink `json:"links,omitempty"`
+}
+
+type ErrLink struct {
+	Link string `json:"link"`
+	Desc string `json:"desc"`
+}
+
+// 统一收单交易支付接口 Rsp
+type TradePayRsp struct {
+	Code        int          `json:"-"`
+	SignInfo    *SignInfo    `json:"-"`
+	Response    *TradePay    `json:"response,omitempty"`
+	ErrResponse *ErrResponse `json:"-"`
+}
+
+// 统一收单线下交易预创建 Rsp
+type TradePrecreateRsp struct {
+	Code        int             `json:"-"`
+	SignInfo    *SignInfo       `json:"-"`
+	Response    *TradePrecreate `json:"response,omitempty"`
+	ErrResponse *ErrResponse    `json:"-"`
+}
+
+// 统一收单交易创建接口 Rsp
+type TradeCreateRsp struct {
+	Code        int          `json:"-"`
+	SignInfo    *SignInfo    `json:"-"`
+	Response    *TradeCreate `json:"response,omitempty"`
+	ErrResponse *ErrResponse `json:"-"`
+}
+
+// 统一收单交易查询 Rsp
+type TradeQueryRsp struct {
+	Code        int          `json:"-"`
+	SignInfo    *SignInfo    `json:"-"`
+	Response    *TradeQuery  `json:"response,omitempty"`
+	ErrResponse *ErrResponse `json:"-"`
+}
+
+// 统一收单交易退款接口 Rsp
+type TradeRefundRsp struct {
+	Code        int          `json:"-"`
+	SignInfo    *SignInfo    `json:"-"`
+	Response    *TradeRefund `json:"response,omitempty"`
+	ErrResponse *ErrResponse `json:"-"`
+}
+
+// 统一收单交易退款查询 Rsp
+type TradeFastPayRefundQueryRsp struct {
+	Code        int                      `json:"-"`
+	SignInfo    *SignInfo                `json:"-"`
+	Response    *TradeFastPayRefundQuery `json:"response,omitempty"`
+	ErrResponse *ErrResponse             `json:"-"`
+}
+
+// 统一收单交易撤销接口 Rsp
+type TradeCancelRsp struct {
+	Code        int          `json:"-"`
+	SignInfo    *SignInfo    `json:"-"`
+	Response    *TradeCancel `json:"response,omitempty"`
+	ErrResponse *ErrResponse `json:"-"`
+}
+
+// 统一收单交易关闭接口 Rsp
+type TradeCloseRsp struct {
+	Code        int          `json:"-"`
+	SignInfo    *SignInfo    `json:"-"`
+	Response    *TradeClose  `json:"response,omitempty"`
+	ErrResponse *ErrResponse `json:"-"`
+}
+
+// 查询对账单下载地址 Rsp
+type DataBillDownloadUrlQueryRsp struct {
+	Code        int                       `json:"-"`
+	SignInfo    *SignInfo                 `json:"-"`
+	Response    *DataBillDownloadUrlQuery `json:"response,omitempty"`
+	ErrResponse *ErrResponse              `json:"-"`
+}
+
+// =========================================================分割=========================================================
+
+type TradePay struct {
+	TradeNo             string           `json:"trade_no"`                         // 支付宝交易号
+	OutTradeNo          string           `json:"out_trade_no"`                     // 商户订单号
+	BuyerLogonId        string           `json:"buyer_logon_id"`                   // 买家支付宝账号
+	TotalAmount         string           `json:"total_amount"`                     // 交易金额
+	ReceiptAmount       string           `json:"receipt_amount"`                   // 实收金额
+	BuyerPayAmount      string           `json:"buyer_pay_amount,omitempty"`       // 买家付款的金额
+	PointAmount         string           `json:"point_amount,omitempty"`           // 使用集分宝付款的金额
+	InvoiceAmount       string           `json:"invoice_amount,omitempty"`         // 交易中可给用户开具发票的金额
+	GmtPayment          string           `json:"gmt_payment"`                      // 交易支付时间
+	FundBillList        []*TradeFundBill `json:"fund_bill_list"`                   // 交易支付使用的资金渠道
+	StoreName           string           `json:"store_name,omitempty"`             // 发生支付交易的商户门店名称
+	DiscountGoodsDetail string           `json:"discount_goods_detail,omitempty"`  // 本次交易支付所使用的单品券优惠的商品优惠信息
+	BuyerUserId         string           `json:"buyer_user_id,omitempty"`          // 买家在支付宝的用户id
+	BuyerOpenId         string           `json:"buyer_open_id,omitempty"`          // 买家支付宝用户唯一标识
+	MdiscountAmount     string           `json:"mdiscount_amount,omitempty"`       // 商家优惠金额
+	DiscountAmount      string           `json:"discount_amount,omitempty"`        // 平台优惠金额
+	BuyerUserName       string           `json:"buyer_user_name,omitempty"`        // 买家名称
+	AsyncPaymentMode    string           `json:"async_payment_mode,omitempty"`     // 异步支付模式
+	VoucherDetailList   []*VoucherDetail `json:"voucher_detail_list,omitempty"`    // 本交易支付时使用的所有优惠券信息
+	AdvanceAmount       string           `json:"advance_amount,omitempty"`         // 先享后付2.0垫资金额
+	AuthTradePayMode    string           `json:"auth_trade_pay_mode,omitempty"`    // 预授权支付模式
+	ChargeAmount        string           `json:"charge_amount,omitempty"`          // 该笔交易针对收款方的收费金额
+	ChargeFlags         string           `json:"charge_flags,omitempty"`           // 费率活动标识
+	SettlementId        string           `json:"settlement_id,omitempty"`          // 支付清算编号
+	BusinessParams      string           `json:"business_params,omitempty"`        // 返回的交易结算信息
+	BuyerPayAmountCents string           `json:"buyer_pay_amount_cents,omitempty"` // 买家付款的金额（分）
+}
+
+type TradeFundBill struct {
+	FundChannel string `json:"fund_channel"`          // 交易使用的资金渠道
+	Amount      string `json:"amount"`                // 该支付工具类型所使用的金额
+	RealAmount  string `json:"real_amount,omitempty"` // 渠道实际付款金额
+	FundType    string `json:"fund_type,omitempty"`   // 渠道所使用的资金类型
+}
+
+type VoucherDetail struct {
+	Id                         string `json:"id"`                                     // 券id
+	Name                       string `json:"name"`                                   // 券名称
+	Type                       string `json:"type"`                                   // 券类型
+	Amount                     string `json:"amount"`                                 // 优惠券面额
+	MerchantContribute         string `json:"merchant_contribute,omitempty"`          // 商家出资
+	OtherContribute            string `json:"other_contribute,omitempty"`             // 其他出资方出资金额
+	Memo                       string `json:"memo,omitempty"`                         // 优惠券备注信息
+	TemplateId                 string `json:"template_id,omitempty"`                  // 券模板id
+	PurchaseBuyerContribute    string `json:"purchase_buyer_contribute,omitempty"`    // 用户实际付款的金额
+	PurchaseMerchantContribute string `json:"purchase_merchant_contribute,omitempty"` // 商户优惠的金额
+	PurchaseAntContribute      string `json:"purchase_ant_contribute,omitempty"`      // 平台优惠的金额
+}
+
+type TradePrecreate struct {
+	OutTradeNo string `json:"out_trade_no"` // 商户订单号
+	QrCode     string `json:"qr_code"`      // 二维码码串
+}
+
+type TradeCreate struct {
+	OutTradeNo string `json:"out_trade_no"` // 商户订单号
+	TradeNo    string `json:"trade_no"`     // 支付宝交易号
+}
+
+type TradeQuery struct {
+	TradeNo             string           `json:"trade_no"`                         // 支付宝交易号
+	OutTradeNo          string           `json:"out_trade_no"`                     // 商户订单号
+	BuyerLogonId        string           `json:"buyer_logon_id"`                   // 买家支付宝账号
+	TradeStatus         string           `json:"trade_status"`                     // 交易状态：WAIT_BUYER_PAY、TRADE_CLOSED、TRADE_SUCCESS、TRADE_FINISHED
+	TotalAmount         string           `json:"total_amount"`                     // 交易的订单金额
+	TransCurrency       string           `json:"trans_currency,omitempty"`         // 标价币种
+	SettleCurrency      string           `json:"settle_currency,omitempty"`        // 订单结算币种
+	SettleAmount        string           `json:"settle_amount,omitempty"`          // 结算币种订单金额
+	PayCurrency         string           `json:"pay_currency,omitempty"`           // 订单支付币种
+	PayAmount           string           `json:"pay_amount,omitempty"`             // 支付币种订单金额
+	SettleTransRate     string           `json:"settle_trans_rate,omitempty"`      // 结算币种兑换标价币种汇率
+	TransPayRate        string           `json:"trans_pay_rate,omitempty"`         // 标价币种兑换支付币种汇率
+	BuyerPayAmount      string           `json:"buyer_pay_amount,omitempty"`       // 买家实付金额
+	PointAmount         string           `json:"point_amount,omitempty"`           // 积分支付的金额
+	InvoiceAmount       string           `json:"invoice_amount,omitempty"`         // 交易中用户支付的可开具发票的金额
+	SendPayDate         string           `json:"send_pay_date,omitempty"`          // 本次交易打款给卖家的时间
+	ReceiptAmount       string           `json:"receipt_amount,omitempty"`         // 实收金额
+	StoreId             string           `json:"store_id,omitempty"`               // 商户门店编号
+	TerminalId          string           `json:"terminal_id,omitempty"`            // 商户机具终端编号
+	FundBillList        []*TradeFundBill `json:"fund_bill_list,omitempty"`         // 交易支付使用的资金渠道
+	StoreName           string           `json:"store_name,omitempty"`             // 请求交易支付中的商户店铺的名称
+	BuyerUserId         string           `json:"buyer_user_id,omitempty"`          // 买家在支付宝的用户id
+	BuyerOpenId         string           `json:"buyer_open_id,omitempty"`          // 买家支付宝用户唯一标识
+	ChargeAmount        string           `json:"charge_amount,omitempty"`          // 该笔交易针对收款方的收费金额
+	ChargeFlags         string           `json:"charge_flags,omitempty"`           // 费率活动标识
+	SettlementId        string           `json:"settlement_id,omitempty"`          // 支付清算编号
+	AuthTradePayMode    string           `json:"auth_trade_pay_mode,omitempty"`    // 预授权支付模式
+	BuyerUserType       string           `json:"buyer_user_type,omitempty"`        // 买家用户类型
+	MdiscountAmount     string           `json:"mdiscount_amount,omitempty"`       // 商家优惠金额
+	DiscountAmount      string           `json:"discount_amount,omitempty"`        // 平台优惠金额
+	Subject             string           `json:"subject,omitempty"`                // 订单标题
+	Body                string           `json:"body,omitempty"`                   // 订单描述
+	AlipaySubMerchantId string           `json:"alipay_sub_merchant_id,omitempty"` // 间连商户在支付宝端的商户编号
+	ExtInfos            string           `json:"ext_infos,omitempty"`              // 交易额外信息
+	PassbackParams      string           `json:"passback_params,omitempty"`        // 公用回传参数
+	VoucherDetailList   []*VoucherDetail `json:"voucher_detail_list,omitempty"`    // 本交易支付时使用的所有优惠券信息
+}
+
+type TradeRefund struct {
+	TradeNo                 string           `json:"trade_no"`                             // 支付宝交易号
+	OutTradeNo              string           `json:"out_trade_no"`                         // 商户订单号
+	BuyerLogonId            string           `json:"buyer_logon_id"`                       // 用户的登录id
+	RefundFee               string           `json:"refund_fee"`                           // 退款总金额
+	FundChange              string           `json:"fund_change,omitempty"`                // 本次退款是否发生了资金变化
+	RefundDetailItemList    []*TradeFundBill `json:"refund_detail_item_list,omitempty"`    // 退款使用的资金渠道
+	StoreName               string           `json:"store_name,omitempty"`                 // 交易在支付时候的门店名称
+	BuyerUserId             string           `json:"buyer_user_id,omitempty"`              // 买家在支付宝的用户id
+	BuyerOpenId             string           `json:"buyer_open_id,omitempty"`              // 买家支付宝用户唯一标识
+	SendBackFee             string           `json:"send_back_fee,omitempty"`              // 本次商户实际退回金额
+	RefundHybAmount         string           `json:"refund_hyb_amount,omitempty"`          // 本次请求退惠营宝金额
+	RefundChargeInfoList    []*RefundCharge  `json:"refund_charge_info_list,omitempty"`    // 退费信息
+	RefundVoucherDetailList []*VoucherDetail `json:"refund_voucher_detail_list,omitempty"` // 本交易退款时使用的所有优惠券信息
+}
+
+type RefundCharge struct {
+	RefundChargeFee        string `json:"refund_charge_fee,omitempty"`         // 实退费用
+	SwitchFeeRate          string `json:"switch_fee_rate,omitempty"`           // 签约费率
+	ChargeType             string `json:"charge_type,omitempty"`               // 收单手续费 trade，花呗分期手续 hbfq，其他手续费如：余利宝 yuli
+	RefundChargeFeeDetails string `json:"refund_charge_fee_details,omitempty"` // 退费详情
+}
+
+type TradeFastPayRefundQuery struct {
+	TradeNo              string           `json:"trade_no,omitempty"`                // 支付宝交易号
+	OutTradeNo           string           `json:"out_trade_no,omitempty"`            // 商户订单号
+	OutRequestNo         string           `json:"out_request_no,omitempty"`          // 本笔退款对应的退款请求号
+	TotalAmount          string           `json:"total_amount,omitempty"`            // 该笔退款所对应的交易的订单金额
+	RefundAmount         string           `json:"refund_amount,omitempty"`           // 本次退款请求，对应的退款金额
+	RefundStatus         string           `json:"refund_status,omitempty"`           // 退款状态：REFUND_SUCCESS
+	RefundRoyaltys       []*RefundRoyalty `json:"refund_royaltys,omitempty"`         // 退分账明细信息
+	GmtRefundPay         string           `json:"gmt_refund_pay,omitempty"`          // 退款时间
+	RefundDetailItemList []*TradeFundBill `json:"refund_detail_item_list,omitempty"` // 本次退款使用的资金渠道
+	SendBackFee          string           `json:"send_back_fee,omitempty"`           // 本次商户实际退回金额
+	RefundHybAmount      string           `json:"refund_hyb_amount,omitempty"`       // 本次请求退惠营宝金额
+	RefundChargeInfoList []*RefundCharge  `json:"refund_charge_info_list,omitempty"` // 退费信息
+}
+
+type RefundRoyalty struct {
+	RefundAmount  string `json:"refund_amount"`             // 退分账金额
+	RoyaltyType   string `json:"royalty_type,omitempty"`    // 分账类型
+	ResultCode    string `json:"result_code"`               // 退分账结果码
+	TransOut      string `json:"trans_out,omitempty"`       // 转出人支付宝账号对应用户ID
+	TransOutEmail string `json:"trans_out_email,omitempty"` // 转出人支付宝账号
+	TransIn       string `json:"trans_in,omitempty"`        // 转入人支付宝账号对应用户ID
+	TransInEmail  string `json:"trans_in_email,omitempty"`  // 转入人支付宝账号
+}
+
+type TradeCancel struct {
+	TradeNo            string `json:"trade_no,omitempty"`             // 支付宝交易号
+	OutTradeNo         string `json:"out_trade_no,omitempty"`         // 商户订单号
+	RetryFlag          string `json:"retry_flag"`                     // 是否需要重试：Y/N
+	Action             string `json:"action,omitempty"`               // 本次撤销触发的交易动作：close、refund
+	GmtRefundPay       string `json:"gmt_refund_pay,omitempty"`       // 返回的退款时间
+	RefundSettlementId string `json:"refund_settlement_id,omitempty"` // 返回的退款清算编号
+}
+
+type TradeClose struct {
+	TradeNo    string `json:"trade_no,omitempty"`     // 支付宝交易号
+	OutTradeNo string `json:"out_trade_no,omitempty"` // 商户订单号
+}
+
+type DataBillDownloadUrlQuery struct {
+	BillDownloadUrl string `json:"bill_download_url"` // 账单下载地址链接，获取连接后30秒后未下载，链接地址失效
+}
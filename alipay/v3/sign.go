@@ -0,0 +1,97 @@
+package alipay
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/pkg/util"
+	"github.com/misu99/gopay/pkg/xlog"
+)
+
+// V3VerifySignByPK 支付宝 V3 同步返回验签
+// 推荐直接开启自动同步验签功能
+// timestamp、nonce、sign：响应头 alipay-timestamp、alipay-nonce、alipay-signature
+// signBody：响应体
+// alipayPublicKey：支付宝公钥
+func V3VerifySignByPK(timestamp, nonce, signBody, sign string, alipayPublicKey *rsa.PublicKey) (err error) {
+	if alipayPublicKey == nil || alipayPublicKey.N == nil {
+		return fmt.Errorf("[%w]: %v", gopay.VerifySignatureErr, "alipayPublicKey is nil")
+	}
+	if sign == util.NULL {
+		return fmt.Errorf("[%w]: %v", gopay.MissSignatureErr, "alipay-signature is empty")
+	}
+	str := timestamp + "\n" + nonce + "\n" + signBody + "\n"
+	signBytes, _ := base64.StdEncoding.DecodeString(sign)
+
+	h := sha256.New()
+	h.Write([]byte(str))
+	if err = rsa.VerifyPKCS1v15(alipayPublicKey, crypto.SHA256, h.Sum(nil), signBytes); err != nil {
+		return fmt.Errorf("[%w]: %v", gopay.VerifySignatureErr, err)
+	}
+	return nil
+}
+
+// 生成请求头 Authorization
+// 签名串：authString\n httpMethod\n httpRequestUrl\n httpRequestBody\n [appAuthToken\n]
+func (a *ClientV3) authorization(method, path string, bm gopay.BodyMap, aat string) (string, error) {
+	var (
+		jb        = ""
+		timestamp = time.Now().UnixNano() / 1e6
+		nonce     = util.RandomString(32)
+	)
+	if bm != nil {
+		jb = bm.JsonBody()
+	}
+	authString := "app_id=" + a.AppId
+	if a.AppCertSN != util.NULL {
+		authString += ",app_cert_sn=" + a.AppCertSN
+	}
+	authString += ",nonce=" + nonce + ",timestamp=" + util.Int642String(timestamp)
+	_str := authString + "\n" + method + "\n" + path + "\n" + jb + "\n"
+	if aat != util.NULL {
+		_str += aat + "\n"
+	}
+	if a.DebugSwitch == gopay.DebugOn {
+		xlog.Debugf("Alipay_V3_SignString:\n%s", _str)
+	}
+	sign, err := a.rsaSign(_str)
+	if err != nil {
+		return "", err
+	}
+	return SignTypeRSA + " " + authString + ",sign=" + sign, nil
+}
+
+func (a *ClientV3) rsaSign(str string) (string, error) {
+	if a.privateKey == nil {
+		return "", errors.New("privateKey can't be nil")
+	}
+	h := sha256.New()
+	h.Write([]byte(str))
+	result, err := rsa.SignPKCS1v15(rand.Reader, a.privateKey, crypto.SHA256, h.Sum(nil))
+	if err != nil {
+		return util.NULL, fmt.Errorf("[%w]: %+v", gopay.SignatureErr, err)
+	}
+	return base64.StdEncoding.EncodeToString(result), nil
+}
+
+// 自动同步验签
+// 公钥证书模式：响应头 alipay-sn 需与支付宝公钥证书SN一致；公钥模式：响应头不应包含 alipay-sn
+func (a *ClientV3) verifySyncSign(si *SignInfo) (err error) {
+	if !a.autoSign {
+		return nil
+	}
+	if si == nil {
+		return errors.New("auto verify sign, but SignInfo is nil")
+	}
+	if si.HeaderSn != util.NULL && si.HeaderSn != a.AliPayPublicCertSN {
+		return fmt.Errorf("[%w], 当前使用的支付宝公钥证书SN[%s]与网关响应头中的SN[%s]不匹配", gopay.CertNotMatchErr, a.AliPayPublicCertSN, si.HeaderSn)
+	}
+	return V3VerifySignByPK(si.HeaderTimestamp, si.HeaderNonce, si.SignBody, si.HeaderSignature, a.aliPayPublicKey)
+}
@@ -0,0 +1,287 @@
+package alipay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/misu99/gopay"
+)
+
+// 统一收单交易支付接口
+// Code = 0 is success
+func (a *ClientV3) TradePay(ctx context.Context, bm gopay.BodyMap) (aliRsp *TradePayRsp, err error) {
+	err = bm.CheckEmptyError("out_trade_no", "total_amount", "subject", "auth_code", "scene")
+	if err != nil {
+		return nil, err
+	}
+	aat := a.appAuthToken(bm)
+	authorization, err := a.authorization(MethodPost, v3TradePay, bm, aat)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := a.doProdPost(ctx, bm, v3TradePay, authorization, aat)
+	if err != nil {
+		return nil, err
+	}
+	aliRsp = &TradePayRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusOK {
+		aliRsp.Code = res.StatusCode
+		aliRsp.ErrResponse = new(ErrResponse)
+		if err = json.Unmarshal(bs, aliRsp.ErrResponse); err != nil {
+			return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+		}
+		return aliRsp, nil
+	}
+	aliRsp.Response = new(TradePay)
+	if err = json.Unmarshal(bs, aliRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	return aliRsp, a.verifySyncSign(si)
+}
+
+// 统一收单线下交易预创建
+// Code = 0 is success
+func (a *ClientV3) TradePrecreate(ctx context.Context, bm gopay.BodyMap) (aliRsp *TradePrecreateRsp, err error) {
+	err = bm.CheckEmptyError("out_trade_no", "total_amount", "subject")
+	if err != nil {
+		return nil, err
+	}
+	aat := a.appAuthToken(bm)
+	authorization, err := a.authorization(MethodPost, v3TradePrecreate, bm, aat)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := a.doProdPost(ctx, bm, v3TradePrecreate, authorization, aat)
+	if err != nil {
+		return nil, err
+	}
+	aliRsp = &TradePrecreateRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusOK {
+		aliRsp.Code = res.StatusCode
+		aliRsp.ErrResponse = new(ErrResponse)
+		if err = json.Unmarshal(bs, aliRsp.ErrResponse); err != nil {
+			return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+		}
+		return aliRsp, nil
+	}
+	aliRsp.Response = new(TradePrecreate)
+	if err = json.Unmarshal(bs, aliRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	return aliRsp, a.verifySyncSign(si)
+}
+
+// 统一收单交易创建接口
+// Code = 0 is success
+func (a *ClientV3) TradeCreate(ctx context.Context, bm gopay.BodyMap) (aliRsp *TradeCreateRsp, err error) {
+	err = bm.CheckEmptyError("out_trade_no", "total_amount", "subject")
+	if err != nil {
+		return nil, err
+	}
+	aat := a.appAuthToken(bm)
+	authorization, err := a.authorization(MethodPost, v3TradeCreate, bm, aat)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := a.doProdPost(ctx, bm, v3TradeCreate, authorization, aat)
+	if err != nil {
+		return nil, err
+	}
+	aliRsp = &TradeCreateRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusOK {
+		aliRsp.Code = res.StatusCode
+		aliRsp.ErrResponse = new(ErrResponse)
+		if err = json.Unmarshal(bs, aliRsp.ErrResponse); err != nil {
+			return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+		}
+		return aliRsp, nil
+	}
+	aliRsp.Response = new(TradeCreate)
+	if err = json.Unmarshal(bs, aliRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	return aliRsp, a.verifySyncSign(si)
+}
+
+// 统一收单交易查询
+// Code = 0 is success
+func (a *ClientV3) TradeQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *TradeQueryRsp, err error) {
+	aat := a.appAuthToken(bm)
+	authorization, err := a.authorization(MethodPost, v3TradeQuery, bm, aat)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := a.doProdPost(ctx, bm, v3TradeQuery, authorization, aat)
+	if err != nil {
+		return nil, err
+	}
+	aliRsp = &TradeQueryRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusOK {
+		aliRsp.Code = res.StatusCode
+		aliRsp.ErrResponse = new(ErrResponse)
+		if err = json.Unmarshal(bs, aliRsp.ErrResponse); err != nil {
+			return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+		}
+		return aliRsp, nil
+	}
+	aliRsp.Response = new(TradeQuery)
+	if err = json.Unmarshal(bs, aliRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	return aliRsp, a.verifySyncSign(si)
+}
+
+// 统一收单交易退款接口
+// Code = 0 is success
+func (a *ClientV3) TradeRefund(ctx context.Context, bm gopay.BodyMap) (aliRsp *TradeRefundRsp, err error) {
+	err = bm.CheckEmptyError("refund_amount")
+	if err != nil {
+		return nil, err
+	}
+	aat := a.appAuthToken(bm)
+	authorization, err := a.authorization(MethodPost, v3TradeRefund, bm, aat)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := a.doProdPost(ctx, bm, v3TradeRefund, authorization, aat)
+	if err != nil {
+		return nil, err
+	}
+	aliRsp = &TradeRefundRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusOK {
+		aliRsp.Code = res.StatusCode
+		aliRsp.ErrResponse = new(ErrResponse)
+		if err = json.Unmarshal(bs, aliRsp.ErrResponse); err != nil {
+			return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+		}
+		return aliRsp, nil
+	}
+	aliRsp.Response = new(TradeRefund)
+	if err = json.Unmarshal(bs, aliRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	return aliRsp, a.verifySyncSign(si)
+}
+
+// 统一收单交易退款查询
+// Code = 0 is success
+func (a *ClientV3) TradeFastPayRefundQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *TradeFastPayRefundQueryRsp, err error) {
+	err = bm.CheckEmptyError("out_request_no")
+	if err != nil {
+		return nil, err
+	}
+	aat := a.appAuthToken(bm)
+	authorization, err := a.authorization(MethodPost, v3TradeFastPayRefundQuery, bm, aat)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := a.doProdPost(ctx, bm, v3TradeFastPayRefundQuery, authorization, aat)
+	if err != nil {
+		return nil, err
+	}
+	aliRsp = &TradeFastPayRefundQueryRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusOK {
+		aliRsp.Code = res.StatusCode
+		aliRsp.ErrResponse = new(ErrResponse)
+		if err = json.Unmarshal(bs, aliRsp.ErrResponse); err != nil {
+			return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+		}
+		return aliRsp, nil
+	}
+	aliRsp.Response = new(TradeFastPayRefundQuery)
+	if err = json.Unmarshal(bs, aliRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	return aliRsp, a.verifySyncSign(si)
+}
+
+// 统一收单交易撤销接口
+// Code = 0 is success
+func (a *ClientV3) TradeCancel(ctx context.Context, bm gopay.BodyMap) (aliRsp *TradeCancelRsp, err error) {
+	aat := a.appAuthToken(bm)
+	authorization, err := a.authorization(MethodPost, v3TradeCancel, bm, aat)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := a.doProdPost(ctx, bm, v3TradeCancel, authorization, aat)
+	if err != nil {
+		return nil, err
+	}
+	aliRsp = &TradeCancelRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusOK {
+		aliRsp.Code = res.StatusCode
+		aliRsp.ErrResponse = new(ErrResponse)
+		if err = json.Unmarshal(bs, aliRsp.ErrResponse); err != nil {
+			return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+		}
+		return aliRsp, nil
+	}
+	aliRsp.Response = new(TradeCancel)
+	if err = json.Unmarshal(bs, aliRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	return aliRsp, a.verifySyncSign(si)
+}
+
+// 统一收单交易关闭接口
+// Code = 0 is success
+func (a *ClientV3) TradeClose(ctx context.Context, bm gopay.BodyMap) (aliRsp *TradeCloseRsp, err error) {
+	aat := a.appAuthToken(bm)
+	authorization, err := a.authorization(MethodPost, v3TradeClose, bm, aat)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := a.doProdPost(ctx, bm, v3TradeClose, authorization, aat)
+	if err != nil {
+		return nil, err
+	}
+	aliRsp = &TradeCloseRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusOK {
+		aliRsp.Code = res.StatusCode
+		aliRsp.ErrResponse = new(ErrResponse)
+		if err = json.Unmarshal(bs, aliRsp.ErrResponse); err != nil {
+			return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+		}
+		return aliRsp, nil
+	}
+	aliRsp.Response = new(TradeClose)
+	if err = json.Unmarshal(bs, aliRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	return aliRsp, a.verifySyncSign(si)
+}
+
+// 查询对账单下载地址
+// Code = 0 is success
+func (a *ClientV3) DataBillDownloadUrlQuery(ctx context.Context, bm gopay.BodyMap) (aliRsp *DataBillDownloadUrlQueryRsp, err error) {
+	err = bm.CheckEmptyError("bill_type", "bill_date")
+	if err != nil {
+		return nil, err
+	}
+	aat := a.appAuthToken(bm)
+	uri := v3DataBillDownloadUrlQuery + "?" + bm.EncodeURLParams()
+	authorization, err := a.authorization(MethodGet, uri, nil, aat)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := a.doProdGet(ctx, uri, authorization, aat)
+	if err != nil {
+		return nil, err
+	}
+	aliRsp = &DataBillDownloadUrlQueryRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusOK {
+		aliRsp.Code = res.StatusCode
+		aliRsp.ErrResponse = new(ErrResponse)
+		if err = json.Unmarshal(bs, aliRsp.ErrResponse); err != nil {
+			return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+		}
+		return aliRsp, nil
+	}
+	aliRsp.Response = new(DataBillDownloadUrlQuery)
+	if err = json.Unmarshal(bs, aliRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	return aliRsp, a.verifySyncSign(si)
+}
@@ -43,6 +43,12 @@ func (bm BodyMap) SetFormFile(key string, file *util.File) BodyMap {
 	return bm
 }
 
+// 设置流式上传的文件，multipart 请求时按块读取，不整体载入内存
+func (bm BodyMap) SetFormFileReader(key string, file *util.FileReader) BodyMap {
+	bm[key] = file
+	return bm
+}
+
 // 获取参数，同 GetString()
 func (bm BodyMap) Get(key string) string {
 	return bm.GetString(key)
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/pkg/util"
+	"github.com/misu99/gopay/pkg/xhttp"
+	wechatv3 "github.com/misu99/gopay/wechat/v3"
+)
+
+// billResult 账单下载结果，微信账单会解析为明细与汇总
+type billResult struct {
+	DownloadUrl string              `json:"download_url"`
+	File        string              `json:"file,omitempty"` // -out 保存的原始账单文件
+	Size        int                 `json:"size"`
+	Records     []map[string]string `json:"records,omitempty"`
+	Summary     map[string]string   `json:"summary,omitempty"`
+}
+
+// gopay bill -provider wechat -date 2023-01-01 [-type ALL] [-out bill.csv] [-dry-run]
+// gopay bill -provider alipay -date 2023-01-01 [-type trade] -out bill.zip [-dry-run]
+func runBill(ctx context.Context, args []string, stdout io.Writer) error {
+	fs := newFlagSet("bill")
+	cfgPath := configFlag(fs)
+	provider := fs.String("provider", providerWechat, "alipay 或 wechat")
+	date := fs.String("date", "", "账单日期，wechat 格式 yyyy-MM-dd（默认前一天），alipay 格式 yyyy-MM-dd 或 yyyy-MM")
+	billType := fs.String("type", "", "账单类型，wechat 默认 ALL，alipay 默认 trade")
+	out := fs.String("out", "", "原始账单文件保存路径，alipay 必填（zip 压缩包）")
+	dryRun := fs.Bool("dry-run", false, "只输出签名后的申请账单请求，不实际发送")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := loadConfig(*cfgPath)
+	if err != nil {
+		return err
+	}
+	switch *provider {
+	case providerWechat:
+		bm := make(gopay.BodyMap)
+		if bm.Set("bill_date", *date); *date == util.NULL {
+			bm.Set("bill_date", time.Now().AddDate(0, 0, -1).Format(util.DateLayout))
+		}
+		if *billType != util.NULL {
+			bm.Set("bill_type", *billType)
+		}
+		client, err := cfg.wechatClient()
+		if err != nil {
+			return err
+		}
+		if *dryRun {
+			req, err := wechatDryRun(client, wechatv3.MethodGet, "/v3/bill/tradebill?"+bm.EncodeURLParams(), nil)
+			if err != nil {
+				return err
+			}
+			return writeJSON(stdout, req)
+		}
+		if err = cfg.wechatAutoVerify(client); err != nil {
+			return err
+		}
+		wxRsp, err := client.V3BillTradeBill(ctx, bm)
+		if err != nil {
+			return err
+		}
+		if wxRsp.Code != wechatv3.Success {
+			return fmt.Errorf("apply trade bill: %d, %s", wxRsp.Code, wxRsp.Error)
+		}
+		bs, err := client.V3BillDownLoadBill(ctx, wxRsp.Response.DownloadUrl)
+		if err != nil {
+			return err
+		}
+		if bs, err = gunzip(bs); err != nil {
+			return err
+		}
+		rs := &billResult{DownloadUrl: wxRsp.Response.DownloadUrl, Size: len(bs)}
+		if err = saveFile(*out, bs); err != nil {
+			return err
+		}
+		rs.File = *out
+		rs.Records, rs.Summary = parseWechatBill(bs)
+		return writeJSON(stdout, rs)
+	case providerAlipay:
+		if *date == util.NULL {
+			return fmt.Errorf("missing -date")
+		}
+		bm := make(gopay.BodyMap)
+		bm.Set("bill_date", *date)
+		if bm.Set("bill_type", *billType); *billType == util.NULL {
+			bm.Set("bill_type", "trade")
+		}
+		client, err := cfg.alipayClient()
+		if err != nil {
+			return err
+		}
+		if *dryRun {
+			req, err := alipayDryRun(client, "alipay.data.dataservice.bill.downloadurl.query", bm)
+			if err != nil {
+				return err
+			}
+			return writeJSON(stdout, req)
+		}
+		if *out == util.NULL {
+			return fmt.Errorf("missing -out")
+		}
+		aliRsp, err := client.DataBillDownloadUrlQuery(ctx, bm)
+		if err != nil {
+			return err
+		}
+		res, bs, err := xhttp.NewClient().Get(aliRsp.Response.BillDownloadUrl).EndBytes(ctx)
+		if err != nil {
+			return err
+		}
+		if res.StatusCode != http.StatusOK {
+			return fmt.Errorf("download bill: HTTP %d", res.StatusCode)
+		}
+		if err = saveFile(*out, bs); err != nil {
+			return err
+		}
+		return writeJSON(stdout, &billResult{DownloadUrl: aliRsp.Response.BillDownloadUrl, File: *out, Size: len(bs)})
+	default:
+		return fmt.Errorf("unsupported provider: %s", *provider)
+	}
+}
+
+func saveFile(path string, bs []byte) error {
+	if path == util.NULL {
+		return nil
+	}
+	return os.WriteFile(path, bs, 0o600)
+}
+
+// gunzip 申请账单时 tar_type=GZIP 下载的是 gzip 压缩内容
+func gunzip(bs []byte) ([]byte, error) {
+	if len(bs) < 2 || bs[0] != 0x1f || bs[1] != 0x8b {
+		return bs, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(bs))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// parseWechatBill 解析微信账单文本
+// 格式：第一行为明细表头，之后每行以 ` 开头为明细数据，再之后为汇总表头与汇总数据
+func parseWechatBill(bs []byte) (records []map[string]string, summary map[string]string) {
+	var (
+		header  []string
+		scanner = bufio.NewScanner(bytes.NewReader(bytes.TrimPrefix(bs, []byte("\xef\xbb\xbf"))))
+	)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	inSummary := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == util.NULL {
+			continue
+		}
+		fields := splitWechatBillLine(line)
+		if !strings.HasPrefix(line, "`") {
+			// 表头行：第一次为明细表头，之后为汇总表头
+			if header != nil {
+				inSummary = true
+			}
+			header = fields
+			continue
+		}
+		row := make(map[string]string, len(header))
+		for i, v := range fields {
+			if i < len(header) {
+				row[header[i]] = v
+			}
+		}
+		if inSummary {
+			summary = row
+			continue
+		}
+		records = append(records, row)
+	}
+	return records, summary
+}
+
+func splitWechatBillLine(line string) []string {
+	fields := strings.Split(line, ",")
+	for i, v := range fields {
+		fields[i] = strings.TrimPrefix(strings.TrimSpace(v), "`")
+	}
+	return fields
+}
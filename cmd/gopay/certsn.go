@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/misu99/gopay/alipay"
+)
+
+type certSNResult struct {
+	CertSN     map[string]string `json:"cert_sn,omitempty"`      // key: 证书文件路径
+	RootCertSN string            `json:"root_cert_sn,omitempty"` // alipay_root_cert_sn
+}
+
+// gopay certsn -cert appPublicCert.crt -cert alipayPublicCert.crt -root alipayRootCert.crt
+func runCertSN(_ context.Context, args []string, stdout io.Writer) error {
+	var certs stringsFlag
+	fs := newFlagSet("certsn")
+	fs.Var(&certs, "cert", "应用公钥证书或支付宝公钥证书路径，可重复指定")
+	root := fs.String("root", "", "支付宝根证书路径")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if len(certs) == 0 && *root == "" {
+		fs.Usage()
+		return errors.New("at least one of -cert or -root is required")
+	}
+	rs := new(certSNResult)
+	if len(certs) > 0 {
+		rs.CertSN = make(map[string]string, len(certs))
+	}
+	for _, path := range certs {
+		sn, err := alipay.GetCertSN(path)
+		if err != nil {
+			return err
+		}
+		rs.CertSN[path] = sn
+	}
+	if *root != "" {
+		sn, err := alipay.GetRootCertSN(*root)
+		if err != nil {
+			return err
+		}
+		rs.RootCertSN = sn
+	}
+	return writeJSON(stdout, rs)
+}
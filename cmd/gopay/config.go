@@ -0,0 +1,171 @@
+package main
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/alipay"
+	"github.com/misu99/gopay/pkg/util"
+	"github.com/misu99/gopay/pkg/xpem"
+	wechatv3 "github.com/misu99/gopay/wechat/v3"
+)
+
+const defaultConfigFile = "gopay.json"
+
+// Config 命令行配置文件，JSON 格式
+type Config struct {
+	Alipay *AlipayConfig `json:"alipay,omitempty"`
+	Wechat *WechatConfig `json:"wechat,omitempty"`
+	Debug  bool          `json:"debug,omitempty"`
+}
+
+type AlipayConfig struct {
+	AppId           string `json:"app_id"`
+	PrivateKey      string `json:"private_key,omitempty"`       // 应用私钥内容，支持PKCS1和PKCS8
+	PrivateKeyFile  string `json:"private_key_file,omitempty"`  // 应用私钥文件路径，与 private_key 二选一
+	IsProd          bool   `json:"is_prod"`                     // 是否是正式环境
+	AlipayPublicKey string `json:"alipay_public_key,omitempty"` // 公钥模式：支付宝公钥
+	AppCertFile     string `json:"app_cert_file,omitempty"`     // 证书模式：应用公钥证书 appPublicCert.crt
+	RootCertFile    string `json:"root_cert_file,omitempty"`    // 证书模式：支付宝根证书 alipayRootCert.crt
+	PublicCertFile  string `json:"public_cert_file,omitempty"`  // 证书模式：支付宝公钥证书 alipayPublicCert.crt
+}
+
+type WechatConfig struct {
+	MchId            string `json:"mchid"`
+	SerialNo         string `json:"serial_no"`                    // 商户API证书序列号
+	ApiV3Key         string `json:"api_v3_key"`                   // APIv3Key
+	ApiKey           string `json:"api_key,omitempty"`            // v2 API秘钥，仅 wechat-v2 签名/验签使用
+	PrivateKey       string `json:"private_key,omitempty"`        // 商户私钥 apiclient_key.pem 内容
+	PrivateKeyFile   string `json:"private_key_file,omitempty"`   // 商户私钥文件路径，与 private_key 二选一
+	PlatformCertFile string `json:"platform_cert_file,omitempty"` // 微信平台证书文件路径，用于验签
+	PlatformSerialNo string `json:"platform_serial_no,omitempty"` // 微信平台证书序列号
+	AutoVerifySign   bool   `json:"auto_verify_sign,omitempty"`   // 是否下载平台证书并开启自动验签
+}
+
+func configFlag(fs *flag.FlagSet) *string {
+	def := os.Getenv("GOPAY_CONFIG")
+	if def == util.NULL {
+		def = defaultConfigFile
+	}
+	return fs.String("config", def, "配置文件路径（JSON）")
+}
+
+func loadConfig(path string) (*Config, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+	cfg := new(Config)
+	if err = json.Unmarshal(bs, cfg); err != nil {
+		return nil, fmt.Errorf("parse config file(%s): %w", path, err)
+	}
+	return cfg, nil
+}
+
+func keyContent(content, file string) (string, error) {
+	if content != util.NULL {
+		return content, nil
+	}
+	if file == util.NULL {
+		return util.NULL, nil
+	}
+	bs, err := os.ReadFile(file)
+	if err != nil {
+		return util.NULL, err
+	}
+	return string(bs), nil
+}
+
+func (c *Config) alipayClient() (*alipay.Client, error) {
+	ac := c.Alipay
+	if ac == nil {
+		return nil, errors.New("missing alipay config")
+	}
+	privateKey, err := keyContent(ac.PrivateKey, ac.PrivateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	client, err := alipay.NewClient(ac.AppId, privateKey, ac.IsProd)
+	if err != nil {
+		return nil, err
+	}
+	if c.Debug {
+		client.DebugSwitch = gopay.DebugOn
+	}
+	switch {
+	case ac.AppCertFile != util.NULL || ac.RootCertFile != util.NULL || ac.PublicCertFile != util.NULL:
+		// 证书模式
+		if ac.AppCertFile == util.NULL || ac.RootCertFile == util.NULL || ac.PublicCertFile == util.NULL {
+			return nil, errors.New("alipay cert mode requires app_cert_file, root_cert_file and public_cert_file")
+		}
+		if err = client.SetCertSnByPath(ac.AppCertFile, ac.RootCertFile, ac.PublicCertFile); err != nil {
+			return nil, err
+		}
+		publicCert, err := os.ReadFile(ac.PublicCertFile)
+		if err != nil {
+			return nil, err
+		}
+		// AutoVerifySign 解析失败时仅打印日志，这里先校验证书内容
+		if _, err = xpem.DecodePublicKey(publicCert); err != nil {
+			return nil, fmt.Errorf("decode alipay public cert(%s): %w", ac.PublicCertFile, err)
+		}
+		client.AutoVerifySign(publicCert)
+	case ac.AlipayPublicKey != util.NULL:
+		// 公钥模式
+		if err = client.AutoVerifySignByPublicKey(ac.AlipayPublicKey); err != nil {
+			return nil, err
+		}
+	}
+	return client, nil
+}
+
+func (c *Config) wechatClient() (*wechatv3.ClientV3, error) {
+	wc := c.Wechat
+	if wc == nil {
+		return nil, errors.New("missing wechat config")
+	}
+	privateKey, err := keyContent(wc.PrivateKey, wc.PrivateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	client, err := wechatv3.NewClientV3(wc.MchId, wc.SerialNo, wc.ApiV3Key, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	if c.Debug {
+		client.DebugSwitch = gopay.DebugOn
+	}
+	if wc.PlatformCertFile != util.NULL {
+		cert, err := os.ReadFile(wc.PlatformCertFile)
+		if err != nil {
+			return nil, err
+		}
+		client.SetPlatformCert(cert, wc.PlatformSerialNo)
+	}
+	return client, nil
+}
+
+// wechatAutoVerify 按配置开启自动验签，会请求微信平台证书接口，dry-run 时不调用
+func (c *Config) wechatAutoVerify(client *wechatv3.ClientV3) error {
+	if c.Wechat != nil && c.Wechat.AutoVerifySign {
+		return client.AutoVerifySign(false)
+	}
+	return nil
+}
+
+// wechatPlatformPublicKey 读取配置的微信平台证书公钥
+func (c *Config) wechatPlatformPublicKey() (*rsa.PublicKey, error) {
+	if c.Wechat == nil || c.Wechat.PlatformCertFile == util.NULL {
+		return nil, errors.New("missing wechat.platform_cert_file config")
+	}
+	cert, err := os.ReadFile(c.Wechat.PlatformCertFile)
+	if err != nil {
+		return nil, err
+	}
+	return xpem.DecodePublicKey(cert)
+}
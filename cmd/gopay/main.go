@@ -0,0 +1,124 @@
+// gopay 命令行工具，用于运维排查与调试
+//
+// 基于 gopay 已有能力，提供签名/验签、微信v3回调解密、支付宝证书SN计算、
+// 账单下载解析、订单查询等功能，所有结果以 JSON 格式输出。
+//
+// 用法：
+//
+//	gopay <command> [flags]
+//
+// 支持的 command：
+//
+//	certsn   计算支付宝证书SN（app_cert_sn、alipay_cert_sn、alipay_root_cert_sn）
+//	sign     对请求参数签名（alipay、wechat、wechat-v2）
+//	verify   对同步返回或异步通知验签（alipay、wechat、wechat-v2）
+//	notify   解析并解密微信v3异步通知
+//	bill     申请并下载账单（alipay、wechat）
+//	query    查询订单（alipay、wechat）
+//
+// 需要商户信息的 command 通过 -config 指定配置文件，默认读取环境变量 GOPAY_CONFIG，再次为 ./gopay.json
+// 发起请求的 command 支持 -dry-run，只输出签名后的请求，不实际发送
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, args []string, stdout io.Writer) error
+}
+
+var commands = map[string]*command{
+	"certsn": {usage: "计算支付宝证书SN", run: runCertSN},
+	"sign":   {usage: "对请求参数签名", run: runSign},
+	"verify": {usage: "同步返回或异步通知验签", run: runVerify},
+	"notify": {usage: "解析并解密微信v3异步通知", run: runNotify},
+	"bill":   {usage: "申请并下载账单", run: runBill},
+	"query":  {usage: "查询订单", run: runQuery},
+}
+
+var errUsage = errors.New("usage")
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout); err != nil {
+		if !errors.Is(err, errUsage) && !errors.Is(err, flag.ErrHelp) {
+			_ = writeJSON(os.Stderr, map[string]string{"error": err.Error()})
+		}
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		usage(os.Stderr)
+		return errUsage
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		usage(os.Stderr)
+		return fmt.Errorf("unknown command: %s", args[0])
+	}
+	return cmd.run(ctx, args[1:], stdout)
+}
+
+func usage(w io.Writer) {
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(w, "Usage: gopay <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-8s %s\n", name, commands[name].usage)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "gopay <command> -h" for more information about a command.`)
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// stringsFlag 可重复指定的 flag，如 -cert a.crt -cert b.crt
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringsFlag) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+// readInput 读取 flag 传入的内容，以 @ 开头表示文件路径，- 表示标准输入
+func readInput(v string) ([]byte, error) {
+	switch {
+	case v == "-":
+		return io.ReadAll(os.Stdin)
+	case strings.HasPrefix(v, "@"):
+		return os.ReadFile(v[1:])
+	default:
+		return []byte(v), nil
+	}
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/misu99/gopay/alipay"
+	"github.com/misu99/gopay/alipay/cert"
+	"github.com/misu99/gopay/pkg/aes"
+	"github.com/misu99/gopay/pkg/xpem"
+	"github.com/misu99/gopay/pkg/xrsa"
+)
+
+const appPublicKey = "MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAw0x6QX0eeW+XRNh4u/BG1RsyuYYsHmqenk4GrPV8ElrUEN6nLRwSXGSIIuIuyCo0t4swCNp9Q54g+AUmpAddeBCYhHKrAYG4n11MnXUYosEe43wzUhd7PaXpxctFlSKhFgYBiX7cQg/O8ThHJJ0H0Qy+k9NNJ2gMnfPypk9/43DstHmKEVQvgQpcgnhlbK8X4thIK4zW0xwHgDhSAeZu9QLvf/cc2PdKmd5xiUUM7J7PtwT7VvbKI27fYOBe1hxxrvpY6vcGGGal8xhNOKD+eiqXAgPgIRYuXqLpPgaYOImjnnH9lIpzjrCO0nsba1T4tonDjp5jv0FaSKwOTAjO6QIDAQAB"
+
+func writeTestConfig(t *testing.T, cfg *Config) string {
+	t.Helper()
+	bs, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "gopay.json")
+	if err = os.WriteFile(path, bs, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCertSN(t *testing.T) {
+	var buf bytes.Buffer
+	err := run(context.Background(), []string{"certsn",
+		"-cert", "../../alipay/cert/appPublicCert.crt",
+		"-cert", "../../alipay/cert/alipayPublicCert.crt",
+		"-root", "../../alipay/cert/alipayRootCert.crt",
+	}, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs := new(certSNResult)
+	if err = json.Unmarshal(buf.Bytes(), rs); err != nil {
+		t.Fatal(err)
+	}
+	if len(rs.CertSN) != 2 || rs.RootCertSN == "" {
+		t.Fatalf("unexpected result: %s", buf.String())
+	}
+}
+
+func TestAlipaySignAndVerify(t *testing.T) {
+	cfgPath := writeTestConfig(t, &Config{Alipay: &AlipayConfig{
+		AppId:           cert.Appid,
+		PrivateKey:      cert.PrivateKey,
+		AlipayPublicKey: appPublicKey,
+	}})
+	ctx := context.Background()
+	params := `{"app_id":"2021000122672388","method":"alipay.trade.query","charset":"utf-8","biz_content":"{\"out_trade_no\":\"GZ201909081743431443\"}"}`
+
+	var buf bytes.Buffer
+	if err := run(ctx, []string{"sign", "-config", cfgPath, "-provider", "alipay", "-params", params}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	signRs := new(signResult)
+	if err := json.Unmarshal(buf.Bytes(), signRs); err != nil {
+		t.Fatal(err)
+	}
+	if signRs.Sign == "" || signRs.SignType != "RSA2" {
+		t.Fatalf("unexpected result: %s", buf.String())
+	}
+
+	bm, err := readBodyMap(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bm.Set("sign", signRs.Sign).Set("sign_type", signRs.SignType)
+	buf.Reset()
+	if err = run(ctx, []string{"verify", "-config", cfgPath, "-provider", "alipay", "-params", bm.EncodeURLParams()}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	verifyRs := new(verifyResult)
+	if err = json.Unmarshal(buf.Bytes(), verifyRs); err != nil {
+		t.Fatal(err)
+	}
+	if !verifyRs.Verified {
+		t.Fatalf("verify failed: %s", buf.String())
+	}
+}
+
+func TestAlipayQueryDryRun(t *testing.T) {
+	cfgPath := writeTestConfig(t, &Config{Alipay: &AlipayConfig{AppId: cert.Appid, PrivateKey: cert.PrivateKey}})
+	var buf bytes.Buffer
+	if err := run(context.Background(), []string{"query", "-config", cfgPath, "-provider", "alipay", "-out-trade-no", "GZ201909081743431443", "-dry-run"}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	req := new(dryRunRequest)
+	if err := json.Unmarshal(buf.Bytes(), req); err != nil {
+		t.Fatal(err)
+	}
+	body, _ := req.Body.(map[string]any)
+	if req.Url != alipay.SandboxGatewayUrl || body["method"] != "alipay.trade.query" || body["sign"] == nil {
+		t.Fatalf("unexpected request: %s", buf.String())
+	}
+}
+
+func TestAlipayVerifyResult(t *testing.T) {
+	// 应用私钥与 appPublicKey、appPublicCert.crt 为一对，模拟支付宝签名
+	privateKey, err := xpem.DecodePrivateKey([]byte(xrsa.FormatAlipayPrivateKey(cert.PrivateKey)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signData := `{"code":"10000","msg":"Success","out_trade_no":"GZ201909081743431443","trade_status":"TRADE_SUCCESS"}`
+	h := sha256.Sum256([]byte(signData))
+	signBytes, err := rsa.SignPKCS1v15(nil, privateKey, crypto.SHA256, h[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign := base64.StdEncoding.EncodeToString(signBytes)
+
+	for _, tt := range []struct {
+		name     string
+		ac       *AlipayConfig
+		signData string
+		want     *bool
+	}{
+		{"public key", &AlipayConfig{AlipayPublicKey: appPublicKey}, signData, newBool(true)},
+		{"public key tampered", &AlipayConfig{AlipayPublicKey: appPublicKey}, signData + " ", newBool(false)},
+		{"public cert", &AlipayConfig{PublicCertFile: "../../alipay/cert/appPublicCert.crt"}, signData, newBool(true)},
+		{"public cert tampered", &AlipayConfig{PublicCertFile: "../../alipay/cert/appPublicCert.crt"}, signData + " ", newBool(false)},
+		{"wrong public cert", &AlipayConfig{PublicCertFile: "../../alipay/cert/alipayPublicCert.crt"}, signData, newBool(false)},
+		{"not configured", &AlipayConfig{}, signData, nil},
+	} {
+		rs := new(queryResult)
+		if err = alipayVerifyResult(tt.ac, tt.signData, sign, rs); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if (rs.Verified == nil) != (tt.want == nil) || rs.Verified != nil && *rs.Verified != *tt.want {
+			t.Fatalf("%s: Verified = %v, want %v", tt.name, rs.Verified, tt.want)
+		}
+		if rs.Verified != nil && !*rs.Verified && rs.VerifyReason == "" {
+			t.Fatalf("%s: missing VerifyReason", tt.name)
+		}
+	}
+	if err = alipayVerifyResult(&AlipayConfig{PublicCertFile: "not_exist.crt"}, signData, sign, new(queryResult)); err == nil {
+		t.Fatal("alipayVerifyResult() with missing cert file should return error")
+	}
+}
+
+func TestAlipayClientConfig(t *testing.T) {
+	badCert := filepath.Join(t.TempDir(), "bad.crt")
+	if err := os.WriteFile(badCert, []byte("bad cert"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		name    string
+		ac      *AlipayConfig
+		wantErr string
+	}{
+		{"public key", &AlipayConfig{AlipayPublicKey: appPublicKey}, ""},
+		{"bad public key", &AlipayConfig{AlipayPublicKey: "bad key"}, "AutoVerifySignByPublicKey"},
+		{"cert", &AlipayConfig{
+			AppCertFile:    "../../alipay/cert/appPublicCert.crt",
+			RootCertFile:   "../../alipay/cert/alipayRootCert.crt",
+			PublicCertFile: "../../alipay/cert/alipayPublicCert.crt",
+		}, ""},
+		{"partial cert", &AlipayConfig{PublicCertFile: "../../alipay/cert/alipayPublicCert.crt"}, "requires app_cert_file"},
+		{"bad public cert", &AlipayConfig{
+			AppCertFile:    "../../alipay/cert/appPublicCert.crt",
+			RootCertFile:   "../../alipay/cert/alipayRootCert.crt",
+			PublicCertFile: badCert,
+		}, "cert"},
+	} {
+		tt.ac.AppId, tt.ac.PrivateKey = cert.Appid, cert.PrivateKey
+		client, err := (&Config{Alipay: tt.ac}).alipayClient()
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("%s: err = %v, want %s", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || client == nil {
+			t.Fatalf("%s: err = %v", tt.name, err)
+		}
+	}
+}
+
+func newBool(b bool) *bool {
+	return &b
+}
+
+func TestWechatNotify(t *testing.T) {
+	const apiV3Key = "Cj5xC9RXf0GFCKWeD9PyY1ZWLgionbvx"
+	plaintext := `{"mchid":"1900000100","out_trade_no":"GZ201909081743431443","trade_state":"SUCCESS"}`
+	nonce, ciphertext, err := aes.GCMEncrypt([]byte(plaintext), []byte("transaction"), []byte(apiV3Key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	notify, _ := json.Marshal(map[string]any{
+		"id":            "EV-2018022511223320873",
+		"create_time":   "2015-05-20T13:29:35+08:00",
+		"resource_type": "encrypt-resource",
+		"event_type":    "TRANSACTION.SUCCESS",
+		"summary":       "支付成功",
+		"resource": map[string]string{
+			"original_type":   "transaction",
+			"algorithm":       "AEAD_AES_256_GCM",
+			"ciphertext":      base64.StdEncoding.EncodeToString(ciphertext),
+			"associated_data": "transaction",
+			"nonce":           string(nonce),
+		},
+	})
+	cfgPath := writeTestConfig(t, &Config{Wechat: &WechatConfig{ApiV3Key: apiV3Key}})
+	var buf bytes.Buffer
+	if err = run(context.Background(), []string{"notify", "-config", cfgPath, "-body", string(notify)}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	rs := new(notifyResult)
+	if err = json.Unmarshal(buf.Bytes(), rs); err != nil {
+		t.Fatal(err)
+	}
+	if rs.EventType != "TRANSACTION.SUCCESS" || rs.Verified != nil {
+		t.Fatalf("unexpected result: %s", buf.String())
+	}
+	var compact bytes.Buffer
+	_ = json.Compact(&compact, rs.Plaintext)
+	if compact.String() != plaintext {
+		t.Fatalf("plaintext: %s", rs.Plaintext)
+	}
+}
+
+func TestParseWechatBill(t *testing.T) {
+	bill := "\ufeff交易时间,公众账号ID,商户号,商户订单号,应结订单金额\n" +
+		"`2023-01-01 10:00:00,`wx2421b1c4370ec43b,`1900000100,`GZ201909081743431443,`0.01\n" +
+		"`2023-01-01 11:00:00,`wx2421b1c4370ec43b,`1900000100,`GZ201909081743431444,`0.02\n" +
+		"总交易单数,应结订单总金额\n" +
+		"`2,`0.03\n"
+	records, summary := parseWechatBill([]byte(bill))
+	if len(records) != 2 || records[1]["商户订单号"] != "GZ201909081743431444" {
+		t.Fatalf("records: %v", records)
+	}
+	if summary["总交易单数"] != "2" || summary["应结订单总金额"] != "0.03" {
+		t.Fatalf("summary: %v", summary)
+	}
+}
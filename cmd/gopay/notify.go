@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/pkg/util"
+	wechatv3 "github.com/misu99/gopay/wechat/v3"
+)
+
+type notifyResult struct {
+	Id           string          `json:"id"`
+	CreateTime   string          `json:"create_time"`
+	EventType    string          `json:"event_type"`
+	ResourceType string          `json:"resource_type"`
+	OriginalType string          `json:"original_type,omitempty"`
+	Summary      string          `json:"summary"`
+	Verified     *bool           `json:"verified,omitempty"` // 未传入 Wechatpay-* 头信息时不验签
+	VerifyReason string          `json:"verify_reason,omitempty"`
+	Plaintext    json.RawMessage `json:"plaintext"` // 解密后的 resource 明文
+}
+
+// gopay notify -body @notify.json [-timestamp xxx -nonce xxx -signature xxx]
+func runNotify(_ context.Context, args []string, stdout io.Writer) error {
+	fs := newFlagSet("notify")
+	cfgPath := configFlag(fs)
+	body := fs.String("body", "-", "微信v3异步通知 body 原文，@file 读取文件，- 读取标准输入")
+	timestamp := fs.String("timestamp", "", "Wechatpay-Timestamp，同时传入 -nonce、-signature 时对通知验签")
+	nonce := fs.String("nonce", "", "Wechatpay-Nonce")
+	signature := fs.String("signature", "", "Wechatpay-Signature")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := loadConfig(*cfgPath)
+	if err != nil {
+		return err
+	}
+	if cfg.Wechat == nil || cfg.Wechat.ApiV3Key == util.NULL {
+		return fmt.Errorf("missing wechat.api_v3_key config")
+	}
+	bs, err := readInput(*body)
+	if err != nil {
+		return err
+	}
+	notifyReq := new(wechatv3.V3NotifyReq)
+	if err = json.Unmarshal(bs, notifyReq); err != nil {
+		return fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if notifyReq.Resource == nil {
+		return fmt.Errorf("notify resource is nil, bytes: %s", string(bs))
+	}
+	rs := &notifyResult{
+		Id:           notifyReq.Id,
+		CreateTime:   notifyReq.CreateTime,
+		EventType:    notifyReq.EventType,
+		ResourceType: notifyReq.ResourceType,
+		OriginalType: notifyReq.Resource.OriginalType,
+		Summary:      notifyReq.Summary,
+	}
+	if *timestamp != util.NULL && *nonce != util.NULL && *signature != util.NULL {
+		publicKey, err := cfg.wechatPlatformPublicKey()
+		if err != nil {
+			return err
+		}
+		vErr := wechatv3.V3VerifySignByPK(*timestamp, *nonce, string(bs), *signature, publicKey)
+		verified := vErr == nil
+		rs.Verified = &verified
+		if vErr != nil {
+			rs.VerifyReason = vErr.Error()
+		}
+	}
+	res := notifyReq.Resource
+	plaintext, err := wechatv3.V3DecryptNotifyCipherTextToBytes(res.Ciphertext, res.Nonce, res.AssociatedData, cfg.Wechat.ApiV3Key)
+	if err != nil {
+		return err
+	}
+	if json.Valid(plaintext) {
+		rs.Plaintext = plaintext
+	} else {
+		rs.Plaintext, _ = json.Marshal(string(plaintext))
+	}
+	return writeJSON(stdout, rs)
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/alipay"
+	"github.com/misu99/gopay/pkg/util"
+	wechatv3 "github.com/misu99/gopay/wechat/v3"
+)
+
+// dryRunRequest -dry-run 时输出的已签名请求
+type dryRunRequest struct {
+	Method  string            `json:"method"`
+	Url     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    any               `json:"body,omitempty"`
+}
+
+// queryResult 查询结果，Verified 为 nil 表示未验签
+type queryResult struct {
+	Code         int    `json:"code"`
+	Error        string `json:"error,omitempty"`
+	Verified     *bool  `json:"verified,omitempty"`
+	VerifyReason string `json:"verify_reason,omitempty"`
+	Response     any    `json:"response,omitempty"`
+}
+
+// gopay query -provider wechat -out-trade-no xxx [-dry-run]
+// gopay query -provider alipay -out-trade-no xxx [-dry-run]
+func runQuery(ctx context.Context, args []string, stdout io.Writer) error {
+	fs := newFlagSet("query")
+	cfgPath := configFlag(fs)
+	provider := fs.String("provider", providerWechat, "alipay 或 wechat")
+	outTradeNo := fs.String("out-trade-no", "", "商户订单号")
+	tradeNo := fs.String("trade-no", "", "alipay 支付宝交易号")
+	transactionId := fs.String("transaction-id", "", "wechat 微信支付订单号")
+	dryRun := fs.Bool("dry-run", false, "只输出签名后的请求，不实际发送")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := loadConfig(*cfgPath)
+	if err != nil {
+		return err
+	}
+	switch *provider {
+	case providerWechat:
+		var (
+			orderNoType wechatv3.OrderNoType
+			orderNo     string
+			uri         string
+		)
+		switch {
+		case *transactionId != util.NULL:
+			orderNoType, orderNo = wechatv3.TransactionId, *transactionId
+			uri = "/v3/pay/transactions/id/" + url.PathEscape(orderNo)
+		case *outTradeNo != util.NULL:
+			orderNoType, orderNo = wechatv3.OutTradeNo, *outTradeNo
+			uri = "/v3/pay/transactions/out-trade-no/" + url.PathEscape(orderNo)
+		default:
+			return fmt.Errorf("missing -out-trade-no or -transaction-id")
+		}
+		client, err := cfg.wechatClient()
+		if err != nil {
+			return err
+		}
+		if *dryRun {
+			req, err := wechatDryRun(client, wechatv3.MethodGet, uri+"?mchid="+client.Mchid, nil)
+			if err != nil {
+				return err
+			}
+			return writeJSON(stdout, req)
+		}
+		if err = cfg.wechatAutoVerify(client); err != nil {
+			return err
+		}
+		wxRsp, err := client.V3TransactionQueryOrder(ctx, orderNoType, orderNo)
+		if err != nil {
+			return err
+		}
+		rs := &queryResult{Code: wxRsp.Code, Error: wxRsp.Error}
+		if wxRsp.Code == wechatv3.Success {
+			rs.Response = wxRsp.Response
+			wechatVerifyResult(client, wxRsp.SignInfo, rs)
+		}
+		return writeJSON(stdout, rs)
+	case providerAlipay:
+		bm := make(gopay.BodyMap)
+		switch {
+		case *tradeNo != util.NULL:
+			bm.Set("trade_no", *tradeNo)
+		case *outTradeNo != util.NULL:
+			bm.Set("out_trade_no", *outTradeNo)
+		default:
+			return fmt.Errorf("missing -out-trade-no or -trade-no")
+		}
+		client, err := cfg.alipayClient()
+		if err != nil {
+			return err
+		}
+		if *dryRun {
+			req, err := alipayDryRun(client, "alipay.trade.query", bm)
+			if err != nil {
+				return err
+			}
+			return writeJSON(stdout, req)
+		}
+		aliRsp, err := client.TradeQuery(ctx, bm)
+		if err != nil {
+			if bizErr, ok := alipay.IsBizError(err); ok {
+				return writeJSON(stdout, &queryResult{Error: bizErr.Error(), Response: aliRsp.Response})
+			}
+			// 自动验签失败时 aliRsp 为 nil，输出验签结果
+			if errors.Is(err, gopay.VerifySignatureErr) || errors.Is(err, gopay.MissSignatureErr) || errors.Is(err, gopay.CertNotMatchErr) {
+				verified := false
+				return writeJSON(stdout, &queryResult{Verified: &verified, VerifyReason: err.Error()})
+			}
+			return err
+		}
+		rs := &queryResult{Response: aliRsp.Response}
+		if err = alipayVerifyResult(cfg.Alipay, aliRsp.SignData, aliRsp.Sign, rs); err != nil {
+			return err
+		}
+		return writeJSON(stdout, rs)
+	default:
+		return fmt.Errorf("unsupported provider: %s", *provider)
+	}
+}
+
+// wechatDryRun 生成微信v3已签名请求
+func wechatDryRun(client *wechatv3.ClientV3, method, uri string, bm gopay.BodyMap) (*dryRunRequest, error) {
+	authorization, err := client.Authorization(method, uri, bm)
+	if err != nil {
+		return nil, err
+	}
+	req := &dryRunRequest{
+		Method: method,
+		Url:    client.BaseUrl() + uri,
+		Headers: map[string]string{
+			wechatv3.HeaderAuthorization: authorization,
+			"Accept":                     "*/*",
+		},
+	}
+	if client.WxSerialNo != util.NULL {
+		req.Headers[wechatv3.HeaderSerial] = client.WxSerialNo
+	}
+	if bm != nil {
+		req.Headers["Content-Type"] = "application/json"
+		req.Body = json.RawMessage(bm.JsonBody())
+	}
+	return req, nil
+}
+
+// wechatVerifyResult 根据已加载的平台证书对同步返回验签，未加载任何平台证书时不验签
+func wechatVerifyResult(client *wechatv3.ClientV3, si *wechatv3.SignInfo, rs *queryResult) {
+	pkMap := client.WxPublicKeyMap()
+	if si == nil || len(pkMap) == 0 {
+		return
+	}
+	var vErr error
+	if publicKey, ok := pkMap[si.HeaderSerial]; ok {
+		vErr = wechatv3.V3VerifySignByPK(si.HeaderTimestamp, si.HeaderNonce, si.SignBody, si.HeaderSignature, publicKey)
+	} else {
+		vErr = fmt.Errorf("platform cert(%s) not found", si.HeaderSerial)
+	}
+	verified := vErr == nil
+	rs.Verified = &verified
+	if vErr != nil {
+		rs.VerifyReason = vErr.Error()
+	}
+}
+
+// alipayVerifyResult 使用配置的支付宝公钥证书或支付宝公钥对同步返回验签，均未配置时不验签
+func alipayVerifyResult(ac *AlipayConfig, signData, sign string, rs *queryResult) error {
+	var (
+		verified bool
+		vErr     error
+	)
+	switch {
+	case ac.PublicCertFile != util.NULL:
+		publicCert, err := os.ReadFile(ac.PublicCertFile)
+		if err != nil {
+			return err
+		}
+		verified, vErr = alipay.VerifySyncSignWithCert(publicCert, signData, sign)
+	case ac.AlipayPublicKey != util.NULL:
+		verified, vErr = alipay.VerifySyncSign(ac.AlipayPublicKey, signData, sign)
+	default:
+		return nil
+	}
+	rs.Verified = &verified
+	if vErr != nil {
+		rs.VerifyReason = vErr.Error()
+	}
+	return nil
+}
+
+// alipayDryRun 生成支付宝已签名请求，bm 中的业务参数会作为 biz_content
+func alipayDryRun(client *alipay.Client, method string, bm gopay.BodyMap) (*dryRunRequest, error) {
+	params, err := client.RequestParam(make(gopay.BodyMap).Set("biz_content", bm), method)
+	if err != nil {
+		return nil, err
+	}
+	values, err := url.ParseQuery(params)
+	if err != nil {
+		return nil, err
+	}
+	body := make(map[string]string, len(values))
+	for k := range values {
+		body[k] = values.Get(k)
+	}
+	req := &dryRunRequest{
+		Method:  "POST",
+		Url:     alipay.SandboxGatewayUrl,
+		Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded;charset=utf-8"},
+		Body:    body,
+	}
+	if client.IsProd {
+		req.Url = alipay.GatewayUrl
+	}
+	return req, nil
+}
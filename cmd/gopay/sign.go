@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/alipay"
+	"github.com/misu99/gopay/pkg/util"
+	"github.com/misu99/gopay/pkg/xpem"
+	"github.com/misu99/gopay/pkg/xrsa"
+	"github.com/misu99/gopay/wechat"
+	wechatv3 "github.com/misu99/gopay/wechat/v3"
+)
+
+const (
+	providerAlipay   = "alipay"
+	providerWechat   = "wechat"
+	providerWechatV2 = "wechat-v2"
+)
+
+type signResult struct {
+	Provider      string `json:"provider"`
+	SignType      string `json:"sign_type,omitempty"`
+	SignContent   string `json:"sign_content,omitempty"` // 待签名字符串
+	Sign          string `json:"sign,omitempty"`
+	Authorization string `json:"authorization,omitempty"` // 微信v3 Authorization Header
+}
+
+type verifyResult struct {
+	Provider string `json:"provider"`
+	Verified bool   `json:"verified"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// gopay sign -provider alipay -params @params.json
+// gopay sign -provider wechat -method POST -path /v3/pay/transactions/native -body @body.json
+// gopay sign -provider wechat-v2 -params @params.json -sign-type HMAC-SHA256
+func runSign(_ context.Context, args []string, stdout io.Writer) error {
+	fs := newFlagSet("sign")
+	cfgPath := configFlag(fs)
+	provider := fs.String("provider", providerAlipay, "alipay、wechat 或 wechat-v2")
+	params := fs.String("params", "", "alipay、wechat-v2 待签名参数（JSON、XML 或 URL 编码），@file 读取文件，- 读取标准输入")
+	signType := fs.String("sign-type", "", "签名类型，alipay 默认 RSA2，wechat-v2 默认 MD5")
+	method := fs.String("method", wechatv3.MethodGet, "wechat 请求方法")
+	path := fs.String("path", "", "wechat 请求 URI，包含 query 参数")
+	body := fs.String("body", "", "wechat 请求 body（JSON），@file 读取文件，- 读取标准输入")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := loadConfig(*cfgPath)
+	if err != nil {
+		return err
+	}
+	rs := &signResult{Provider: *provider}
+	switch *provider {
+	case providerAlipay:
+		if cfg.Alipay == nil {
+			return fmt.Errorf("missing alipay config")
+		}
+		bm, err := readBodyMap(*params)
+		if err != nil {
+			return err
+		}
+		key, err := keyContent(cfg.Alipay.PrivateKey, cfg.Alipay.PrivateKeyFile)
+		if err != nil {
+			return err
+		}
+		privateKey, err := xpem.DecodePrivateKey([]byte(xrsa.FormatAlipayPrivateKey(key)))
+		if err != nil {
+			return err
+		}
+		if rs.SignType = *signType; rs.SignType == util.NULL {
+			if rs.SignType = bm.GetString("sign_type"); rs.SignType == util.NULL {
+				rs.SignType = alipay.RSA2
+			}
+		}
+		bm.Remove("sign")
+		rs.SignContent = bm.EncodeAliPaySignParams()
+		if rs.Sign, err = alipay.GetRsaSign(bm, rs.SignType, privateKey); err != nil {
+			return err
+		}
+	case providerWechat:
+		if *path == util.NULL {
+			return fmt.Errorf("missing -path")
+		}
+		client, err := cfg.wechatClient()
+		if err != nil {
+			return err
+		}
+		var bm gopay.BodyMap
+		if *body != util.NULL {
+			if bm, err = readBodyMap(*body); err != nil {
+				return err
+			}
+		}
+		if rs.Authorization, err = client.Authorization(strings.ToUpper(*method), *path, bm); err != nil {
+			return err
+		}
+	case providerWechatV2:
+		if cfg.Wechat == nil || cfg.Wechat.ApiKey == util.NULL {
+			return fmt.Errorf("missing wechat.api_key config")
+		}
+		bm, err := readBodyMap(*params)
+		if err != nil {
+			return err
+		}
+		if rs.SignType = *signType; rs.SignType == util.NULL {
+			if rs.SignType = bm.GetString("sign_type"); rs.SignType == util.NULL {
+				rs.SignType = wechat.SignType_MD5
+			}
+		}
+		bm.Remove("sign")
+		rs.SignContent = bm.EncodeWeChatSignParams(cfg.Wechat.ApiKey)
+		rs.Sign = wechat.GetReleaseSign(cfg.Wechat.ApiKey, rs.SignType, bm)
+	default:
+		return fmt.Errorf("unsupported provider: %s", *provider)
+	}
+	return writeJSON(stdout, rs)
+}
+
+// gopay verify -provider alipay -params @notify.txt
+// gopay verify -provider wechat -timestamp xxx -nonce xxx -signature xxx -body @notify.json
+// gopay verify -provider wechat-v2 -params @notify.xml
+func runVerify(_ context.Context, args []string, stdout io.Writer) error {
+	fs := newFlagSet("verify")
+	cfgPath := configFlag(fs)
+	provider := fs.String("provider", providerAlipay, "alipay、wechat 或 wechat-v2")
+	params := fs.String("params", "", "alipay、wechat-v2 待验签参数（JSON、XML 或 URL 编码），@file 读取文件，- 读取标准输入")
+	signType := fs.String("sign-type", wechat.SignType_MD5, "wechat-v2 签名类型")
+	timestamp := fs.String("timestamp", "", "wechat Wechatpay-Timestamp")
+	nonce := fs.String("nonce", "", "wechat Wechatpay-Nonce")
+	signature := fs.String("signature", "", "wechat Wechatpay-Signature")
+	body := fs.String("body", "", "wechat 应答或通知 body 原文，@file 读取文件，- 读取标准输入")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := loadConfig(*cfgPath)
+	if err != nil {
+		return err
+	}
+	var (
+		rs   = &verifyResult{Provider: *provider}
+		vErr error
+	)
+	switch *provider {
+	case providerAlipay:
+		ac := cfg.Alipay
+		if ac == nil {
+			return fmt.Errorf("missing alipay config")
+		}
+		bm, err := readBodyMap(*params)
+		if err != nil {
+			return err
+		}
+		switch {
+		case ac.PublicCertFile != util.NULL:
+			rs.Verified, vErr = alipay.VerifySignWithCert(ac.PublicCertFile, bm)
+		case ac.AlipayPublicKey != util.NULL:
+			rs.Verified, vErr = alipay.VerifySign(ac.AlipayPublicKey, bm)
+		default:
+			return fmt.Errorf("missing alipay.alipay_public_key or alipay.public_cert_file config")
+		}
+	case providerWechat:
+		if *timestamp == util.NULL || *nonce == util.NULL || *signature == util.NULL {
+			return fmt.Errorf("missing -timestamp, -nonce or -signature")
+		}
+		publicKey, err := cfg.wechatPlatformPublicKey()
+		if err != nil {
+			return err
+		}
+		bs, err := readInput(*body)
+		if err != nil {
+			return err
+		}
+		vErr = wechatv3.V3VerifySignByPK(*timestamp, *nonce, string(bs), *signature, publicKey)
+		rs.Verified = vErr == nil
+	case providerWechatV2:
+		if cfg.Wechat == nil || cfg.Wechat.ApiKey == util.NULL {
+			return fmt.Errorf("missing wechat.api_key config")
+		}
+		bm, err := readBodyMap(*params)
+		if err != nil {
+			return err
+		}
+		rs.Verified, vErr = wechat.VerifySign(cfg.Wechat.ApiKey, *signType, bm)
+	default:
+		return fmt.Errorf("unsupported provider: %s", *provider)
+	}
+	if vErr != nil {
+		rs.Reason = vErr.Error()
+	}
+	return writeJSON(stdout, rs)
+}
+
+// readBodyMap 读取参数并按格式解析：JSON 对象、XML（微信v2）或 URL 编码（支付宝异步通知原文）
+func readBodyMap(v string) (bm gopay.BodyMap, err error) {
+	if v == util.NULL {
+		return nil, fmt.Errorf("missing params")
+	}
+	bs, err := readInput(v)
+	if err != nil {
+		return nil, err
+	}
+	bs = bytes.TrimSpace(bs)
+	bm = make(gopay.BodyMap)
+	switch {
+	case bytes.HasPrefix(bs, []byte("{")):
+		if err = json.Unmarshal(bs, &bm); err != nil {
+			return nil, fmt.Errorf("[%w]: %v", gopay.UnmarshalErr, err)
+		}
+	case bytes.HasPrefix(bs, []byte("<")):
+		if err = xml.Unmarshal(bs, &bm); err != nil {
+			return nil, fmt.Errorf("[%w]: %v", gopay.UnmarshalErr, err)
+		}
+	default:
+		values, err := url.ParseQuery(string(bs))
+		if err != nil {
+			return nil, fmt.Errorf("[%w]: %v", gopay.UnmarshalErr, err)
+		}
+		for k, vs := range values {
+			if len(vs) > 0 {
+				bm.Set(k, vs[0])
+			}
+		}
+	}
+	return bm, nil
+}
@@ -13,6 +13,7 @@ var (
 	UnmarshalErr           = errors.New("unmarshal error")
 	SignatureErr           = errors.New("signature error")
 	VerifySignatureErr     = errors.New("verify signature error")
+	MissSignatureErr       = errors.New("missing signature error")
 	CertNotMatchErr        = errors.New("cert not match error")
 	GetSignDataErr         = errors.New("get signature data error")
 )
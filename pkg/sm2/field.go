@@ -0,0 +1,202 @@
+package sm2
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+)
+
+// element 256 位模数下的 Montgomery 形式元素，小端 4×64 位
+// 所有运算均不依赖元素取值的分支与访存，用于私钥、随机数 k 等秘密值的常数时间运算
+type element [4]uint64
+
+// montField Montgomery 模运算（CIOS），用于曲线素数 p 与阶 n
+type montField struct {
+	m     element // 模数
+	r2    element // R^2 mod m，R = 2^256
+	one   element // R mod m（Montgomery 形式的 1）
+	n0    uint64  // -m^-1 mod 2^64
+	mBig  *big.Int
+	mSub2 []byte // m - 2，费马小定理求逆的指数（公开值）
+}
+
+func newMontField(m *big.Int) *montField {
+	f := &montField{mBig: m}
+	f.m = elementFromBig(m)
+	r := new(big.Int).Lsh(big.NewInt(1), 256)
+	f.one = elementFromBig(new(big.Int).Mod(r, m))
+	f.r2 = elementFromBig(new(big.Int).Mod(new(big.Int).Mul(r, r), m))
+	// 牛顿迭代求 m^-1 mod 2^64
+	inv := uint64(1)
+	for i := 0; i < 6; i++ {
+		inv *= 2 - f.m[0]*inv
+	}
+	f.n0 = -inv
+	f.mSub2 = new(big.Int).Sub(m, big.NewInt(2)).Bytes()
+	return f
+}
+
+func elementFromBig(v *big.Int) (e element) {
+	var buf [32]byte
+	v.FillBytes(buf[:])
+	return elementFromBytes(&buf)
+}
+
+// elementFromBytes 32 字节大端转为元素（非 Montgomery 形式）
+func elementFromBytes(b *[32]byte) (e element) {
+	for i := 0; i < 4; i++ {
+		e[i] = binary.BigEndian.Uint64(b[32-8*(i+1):])
+	}
+	return e
+}
+
+// bytes 元素转为 32 字节大端（非 Montgomery 形式）
+func (e *element) bytes() (b [32]byte) {
+	for i := 0; i < 4; i++ {
+		binary.BigEndian.PutUint64(b[32-8*(i+1):], e[i])
+	}
+	return b
+}
+
+// reduce 将 [0, 2^256) 内的值约减到 [0, m)，要求 m > 2^255
+func (f *montField) reduce(a *element) (z element) {
+	var d element
+	var borrow uint64
+	d[0], borrow = bits.Sub64(a[0], f.m[0], 0)
+	d[1], borrow = bits.Sub64(a[1], f.m[1], borrow)
+	d[2], borrow = bits.Sub64(a[2], f.m[2], borrow)
+	d[3], borrow = bits.Sub64(a[3], f.m[3], borrow)
+	return selectElement(borrow, a, &d)
+}
+
+// fromBytes 32 字节大端转为 Montgomery 形式
+func (f *montField) fromBytes(b *[32]byte) element {
+	e := elementFromBytes(b)
+	e = f.reduce(&e)
+	return f.mul(&e, &f.r2)
+}
+
+// toBytes Montgomery 形式转为 32 字节大端
+func (f *montField) toBytes(a *element) [32]byte {
+	e := f.mul(a, &element{1})
+	return e.bytes()
+}
+
+func (f *montField) fromBig(v *big.Int) element {
+	if v.Sign() < 0 || v.Cmp(f.mBig) >= 0 {
+		v = new(big.Int).Mod(v, f.mBig)
+	}
+	e := elementFromBig(v)
+	return f.mul(&e, &f.r2)
+}
+
+func (f *montField) toBig(a *element) *big.Int {
+	b := f.toBytes(a)
+	return new(big.Int).SetBytes(b[:])
+}
+
+// madd 返回 a*b + c + d 的高低 64 位
+func madd(a, b, c, d uint64) (hi, lo uint64) {
+	var carry uint64
+	hi, lo = bits.Mul64(a, b)
+	lo, carry = bits.Add64(lo, c, 0)
+	hi += carry
+	lo, carry = bits.Add64(lo, d, 0)
+	hi += carry
+	return hi, lo
+}
+
+// mul Montgomery 乘法 a*b*R^-1 mod m
+func (f *montField) mul(a, b *element) element {
+	var t [6]uint64
+	var c, carry uint64
+	for i := 0; i < 4; i++ {
+		c = 0
+		for j := 0; j < 4; j++ {
+			c, t[j] = madd(a[j], b[i], t[j], c)
+		}
+		t[4], carry = bits.Add64(t[4], c, 0)
+		t[5] = carry
+
+		mi := t[0] * f.n0
+		c, _ = madd(mi, f.m[0], t[0], 0)
+		for j := 1; j < 4; j++ {
+			c, t[j-1] = madd(mi, f.m[j], t[j], c)
+		}
+		t[3], carry = bits.Add64(t[4], c, 0)
+		t[4] = t[5] + carry
+	}
+	var d element
+	var borrow uint64
+	d[0], borrow = bits.Sub64(t[0], f.m[0], 0)
+	d[1], borrow = bits.Sub64(t[1], f.m[1], borrow)
+	d[2], borrow = bits.Sub64(t[2], f.m[2], borrow)
+	d[3], borrow = bits.Sub64(t[3], f.m[3], borrow)
+	_, borrow = bits.Sub64(t[4], 0, borrow)
+	r := element{t[0], t[1], t[2], t[3]}
+	return selectElement(borrow, &r, &d)
+}
+
+func (f *montField) square(a *element) element {
+	return f.mul(a, a)
+}
+
+func (f *montField) add(a, b *element) element {
+	var s, d element
+	var carry, borrow uint64
+	s[0], carry = bits.Add64(a[0], b[0], 0)
+	s[1], carry = bits.Add64(a[1], b[1], carry)
+	s[2], carry = bits.Add64(a[2], b[2], carry)
+	s[3], carry = bits.Add64(a[3], b[3], carry)
+	d[0], borrow = bits.Sub64(s[0], f.m[0], 0)
+	d[1], borrow = bits.Sub64(s[1], f.m[1], borrow)
+	d[2], borrow = bits.Sub64(s[2], f.m[2], borrow)
+	d[3], borrow = bits.Sub64(s[3], f.m[3], borrow)
+	_, borrow = bits.Sub64(carry, 0, borrow)
+	return selectElement(borrow, &s, &d)
+}
+
+func (f *montField) sub(a, b *element) element {
+	var d, s element
+	var borrow, carry uint64
+	d[0], borrow = bits.Sub64(a[0], b[0], 0)
+	d[1], borrow = bits.Sub64(a[1], b[1], borrow)
+	d[2], borrow = bits.Sub64(a[2], b[2], borrow)
+	d[3], borrow = bits.Sub64(a[3], b[3], borrow)
+	mask := -borrow
+	s[0], carry = bits.Add64(d[0], f.m[0]&mask, 0)
+	s[1], carry = bits.Add64(d[1], f.m[1]&mask, carry)
+	s[2], carry = bits.Add64(d[2], f.m[2]&mask, carry)
+	s[3], _ = bits.Add64(d[3], f.m[3]&mask, carry)
+	return s
+}
+
+// inv 费马小定理求逆 a^(m-2)，指数为公开值，运算时间与 a 无关；a 为 0 时返回 0
+func (f *montField) inv(a *element) element {
+	z := f.one
+	for _, b := range f.mSub2 {
+		for i := 7; i >= 0; i-- {
+			z = f.square(&z)
+			if (b>>uint(i))&1 == 1 {
+				z = f.mul(&z, a)
+			}
+		}
+	}
+	return z
+}
+
+// isZero 返回 1 表示 a == 0
+func (e *element) isZero() int {
+	v := e[0] | e[1] | e[2] | e[3]
+	return subtle.ConstantTimeEq(int32(v>>32), 0) & subtle.ConstantTimeEq(int32(v), 0)
+}
+
+// selectElement borrow 为 1 时返回 a，为 0 时返回 b
+func selectElement(borrow uint64, a, b *element) (z element) {
+	mask := -borrow
+	for i := 0; i < 4; i++ {
+		z[i] = (a[i] & mask) | (b[i] &^ mask)
+	}
+	return z
+}
@@ -0,0 +1,226 @@
+package sm2
+
+import (
+	"crypto/elliptic"
+	"crypto/subtle"
+	"math/big"
+)
+
+// sm2Curve SM2 推荐曲线，标量乘使用固定窗口、常数时间查表与完备加法公式
+// （Renes-Costello-Batina 2016，a = -3），运算时间与标量取值无关
+type sm2Curve struct {
+	*elliptic.CurveParams
+	fp, fn *montField
+	b      element // Montgomery 形式的曲线参数 b
+	g      point
+}
+
+// point 射影坐标点 (X:Y:Z)，无穷远点为 (0:1:0)
+type point struct {
+	x, y, z element
+}
+
+func newSM2Curve(params *elliptic.CurveParams) *sm2Curve {
+	c := &sm2Curve{CurveParams: params, fp: newMontField(params.P), fn: newMontField(params.N)}
+	c.b = c.fp.fromBig(params.B)
+	c.g = point{x: c.fp.fromBig(params.Gx), y: c.fp.fromBig(params.Gy), z: c.fp.one}
+	return c
+}
+
+func (c *sm2Curve) Params() *elliptic.CurveParams {
+	return c.CurveParams
+}
+
+// IsOnCurve 判断 (x, y) 是否在曲线上，仅用于公开数据
+func (c *sm2Curve) IsOnCurve(x, y *big.Int) bool {
+	p := c.P
+	if x.Sign() < 0 || x.Cmp(p) >= 0 || y.Sign() < 0 || y.Cmp(p) >= 0 {
+		return false
+	}
+	// y² = x³ - 3x + b
+	y2 := new(big.Int).Mul(y, y)
+	y2.Mod(y2, p)
+	x3 := new(big.Int).Mul(x, x)
+	x3.Mul(x3, x)
+	threeX := new(big.Int).Lsh(x, 1)
+	threeX.Add(threeX, x)
+	x3.Sub(x3, threeX)
+	x3.Add(x3, c.B)
+	x3.Mod(x3, p)
+	return x3.Cmp(y2) == 0
+}
+
+func (c *sm2Curve) Add(x1, y1, x2, y2 *big.Int) (x, y *big.Int) {
+	p1, p2 := c.fromAffine(x1, y1), c.fromAffine(x2, y2)
+	r := c.add(&p1, &p2)
+	return c.toAffine(&r)
+}
+
+func (c *sm2Curve) Double(x1, y1 *big.Int) (x, y *big.Int) {
+	p1 := c.fromAffine(x1, y1)
+	r := c.double(&p1)
+	return c.toAffine(&r)
+}
+
+func (c *sm2Curve) ScalarMult(x1, y1 *big.Int, k []byte) (x, y *big.Int) {
+	p1 := c.fromAffine(x1, y1)
+	r := c.scalarMult(&p1, c.scalarBytes(k))
+	return c.toAffine(&r)
+}
+
+func (c *sm2Curve) ScalarBaseMult(k []byte) (x, y *big.Int) {
+	r := c.scalarMult(&c.g, c.scalarBytes(k))
+	return c.toAffine(&r)
+}
+
+// scalarBytes 标量转为 32 字节大端，超过 32 字节时先模 n
+func (c *sm2Curve) scalarBytes(k []byte) (b [32]byte) {
+	if len(k) > 32 {
+		k = new(big.Int).Mod(new(big.Int).SetBytes(k), c.N).Bytes()
+	}
+	copy(b[32-len(k):], k)
+	return b
+}
+
+// fromAffine 仿射坐标转射影坐标，(0, 0) 视为无穷远点
+func (c *sm2Curve) fromAffine(x, y *big.Int) point {
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return point{y: c.fp.one}
+	}
+	return point{x: c.fp.fromBig(x), y: c.fp.fromBig(y), z: c.fp.one}
+}
+
+// toAffine 射影坐标转仿射坐标，无穷远点返回 (0, 0)
+func (c *sm2Curve) toAffine(p *point) (x, y *big.Int) {
+	zInv := c.fp.inv(&p.z)
+	ax := c.fp.mul(&p.x, &zInv)
+	ay := c.fp.mul(&p.y, &zInv)
+	return c.fp.toBig(&ax), c.fp.toBig(&ay)
+}
+
+// scalarMult 4 位固定窗口标量乘，每个窗口固定执行 4 次倍点、1 次常数时间查表与 1 次点加
+func (c *sm2Curve) scalarMult(q *point, k [32]byte) point {
+	var table [16]point
+	table[0] = point{y: c.fp.one}
+	table[1] = *q
+	for i := 2; i < 16; i++ {
+		if i&1 == 0 {
+			table[i] = c.double(&table[i/2])
+		} else {
+			table[i] = c.add(&table[i-1], q)
+		}
+	}
+	r := point{y: c.fp.one}
+	for _, b := range k {
+		for _, w := range [2]byte{b >> 4, b & 0x0f} {
+			for i := 0; i < 4; i++ {
+				r = c.double(&r)
+			}
+			t := c.lookup(&table, w)
+			r = c.add(&r, &t)
+		}
+	}
+	return r
+}
+
+// lookup 常数时间查表，遍历全部表项按掩码选择
+func (c *sm2Curve) lookup(table *[16]point, w byte) (p point) {
+	for i := range table {
+		mask := -uint64(subtle.ConstantTimeByteEq(byte(i), w))
+		for j := 0; j < 4; j++ {
+			p.x[j] |= table[i].x[j] & mask
+			p.y[j] |= table[i].y[j] & mask
+			p.z[j] |= table[i].z[j] & mask
+		}
+	}
+	return p
+}
+
+// add 完备点加公式（RCB16 Algorithm 4），对倍点、无穷远点同样成立
+func (c *sm2Curve) add(p1, p2 *point) point {
+	f := c.fp
+	t0 := f.mul(&p1.x, &p2.x)
+	t1 := f.mul(&p1.y, &p2.y)
+	t2 := f.mul(&p1.z, &p2.z)
+	t3 := f.add(&p1.x, &p1.y)
+	t4 := f.add(&p2.x, &p2.y)
+	t3 = f.mul(&t3, &t4)
+	t4 = f.add(&t0, &t1)
+	t3 = f.sub(&t3, &t4)
+	t4 = f.add(&p1.y, &p1.z)
+	x3 := f.add(&p2.y, &p2.z)
+	t4 = f.mul(&t4, &x3)
+	x3 = f.add(&t1, &t2)
+	t4 = f.sub(&t4, &x3)
+	x3 = f.add(&p1.x, &p1.z)
+	y3 := f.add(&p2.x, &p2.z)
+	x3 = f.mul(&x3, &y3)
+	y3 = f.add(&t0, &t2)
+	y3 = f.sub(&x3, &y3)
+	z3 := f.mul(&c.b, &t2)
+	x3 = f.sub(&y3, &z3)
+	z3 = f.add(&x3, &x3)
+	x3 = f.add(&x3, &z3)
+	z3 = f.sub(&t1, &x3)
+	x3 = f.add(&t1, &x3)
+	y3 = f.mul(&c.b, &y3)
+	t1 = f.add(&t2, &t2)
+	t2 = f.add(&t1, &t2)
+	y3 = f.sub(&y3, &t2)
+	y3 = f.sub(&y3, &t0)
+	t1 = f.add(&y3, &y3)
+	y3 = f.add(&t1, &y3)
+	t1 = f.add(&t0, &t0)
+	t0 = f.add(&t1, &t0)
+	t0 = f.sub(&t0, &t2)
+	t1 = f.mul(&t4, &y3)
+	t2 = f.mul(&t0, &y3)
+	y3 = f.mul(&x3, &z3)
+	y3 = f.add(&y3, &t2)
+	x3 = f.mul(&t3, &x3)
+	x3 = f.sub(&x3, &t1)
+	z3 = f.mul(&t4, &z3)
+	t1 = f.mul(&t3, &t0)
+	z3 = f.add(&z3, &t1)
+	return point{x: x3, y: y3, z: z3}
+}
+
+// double 完备倍点公式（RCB16 Algorithm 6）
+func (c *sm2Curve) double(p *point) point {
+	f := c.fp
+	t0 := f.square(&p.x)
+	t1 := f.square(&p.y)
+	t2 := f.square(&p.z)
+	t3 := f.mul(&p.x, &p.y)
+	t3 = f.add(&t3, &t3)
+	z3 := f.mul(&p.x, &p.z)
+	z3 = f.add(&z3, &z3)
+	y3 := f.mul(&c.b, &t2)
+	y3 = f.sub(&y3, &z3)
+	x3 := f.add(&y3, &y3)
+	y3 = f.add(&x3, &y3)
+	x3 = f.sub(&t1, &y3)
+	y3 = f.add(&t1, &y3)
+	y3 = f.mul(&x3, &y3)
+	x3 = f.mul(&x3, &t3)
+	t3 = f.add(&t2, &t2)
+	t2 = f.add(&t2, &t3)
+	z3 = f.mul(&c.b, &z3)
+	z3 = f.sub(&z3, &t2)
+	z3 = f.sub(&z3, &t0)
+	t3 = f.add(&z3, &z3)
+	z3 = f.add(&z3, &t3)
+	t3 = f.add(&t0, &t0)
+	t0 = f.add(&t3, &t0)
+	t0 = f.sub(&t0, &t2)
+	t0 = f.mul(&t0, &z3)
+	y3 = f.add(&y3, &t0)
+	t0 = f.mul(&p.y, &p.z)
+	t0 = f.add(&t0, &t0)
+	z3 = f.mul(&t0, &z3)
+	x3 = f.sub(&x3, &z3)
+	z3 = f.mul(&t0, &t1)
+	z3 = f.add(&z3, &z3)
+	z3 = f.add(&z3, &z3)
+	return point{x: x3, y: y3, z: z3}
+}
@@ -0,0 +1,246 @@
+// Package sm2 国密 SM2 椭圆曲线公钥密码算法（GB/T 32918-2016）
+// 提供密钥生成、SM2withSM3 签名验签、公钥加密（C1C3C2）及密钥/证书解析
+package sm2
+
+import (
+	"crypto/elliptic"
+	"crypto/subtle"
+	"encoding/asn1"
+	"errors"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/misu99/gopay/pkg/sm3"
+)
+
+// DefaultUID 未指定用户身份标识时使用的默认 ID（GB/T 35276-2017）
+var DefaultUID = []byte("1234567812345678")
+
+var (
+	initOnce sync.Once
+	sm2P256  *sm2Curve
+	one      = big.NewInt(1)
+)
+
+func initP256() {
+	params := &elliptic.CurveParams{Name: "SM2-P-256", BitSize: 256}
+	params.P, _ = new(big.Int).SetString("FFFFFFFEFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF00000000FFFFFFFFFFFFFFFF", 16)
+	params.N, _ = new(big.Int).SetString("FFFFFFFEFFFFFFFFFFFFFFFFFFFFFFFF7203DF6B21C6052B53BBF40939D54123", 16)
+	params.B, _ = new(big.Int).SetString("28E9FA9E9D9F5E344D5A9E4BCF6509A7F39789F515AB8F92DDBCBD414D940E93", 16)
+	params.Gx, _ = new(big.Int).SetString("32C4AE2C1F1981195F9904466A39C9948FE30BBFF2660BE1715A4589334C74C7", 16)
+	params.Gy, _ = new(big.Int).SetString("BC3736A2F4F6779C59BDCEE36B692153D0A9877CC62A474002DF32E52139F0A0", 16)
+	sm2P256 = newSM2Curve(params)
+}
+
+// P256 返回 SM2 推荐曲线（a = p - 3），标量乘为常数时间实现
+func P256() elliptic.Curve {
+	return p256()
+}
+
+func p256() *sm2Curve {
+	initOnce.Do(initP256)
+	return sm2P256
+}
+
+// PublicKey SM2 公钥
+type PublicKey struct {
+	elliptic.Curve
+	X, Y *big.Int
+}
+
+// PrivateKey SM2 私钥
+type PrivateKey struct {
+	PublicKey
+	D *big.Int
+}
+
+// Public 返回私钥对应的公钥
+func (priv *PrivateKey) Public() *PublicKey {
+	return &priv.PublicKey
+}
+
+type sm2Signature struct {
+	R, S *big.Int
+}
+
+// GenerateKey 生成 SM2 密钥对
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	c := p256()
+	k, err := randScalar(c, rand)
+	if err != nil {
+		return nil, err
+	}
+	kb := c.fn.toBytes(&k)
+	priv := new(PrivateKey)
+	priv.PublicKey.Curve = c
+	priv.D = new(big.Int).SetBytes(kb[:])
+	priv.PublicKey.X, priv.PublicKey.Y = c.ScalarBaseMult(kb[:])
+	return priv, nil
+}
+
+// NewPrivateKey 根据私钥 D 生成私钥
+func NewPrivateKey(d []byte) (*PrivateKey, error) {
+	c := p256()
+	k := new(big.Int).SetBytes(d)
+	n := new(big.Int).Sub(c.Params().N, one)
+	if k.Sign() <= 0 || k.Cmp(n) >= 0 {
+		return nil, errors.New("sm2: invalid private key")
+	}
+	priv := new(PrivateKey)
+	priv.PublicKey.Curve = c
+	priv.D = k
+	priv.PublicKey.X, priv.PublicKey.Y = c.ScalarBaseMult(toBytes(c.CurveParams, k))
+	return priv, nil
+}
+
+// SignASN1 使用 SM2withSM3 对 msg 签名，返回 ASN.1 DER 编码的签名值
+// uid：用户身份标识，传 nil 使用 DefaultUID
+func SignASN1(rand io.Reader, priv *PrivateKey, msg, uid []byte) ([]byte, error) {
+	r, s, err := Sign(rand, priv, msg, uid)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(sm2Signature{r, s})
+}
+
+// VerifyASN1 验证 ASN.1 DER 编码的 SM2withSM3 签名
+// uid：用户身份标识，传 nil 使用 DefaultUID
+func VerifyASN1(pub *PublicKey, msg, uid, sig []byte) bool {
+	var s sm2Signature
+	rest, err := asn1.Unmarshal(sig, &s)
+	if err != nil || len(rest) != 0 {
+		return false
+	}
+	return Verify(pub, msg, uid, s.R, s.S)
+}
+
+// Sign 使用 SM2withSM3 对 msg 签名
+// 私钥与随机数 k 参与的标量乘、模 n 运算均为常数时间实现
+func Sign(rand io.Reader, priv *PrivateKey, msg, uid []byte) (r, s *big.Int, err error) {
+	za, err := ZA(&priv.PublicKey, uid)
+	if err != nil {
+		return nil, nil, err
+	}
+	e := hashToInt(za, msg)
+	c := p256()
+	fn := c.fn
+	d := fn.fromBig(priv.D)
+	// (1 + d)^-1
+	dInv := fn.add(&d, &fn.one)
+	dInv = fn.inv(&dInv)
+	for {
+		k, err := randScalar(c, rand)
+		if err != nil {
+			return nil, nil, err
+		}
+		kb := fn.toBytes(&k)
+		x1, _ := c.ScalarBaseMult(kb[:])
+		r = new(big.Int).Add(e, x1)
+		r.Mod(r, c.N)
+		rm := fn.fromBig(r)
+		// r + k == n
+		rk := fn.add(&rm, &k)
+		if r.Sign() == 0 || rk.isZero() == 1 {
+			continue
+		}
+		// s = (1 + d)^-1 * (k - r * d) mod n
+		sm := fn.mul(&rm, &d)
+		sm = fn.sub(&k, &sm)
+		sm = fn.mul(&sm, &dInv)
+		if sm.isZero() == 1 {
+			continue
+		}
+		return r, fn.toBig(&sm), nil
+	}
+}
+
+// Verify 验证 SM2withSM3 签名
+func Verify(pub *PublicKey, msg, uid []byte, r, s *big.Int) bool {
+	if pub == nil || pub.Curve == nil || pub.X == nil || pub.Y == nil || r == nil || s == nil {
+		return false
+	}
+	c := pub.Curve
+	// 公钥须为曲线上的点，(0, 0) 表示的无穷远点不满足曲线方程，同样拒绝
+	if !c.IsOnCurve(pub.X, pub.Y) {
+		return false
+	}
+	n := c.Params().N
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(n) >= 0 || s.Cmp(n) >= 0 {
+		return false
+	}
+	za, err := ZA(pub, uid)
+	if err != nil {
+		return false
+	}
+	e := hashToInt(za, msg)
+	t := new(big.Int).Add(r, s)
+	t.Mod(t, n)
+	if t.Sign() == 0 {
+		return false
+	}
+	x1, y1 := c.ScalarBaseMult(s.Bytes())
+	x2, y2 := c.ScalarMult(pub.X, pub.Y, t.Bytes())
+	x, _ := c.Add(x1, y1, x2, y2)
+	x.Add(x, e)
+	x.Mod(x, n)
+	return x.Cmp(r) == 0
+}
+
+// ZA 计算用户身份杂凑值 Z = SM3(ENTL || ID || a || b || xG || yG || xA || yA)
+func ZA(pub *PublicKey, uid []byte) ([]byte, error) {
+	if len(uid) == 0 {
+		uid = DefaultUID
+	}
+	if len(uid) >= 8192 {
+		return nil, errors.New("sm2: uid too large")
+	}
+	params := pub.Curve.Params()
+	a := new(big.Int).Sub(params.P, big.NewInt(3))
+	entl := len(uid) * 8
+	h := sm3.New()
+	h.Write([]byte{byte(entl >> 8), byte(entl)})
+	h.Write(uid)
+	h.Write(toBytes(params, a))
+	h.Write(toBytes(params, params.B))
+	h.Write(toBytes(params, params.Gx))
+	h.Write(toBytes(params, params.Gy))
+	h.Write(toBytes(params, pub.X))
+	h.Write(toBytes(params, pub.Y))
+	return h.Sum(nil), nil
+}
+
+func hashToInt(za, msg []byte) *big.Int {
+	h := sm3.New()
+	h.Write(za)
+	h.Write(msg)
+	return new(big.Int).SetBytes(h.Sum(nil))
+}
+
+// toBytes 大整数转为曲线字节长度的定长大端字节
+func toBytes(params *elliptic.CurveParams, v *big.Int) []byte {
+	byteLen := (params.BitSize + 7) / 8
+	buf := make([]byte, byteLen)
+	return v.FillBytes(buf)
+}
+
+// randScalar 拒绝采样生成 [1, n-1] 范围内的随机数（Montgomery 形式）
+func randScalar(c *sm2Curve, rand io.Reader) (k element, err error) {
+	var b [32]byte
+	for {
+		if _, err = io.ReadFull(rand, b[:]); err != nil {
+			return k, err
+		}
+		raw := elementFromBytes(&b)
+		reduced := c.fn.reduce(&raw)
+		// reduce 后发生变化说明 raw >= n，或为 0，均丢弃重新采样
+		if subtle.ConstantTimeCompare(elementBytes(&raw), elementBytes(&reduced)) == 1 && raw.isZero() == 0 {
+			return c.fn.mul(&raw, &c.fn.r2), nil
+		}
+	}
+}
+
+func elementBytes(e *element) []byte {
+	b := e.bytes()
+	return b[:]
+}
@@ -0,0 +1,147 @@
+package sm2
+
+import (
+	"crypto/subtle"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/misu99/gopay/pkg/sm3"
+)
+
+type sm2Cipher struct {
+	X, Y *big.Int
+	Hash []byte
+	Text []byte
+}
+
+// Encrypt SM2 公钥加密，返回 C1C3C2 格式密文（C1 为 04||x||y 非压缩点）
+func Encrypt(rand io.Reader, pub *PublicKey, msg []byte) ([]byte, error) {
+	x1, y1, c3, c2, err := encrypt(rand, pub, msg)
+	if err != nil {
+		return nil, err
+	}
+	params := pub.Curve.Params()
+	out := make([]byte, 0, 1+64+len(c3)+len(c2))
+	out = append(out, 0x04)
+	out = append(out, toBytes(params, x1)...)
+	out = append(out, toBytes(params, y1)...)
+	out = append(out, c3...)
+	return append(out, c2...), nil
+}
+
+// EncryptASN1 SM2 公钥加密，返回 ASN.1 DER 编码的 C1C3C2 密文（GB/T 35276-2017）
+func EncryptASN1(rand io.Reader, pub *PublicKey, msg []byte) ([]byte, error) {
+	x1, y1, c3, c2, err := encrypt(rand, pub, msg)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(sm2Cipher{X: x1, Y: y1, Hash: c3, Text: c2})
+}
+
+// Decrypt SM2 私钥解密 C1C3C2 格式密文
+func Decrypt(priv *PrivateKey, ciphertext []byte) ([]byte, error) {
+	params := priv.Curve.Params()
+	byteLen := (params.BitSize + 7) / 8
+	if len(ciphertext) < 1+2*byteLen+sm3.Size || ciphertext[0] != 0x04 {
+		return nil, errors.New("sm2: invalid ciphertext")
+	}
+	x1 := new(big.Int).SetBytes(ciphertext[1 : 1+byteLen])
+	y1 := new(big.Int).SetBytes(ciphertext[1+byteLen : 1+2*byteLen])
+	c3 := ciphertext[1+2*byteLen : 1+2*byteLen+sm3.Size]
+	c2 := ciphertext[1+2*byteLen+sm3.Size:]
+	return decrypt(priv, x1, y1, c3, c2)
+}
+
+// DecryptASN1 SM2 私钥解密 ASN.1 DER 编码的 C1C3C2 密文
+func DecryptASN1(priv *PrivateKey, ciphertext []byte) ([]byte, error) {
+	var c sm2Cipher
+	rest, err := asn1.Unmarshal(ciphertext, &c)
+	if err != nil || len(rest) != 0 {
+		return nil, errors.New("sm2: invalid asn1 ciphertext")
+	}
+	return decrypt(priv, c.X, c.Y, c.Hash, c.Text)
+}
+
+func encrypt(rand io.Reader, pub *PublicKey, msg []byte) (x1, y1 *big.Int, c3, c2 []byte, err error) {
+	if pub == nil || pub.Curve == nil || pub.X == nil || pub.Y == nil {
+		return nil, nil, nil, nil, errors.New("sm2: invalid public key")
+	}
+	c := p256()
+	params := c.Params()
+	for {
+		k, err := randScalar(c, rand)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		kb := c.fn.toBytes(&k)
+		x1, y1 = c.ScalarBaseMult(kb[:])
+		x2, y2 := c.ScalarMult(pub.X, pub.Y, kb[:])
+		x2b, y2b := toBytes(params, x2), toBytes(params, y2)
+		t, ok := kdf(len(msg), x2b, y2b)
+		if !ok {
+			continue
+		}
+		c2 = make([]byte, len(msg))
+		xorBytes(c2, msg, t)
+		h := sm3.New()
+		h.Write(x2b)
+		h.Write(msg)
+		h.Write(y2b)
+		return x1, y1, h.Sum(nil), c2, nil
+	}
+}
+
+func decrypt(priv *PrivateKey, x1, y1 *big.Int, c3, c2 []byte) ([]byte, error) {
+	c := p256()
+	if x1 == nil || y1 == nil || !c.IsOnCurve(x1, y1) {
+		return nil, errors.New("sm2: invalid ciphertext point")
+	}
+	params := c.Params()
+	x2, y2 := c.ScalarMult(x1, y1, toBytes(params, priv.D))
+	x2b, y2b := toBytes(params, x2), toBytes(params, y2)
+	t, ok := kdf(len(c2), x2b, y2b)
+	if !ok {
+		return nil, errors.New("sm2: decrypt failed")
+	}
+	msg := make([]byte, len(c2))
+	xorBytes(msg, c2, t)
+	h := sm3.New()
+	h.Write(x2b)
+	h.Write(msg)
+	h.Write(y2b)
+	if subtle.ConstantTimeCompare(h.Sum(nil), c3) != 1 {
+		return nil, errors.New("sm2: decrypt failed, hash mismatch")
+	}
+	return msg, nil
+}
+
+// kdf 密钥派生函数，返回结果全为 0 时 ok 为 false
+func kdf(length int, z ...[]byte) (k []byte, ok bool) {
+	var ct [4]byte
+	k = make([]byte, 0, length+sm3.Size)
+	for i := uint32(1); len(k) < length; i++ {
+		binary.BigEndian.PutUint32(ct[:], i)
+		h := sm3.New()
+		for _, v := range z {
+			h.Write(v)
+		}
+		h.Write(ct[:])
+		k = h.Sum(k)
+	}
+	k = k[:length]
+	for _, v := range k {
+		if v != 0 {
+			return k, true
+		}
+	}
+	return k, length == 0
+}
+
+func xorBytes(dst, a, b []byte) {
+	for i := range dst {
+		dst[i] = a[i] ^ b[i]
+	}
+}
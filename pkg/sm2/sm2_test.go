@@ -0,0 +1,263 @@
+package sm2
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// GM/T 0003.5-2012 推荐曲线示例密钥
+func TestNewPrivateKey(t *testing.T) {
+	d, _ := hex.DecodeString("3945208F7B2144B13F36E38AC6D39F95889393692860B51A42FB81EF4DF7C5B8")
+	priv, err := NewPrivateKey(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantX, _ := new(big.Int).SetString("09F9DF311E5421A150DD7D161E4BC5C672179FAD1833FC076BB08FF356F35020", 16)
+	wantY, _ := new(big.Int).SetString("CCEA490CE26775A52DC6EA718CC1AA600AED05FBF35E084A6632F6072DA9AD13", 16)
+	if priv.X.Cmp(wantX) != 0 || priv.Y.Cmp(wantY) != 0 {
+		t.Fatalf("public key = (%X, %X)", priv.X, priv.Y)
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("GET\n/v3/certificates\n1554208460\n593BEC0C930BF1AFEB40B4A08C8FB242\n\n")
+	sig, err := SignASN1(rand.Reader, priv, msg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyASN1(priv.Public(), msg, nil, sig) {
+		t.Fatal("VerifyASN1 failed")
+	}
+	if VerifyASN1(priv.Public(), msg, []byte("other uid"), sig) {
+		t.Fatal("VerifyASN1 with wrong uid should fail")
+	}
+	if VerifyASN1(priv.Public(), append(msg, '1'), nil, sig) {
+		t.Fatal("VerifyASN1 with wrong msg should fail")
+	}
+	// 不在曲线上的公钥、无穷远点
+	for _, pub := range []*PublicKey{
+		{Curve: priv.Curve, X: priv.X, Y: new(big.Int).Add(priv.Y, big.NewInt(1))},
+		{Curve: priv.Curve, X: new(big.Int), Y: new(big.Int)},
+		{Curve: priv.Curve, X: priv.X, Y: new(big.Int).Add(priv.Y, priv.Curve.Params().P)},
+	} {
+		if VerifyASN1(pub, msg, nil, sig) {
+			t.Fatalf("VerifyASN1 with invalid public key (%X, %X) should fail", pub.X, pub.Y)
+		}
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("6214832172305216")
+	ciphertext, err := Encrypt(rand.Reader, priv.Public(), msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := Decrypt(priv, ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(plain, msg) {
+		t.Fatalf("Decrypt = %s, want %s", plain, msg)
+	}
+	ciphertext, err = EncryptASN1(rand.Reader, priv.Public(), msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain, err = DecryptASN1(priv, ciphertext); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(plain, msg) {
+		t.Fatalf("DecryptASN1 = %s, want %s", plain, msg)
+	}
+	ciphertext[len(ciphertext)-1] ^= 0xff
+	if _, err = DecryptASN1(priv, ciphertext); err == nil {
+		t.Fatal("DecryptASN1 with tampered ciphertext should fail")
+	}
+}
+
+func TestMarshalParse(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv2, err := ParsePKCS8PrivateKey(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if priv2.D.Cmp(priv.D) != 0 || priv2.X.Cmp(priv.X) != 0 {
+		t.Fatal("ParsePKCS8PrivateKey mismatch")
+	}
+	der, err = MarshalPKIXPublicKey(priv.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := ParsePKIXPublicKey(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pub.X.Cmp(priv.X) != 0 || pub.Y.Cmp(priv.Y) != 0 {
+		t.Fatal("ParsePKIXPublicKey mismatch")
+	}
+}
+
+func TestParseCertificate(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubDer, err := MarshalPKIXPublicKey(priv.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pki publicKeyInfo
+	if _, err = asn1.Unmarshal(pubDer, &pki); err != nil {
+		t.Fatal(err)
+	}
+	name, _ := asn1.Marshal(pkix.Name{CommonName: "Tenpay.com Root CA"}.ToRDNSequence())
+	serial, _ := new(big.Int).SetString("5157F3F43C1BA2B7D3F2ADD57D4F0D8D1C0B3CB2", 16)
+	notBefore := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	sigAlgo := pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 501}}
+	der, err := asn1.Marshal(certificate{
+		TBSCertificate: tbsCertificate{
+			Version:            2,
+			SerialNumber:       serial,
+			SignatureAlgorithm: sigAlgo,
+			Issuer:             asn1.RawValue{FullBytes: name},
+			Validity:           validity{NotBefore: notBefore, NotAfter: notBefore.AddDate(5, 0, 0)},
+			Subject:            asn1.RawValue{FullBytes: name},
+			PublicKey:          publicKeyInfo{Algorithm: pki.Algorithm, PublicKey: pki.PublicKey},
+		},
+		SignatureAlgorithm: sigAlgo,
+		SignatureValue:     asn1.BitString{Bytes: []byte{0}, BitLength: 8},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cert.SerialNumber.Cmp(serial) != 0 || !cert.NotBefore.Equal(notBefore) {
+		t.Fatalf("ParseCertificate mismatch: %+v", cert)
+	}
+	if cert.PublicKey.X.Cmp(priv.X) != 0 || cert.PublicKey.Y.Cmp(priv.Y) != 0 {
+		t.Fatal("ParseCertificate public key mismatch")
+	}
+}
+
+// GM/T 0003.5-2012 附录 A 推荐曲线签名示例：ID 为 1234567812345678，消息为 message digest
+func TestSignKnownAnswer(t *testing.T) {
+	d, _ := hex.DecodeString("3945208F7B2144B13F36E38AC6D39F95889393692860B51A42FB81EF4DF7C5B8")
+	priv, err := NewPrivateKey(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("message digest")
+	za, err := ZA(&priv.PublicKey, []byte("1234567812345678"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(za); got != "b2e14c5c79c6df5b85f4fe7ed8db7a262b9da7e07ccb0ea9f4747b8ccda8a4f3" {
+		t.Fatalf("ZA = %s", got)
+	}
+	if got := hashToInt(za, msg).Text(16); got != "f0b43e94ba45accaace692ed534382eb17e6ab5a19ce7b31f4486fdfc0d28640" {
+		t.Fatalf("e = %s", got)
+	}
+	k, _ := hex.DecodeString("59276E27D506861A16680F3AD9C02DCCEF3CC1FA3CDBE4CE6D54B80DEAC1BC21")
+	r, s, err := Sign(bytes.NewReader(k), priv, msg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.Text(16); got != "f5a03b0648d2c4630eeac513e1bb81a15944da3827d5b74143ac7eaceee720b3" {
+		t.Fatalf("r = %s", got)
+	}
+	if got := s.Text(16); got != "b1b6aa29df212fd8763182bc0d421ca1bb9038fd1f7f42d4840b69c485bbc1aa" {
+		t.Fatalf("s = %s", got)
+	}
+	if !Verify(priv.Public(), msg, nil, r, s) {
+		t.Fatal("Verify known answer signature failed")
+	}
+}
+
+// GM/T 0003.5-2012 附录 C 推荐曲线加密示例：消息为 encryption standard，C1C3C2 格式
+func TestEncryptKnownAnswer(t *testing.T) {
+	d, _ := hex.DecodeString("3945208F7B2144B13F36E38AC6D39F95889393692860B51A42FB81EF4DF7C5B8")
+	priv, err := NewPrivateKey(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, _ := hex.DecodeString("59276E27D506861A16680F3AD9C02DCCEF3CC1FA3CDBE4CE6D54B80DEAC1BC21")
+	want := "04" +
+		"04ebfc718e8d1798620432268e77feb6415e2ede0e073c0f4f640ecd2e149a73" +
+		"e858f9d81e5430a57b36daab8f950a3c64e6ee6a63094d99283aff767e124df0" +
+		"59983c18f809e262923c53aec295d30383b54e39d609d160afcb1908d0bd8766" +
+		"21886ca989ca9c7d58087307ca93092d651efa"
+	ciphertext, err := Encrypt(bytes.NewReader(k), priv.Public(), []byte("encryption standard"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(ciphertext); got != want {
+		t.Fatalf("Encrypt = %s", got)
+	}
+	wantBytes, _ := hex.DecodeString(want)
+	plain, err := Decrypt(priv, wantBytes)
+	if err != nil || string(plain) != "encryption standard" {
+		t.Fatalf("Decrypt = %s, %v", plain, err)
+	}
+}
+
+// 常数时间标量乘与通用实现结果一致，覆盖 0、1、n-1、n 等边界标量
+func TestScalarMult(t *testing.T) {
+	c := p256()
+	generic := c.CurveParams
+	n := c.N
+	scalars := [][]byte{
+		{0}, {1}, {2}, {15}, {16},
+		new(big.Int).Sub(n, one).Bytes(),
+		n.Bytes(),
+		new(big.Int).Add(n, one).Bytes(),
+	}
+	for i := 0; i < 16; i++ {
+		k := make([]byte, 32)
+		_, _ = rand.Read(k)
+		scalars = append(scalars, k)
+	}
+	for _, k := range scalars {
+		x1, y1 := c.ScalarBaseMult(k)
+		x2, y2 := generic.ScalarBaseMult(k)
+		if x1.Cmp(x2) != 0 || y1.Cmp(y2) != 0 {
+			t.Fatalf("ScalarBaseMult(%x) mismatch", k)
+		}
+		px, py := generic.ScalarBaseMult([]byte{7})
+		x1, y1 = c.ScalarMult(px, py, k)
+		x2, y2 = generic.ScalarMult(px, py, k)
+		if x1.Cmp(x2) != 0 || y1.Cmp(y2) != 0 {
+			t.Fatalf("ScalarMult(%x) mismatch", k)
+		}
+	}
+	gx, gy := c.Gx, c.Gy
+	if x, y := c.Double(gx, gy); !c.IsOnCurve(x, y) {
+		t.Fatal("Double result not on curve")
+	}
+	if x, y := c.Add(gx, gy, gx, new(big.Int).Sub(c.P, gy)); x.Sign() != 0 || y.Sign() != 0 {
+		t.Fatal("G + (-G) should be the point at infinity")
+	}
+}
@@ -0,0 +1,220 @@
+package sm2
+
+import (
+	"crypto/elliptic"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidNamedCurveSM2  = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 301}
+)
+
+type pkcs8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+type publicKeyInfo struct {
+	Raw       asn1.RawContent
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+type certificate struct {
+	TBSCertificate     tbsCertificate
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	SignatureValue     asn1.BitString
+}
+
+type tbsCertificate struct {
+	Raw                asn1.RawContent
+	Version            int `asn1:"optional,explicit,default:0,tag:0"`
+	SerialNumber       *big.Int
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Issuer             asn1.RawValue
+	Validity           validity
+	Subject            asn1.RawValue
+	PublicKey          publicKeyInfo
+	UniqueId           asn1.BitString   `asn1:"optional,tag:1"`
+	SubjectUniqueId    asn1.BitString   `asn1:"optional,tag:2"`
+	Extensions         []pkix.Extension `asn1:"optional,explicit,tag:3"`
+}
+
+type validity struct {
+	NotBefore, NotAfter time.Time
+}
+
+// Certificate SM2 证书中与验签相关的信息
+type Certificate struct {
+	SerialNumber *big.Int
+	NotBefore    time.Time
+	NotAfter     time.Time
+	PublicKey    *PublicKey
+}
+
+// ParsePKCS8PrivateKey 解析 PKCS#8 DER 格式的 SM2 私钥
+func ParsePKCS8PrivateKey(der []byte) (*PrivateKey, error) {
+	var privKey pkcs8
+	if _, err := asn1.Unmarshal(der, &privKey); err != nil {
+		return nil, fmt.Errorf("sm2: parse pkcs8 private key: %w", err)
+	}
+	if !privKey.Algo.Algorithm.Equal(oidPublicKeyECDSA) {
+		return nil, fmt.Errorf("sm2: unknown private key algorithm: %v", privKey.Algo.Algorithm)
+	}
+	if err := checkNamedCurve(privKey.Algo.Parameters.FullBytes); err != nil {
+		return nil, err
+	}
+	return ParseECPrivateKey(privKey.PrivateKey)
+}
+
+// ParseECPrivateKey 解析 SEC1（EC PRIVATE KEY）DER 格式的 SM2 私钥
+func ParseECPrivateKey(der []byte) (*PrivateKey, error) {
+	var privKey ecPrivateKey
+	if _, err := asn1.Unmarshal(der, &privKey); err != nil {
+		return nil, fmt.Errorf("sm2: parse ec private key: %w", err)
+	}
+	if privKey.Version != 1 {
+		return nil, fmt.Errorf("sm2: unknown ec private key version %d", privKey.Version)
+	}
+	if len(privKey.NamedCurveOID) > 0 && !privKey.NamedCurveOID.Equal(oidNamedCurveSM2) {
+		return nil, fmt.Errorf("sm2: unsupported curve: %v", privKey.NamedCurveOID)
+	}
+	return NewPrivateKey(privKey.PrivateKey)
+}
+
+// MarshalPKCS8PrivateKey 将 SM2 私钥编码为 PKCS#8 DER 格式
+func MarshalPKCS8PrivateKey(priv *PrivateKey) ([]byte, error) {
+	params := priv.Curve.Params()
+	point := marshalPoint(priv.Curve, priv.X, priv.Y)
+	oidBytes, err := asn1.Marshal(oidNamedCurveSM2)
+	if err != nil {
+		return nil, err
+	}
+	ecKey, err := asn1.Marshal(ecPrivateKey{
+		Version:    1,
+		PrivateKey: toBytes(params, priv.D),
+		PublicKey:  asn1.BitString{Bytes: point, BitLength: 8 * len(point)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(pkcs8{
+		Algo: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPublicKeyECDSA,
+			Parameters: asn1.RawValue{FullBytes: oidBytes},
+		},
+		PrivateKey: ecKey,
+	})
+}
+
+// ParsePKIXPublicKey 解析 PKIX（PUBLIC KEY）DER 格式的 SM2 公钥
+func ParsePKIXPublicKey(der []byte) (*PublicKey, error) {
+	var pki publicKeyInfo
+	rest, err := asn1.Unmarshal(der, &pki)
+	if err != nil {
+		return nil, fmt.Errorf("sm2: parse public key: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("sm2: trailing data after public key")
+	}
+	return parsePublicKeyInfo(&pki)
+}
+
+// MarshalPKIXPublicKey 将 SM2 公钥编码为 PKIX DER 格式
+func MarshalPKIXPublicKey(pub *PublicKey) ([]byte, error) {
+	oidBytes, err := asn1.Marshal(oidNamedCurveSM2)
+	if err != nil {
+		return nil, err
+	}
+	point := marshalPoint(pub.Curve, pub.X, pub.Y)
+	return asn1.Marshal(publicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPublicKeyECDSA,
+			Parameters: asn1.RawValue{FullBytes: oidBytes},
+		},
+		PublicKey: asn1.BitString{Bytes: point, BitLength: 8 * len(point)},
+	})
+}
+
+// ParseCertificate 解析 DER 格式的 SM2 证书，提取证书序列号、有效期与公钥
+// 注意：不校验证书签名及证书链
+func ParseCertificate(der []byte) (*Certificate, error) {
+	var cert certificate
+	rest, err := asn1.Unmarshal(der, &cert)
+	if err != nil {
+		return nil, fmt.Errorf("sm2: parse certificate: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("sm2: trailing data after certificate")
+	}
+	pub, err := parsePublicKeyInfo(&cert.TBSCertificate.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Certificate{
+		SerialNumber: cert.TBSCertificate.SerialNumber,
+		NotBefore:    cert.TBSCertificate.Validity.NotBefore,
+		NotAfter:     cert.TBSCertificate.Validity.NotAfter,
+		PublicKey:    pub,
+	}, nil
+}
+
+func parsePublicKeyInfo(pki *publicKeyInfo) (*PublicKey, error) {
+	if !pki.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) {
+		return nil, fmt.Errorf("sm2: unknown public key algorithm: %v", pki.Algorithm.Algorithm)
+	}
+	if err := checkNamedCurve(pki.Algorithm.Parameters.FullBytes); err != nil {
+		return nil, err
+	}
+	c := P256()
+	x, y := unmarshalPoint(c, pki.PublicKey.RightAlign())
+	if x == nil {
+		return nil, errors.New("sm2: invalid public key point")
+	}
+	return &PublicKey{Curve: c, X: x, Y: y}, nil
+}
+
+func checkNamedCurve(params []byte) error {
+	var oid asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(params, &oid); err != nil {
+		return fmt.Errorf("sm2: parse named curve: %w", err)
+	}
+	if !oid.Equal(oidNamedCurveSM2) {
+		return fmt.Errorf("sm2: unsupported curve: %v", oid)
+	}
+	return nil
+}
+
+func marshalPoint(c elliptic.Curve, x, y *big.Int) []byte {
+	params := c.Params()
+	out := append([]byte{0x04}, toBytes(params, x)...)
+	return append(out, toBytes(params, y)...)
+}
+
+// unmarshalPoint 解析非压缩格式的曲线点 04||x||y
+func unmarshalPoint(c elliptic.Curve, data []byte) (x, y *big.Int) {
+	byteLen := (c.Params().BitSize + 7) / 8
+	if len(data) != 1+2*byteLen || data[0] != 0x04 {
+		return nil, nil
+	}
+	x = new(big.Int).SetBytes(data[1 : 1+byteLen])
+	y = new(big.Int).SetBytes(data[1+byteLen:])
+	if !c.IsOnCurve(x, y) {
+		return nil, nil
+	}
+	return x, y
+}
@@ -0,0 +1,30 @@
+package sm3
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// GB/T 32905-2016 附录A 示例
+func TestSum(t *testing.T) {
+	cases := []struct {
+		in, out string
+	}{
+		{"abc", "66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0"},
+		{strings.Repeat("abcd", 16), "debe9ff92275b8a138604889c18e5a4d6fdb70e5387e5765293dcba39c0c5732"},
+	}
+	for _, c := range cases {
+		sum := Sum([]byte(c.in))
+		if got := hex.EncodeToString(sum[:]); got != c.out {
+			t.Fatalf("Sum(%s) = %s, want %s", c.in, got, c.out)
+		}
+		h := New()
+		for i := 0; i < len(c.in); i++ {
+			h.Write([]byte{c.in[i]})
+		}
+		if got := hex.EncodeToString(h.Sum(nil)); got != c.out {
+			t.Fatalf("New().Write(%s) = %s, want %s", c.in, got, c.out)
+		}
+	}
+}
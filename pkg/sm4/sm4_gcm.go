@@ -0,0 +1,40 @@
+package sm4
+
+import (
+	"crypto/cipher"
+	"fmt"
+
+	"github.com/misu99/gopay/pkg/util"
+)
+
+// SM4-GCM 加密数据
+func GCMEncrypt(originText, additional, key []byte) (nonce []byte, cipherText []byte, err error) {
+	block, err := NewCipher(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce = []byte(util.RandomString(12))
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cipher.NewGCM(),error:%w", err)
+	}
+	cipherText = gcm.Seal(nil, nonce, originText, additional)
+	return nonce, cipherText, nil
+}
+
+// SM4-GCM 解密数据
+func GCMDecrypt(cipherText, nonce, additional, key []byte) ([]byte, error) {
+	block, err := NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("cipher.NewGCM(),error:%w", err)
+	}
+	originByte, err := gcm.Open(nil, nonce, cipherText, additional)
+	if err != nil {
+		return nil, err
+	}
+	return originByte, nil
+}
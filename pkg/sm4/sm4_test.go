@@ -0,0 +1,65 @@
+package sm4
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// GB/T 32907-2016 附录A 示例1
+func TestSM4Block(t *testing.T) {
+	key, _ := hex.DecodeString("0123456789abcdeffedcba9876543210")
+	want, _ := hex.DecodeString("681edf34d206965e86b3e94f536e4246")
+	block, err := NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := make([]byte, BlockSize)
+	block.Encrypt(dst, key)
+	if !bytes.Equal(dst, want) {
+		t.Fatalf("Encrypt = %x, want %x", dst, want)
+	}
+	block.Decrypt(dst, dst)
+	if !bytes.Equal(dst, key) {
+		t.Fatalf("Decrypt = %x, want %x", dst, key)
+	}
+}
+
+func TestGCMEncryptDecrypt(t *testing.T) {
+	key := []byte("JYRn4wbCy8KgVIZJ")
+	originText := []byte("www.gopay.ink")
+	additional := []byte("transaction")
+	nonce, cipherText, err := GCMEncrypt(originText, additional, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := GCMDecrypt(cipherText, nonce, additional, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(plain, originText) {
+		t.Fatalf("GCMDecrypt = %s, want %s", plain, originText)
+	}
+	if _, err = GCMDecrypt(cipherText, nonce, []byte("refund"), key); err == nil {
+		t.Fatal("GCMDecrypt with wrong additional data should fail")
+	}
+}
+
+// RFC 8998 附录 A.1 SM4-GCM 测试向量
+func TestGCMKnownAnswer(t *testing.T) {
+	key, _ := hex.DecodeString("0123456789abcdeffedcba9876543210")
+	nonce, _ := hex.DecodeString("00001234567800000000abcd")
+	additional, _ := hex.DecodeString("feedfacedeadbeeffeedfacedeadbeefabaddad2")
+	plain, _ := hex.DecodeString("aaaaaaaaaaaaaaaabbbbbbbbbbbbbbbbccccccccccccccccdddddddddddddddd" +
+		"eeeeeeeeeeeeeeeeffffffffffffffffeeeeeeeeeeeeeeeeaaaaaaaaaaaaaaaa")
+	cipherText, _ := hex.DecodeString("17f399f08c67d5ee19d0dc9969c4bb7d5fd46fd3756489069157b282bb200735" +
+		"d82710ca5c22f0ccfa7cbf93d496ac15a56834cbcf98c397b4024a2691233b8d" +
+		"83de3541e4c2b58177e065a9bf7b62ec")
+	got, err := GCMDecrypt(cipherText, nonce, additional, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, plain) {
+		t.Fatalf("GCMDecrypt = %x", got)
+	}
+}
@@ -0,0 +1,48 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// FileReader 流式上传的文件，上传时按块读取 Reader，不整体载入内存
+type FileReader struct {
+	Name   string    // 文件名
+	Reader io.Reader // 文件内容，实现 io.Seeker 时可避免计算摘要时的临时文件
+	Size   int64     // 文件大小，未知时为 -1
+	closer io.Closer
+}
+
+// NewFileReader 通过 io.Reader 初始化上传文件
+// size：文件大小，未知时传 -1
+func NewFileReader(name string, r io.Reader, size int64) *FileReader {
+	return &FileReader{Name: name, Reader: r, Size: size}
+}
+
+// OpenFile 通过文件路径初始化上传文件，使用完毕后需调用 Close()
+func OpenFile(path string) (f *FileReader, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	if info.IsDir() {
+		_ = file.Close()
+		return nil, fmt.Errorf("%s is a directory", path)
+	}
+	return &FileReader{Name: filepath.Base(path), Reader: file, Size: info.Size(), closer: file}, nil
+}
+
+// Close 关闭通过 OpenFile() 打开的文件
+func (f *FileReader) Close() error {
+	if f.closer != nil {
+		return f.closer.Close()
+	}
+	return nil
+}
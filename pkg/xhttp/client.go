@@ -226,11 +226,13 @@ func (c *Client) EndBytes(ctx context.Context) (res *http.Response, bs []byte, e
 		return nil, nil, c.err
 	}
 	var (
-		body io.Reader
-		bw   *multipart.Writer
+		body          io.Reader
+		bw            *multipart.Writer
+		contentLength int64 = -1
+		stream              = c.requestType == TypeMultipartFormData && hasFileReader(c.multipartBodyMap)
 	)
 	// multipart-form-data
-	if c.requestType == TypeMultipartFormData {
+	if c.requestType == TypeMultipartFormData && !stream {
 		body = &bytes.Buffer{}
 		bw = multipart.NewWriter(body.(io.Writer))
 	}
@@ -262,6 +264,13 @@ func (c *Client) EndBytes(ctx context.Context) (res *http.Response, bs []byte, e
 				body = strings.NewReader(c.FormString)
 				c.ContentType = types[TypeForm]
 			case TypeMultipartFormData:
+				// 包含流式上传的文件时，按块读取文件内容
+				if stream {
+					if body, c.ContentType, contentLength, err = multipartStream(c.multipartBodyMap); err != nil {
+						return err
+					}
+					break
+				}
 				for k, v := range c.multipartBodyMap {
 					// file 参数
 					if file, ok := v.(*util.File); ok {
@@ -297,6 +306,9 @@ func (c *Client) EndBytes(ctx context.Context) (res *http.Response, bs []byte, e
 		if err != nil {
 			return err
 		}
+		if stream && contentLength >= 0 {
+			req.ContentLength = contentLength
+		}
 		req.Header = c.Header
 		req.Header.Set("Content-Type", c.ContentType)
 		if c.Transport != nil {
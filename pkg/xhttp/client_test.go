@@ -1,8 +1,12 @@
 package xhttp
 
 import (
+	"bytes"
 	"context"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -67,3 +71,49 @@ func TestHttpUploadFile(t *testing.T) {
 	}
 	xlog.Debugf("%+v", rsp)
 }
+
+func TestHttpUploadFileReader(t *testing.T) {
+	content := bytes.Repeat([]byte("gopay"), 100000)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f, fh, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer f.Close()
+		bs, _ := ioutil.ReadAll(f)
+		if fh.Filename != "logo.png" || !bytes.Equal(bs, content) || r.FormValue("meta") != `{"filename":"logo.png"}` {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.ContentLength < 0 && !strings.Contains(r.URL.RawQuery, "chunked") {
+			w.WriteHeader(http.StatusLengthRequired)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	for _, size := range []int64{int64(len(content)), -1} {
+		bm := make(gopay.BodyMap)
+		bm.SetBodyMap("meta", func(bm gopay.BodyMap) {
+			bm.Set("filename", "logo.png")
+		}).SetFormFileReader("file", util.NewFileReader("logo.png", bytes.NewReader(content), size))
+
+		url := srv.URL
+		if size < 0 {
+			url += "?chunked"
+		}
+		res, _, err := NewClient().Type(TypeMultipartFormData).Post(url).SendMultipartBodyMap(bm).EndBytes(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("size(%d) StatusCode = %d", size, res.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package xhttp
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"sort"
+
+	"github.com/misu99/gopay/pkg/util"
+)
+
+// multipart 参数中是否包含流式上传的文件
+func hasFileReader(bm map[string]any) bool {
+	for _, v := range bm {
+		if _, ok := v.(*util.FileReader); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// 写入当前分段 buffer 的 writer，遇到文件时切换到新的分段
+type segmentWriter struct {
+	buf *bytes.Buffer
+}
+
+func (w *segmentWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+// 构造流式 multipart body：文本参数在前，文件按 key 排序在后，文件内容按块读取，不整体载入内存
+// length：文件大小均已知时为 body 总长度，否则为 -1（chunked 传输）
+func multipartStream(bm map[string]any) (body io.Reader, contentType string, length int64, err error) {
+	var (
+		keys     []string
+		segments []io.Reader
+		sw       = &segmentWriter{buf: new(bytes.Buffer)}
+		bw       = multipart.NewWriter(sw)
+	)
+	for k := range bm {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		switch v := bm[k].(type) {
+		case *util.File, *util.FileReader:
+		case string:
+			_ = bw.WriteField(k, v)
+		default:
+			if ss := util.ConvertToString(v); ss != "" {
+				_ = bw.WriteField(k, ss)
+			}
+		}
+	}
+	for _, k := range keys {
+		switch v := bm[k].(type) {
+		case *util.File:
+			fw, err := bw.CreateFormFile(k, v.Name)
+			if err != nil {
+				return nil, "", 0, err
+			}
+			_, _ = fw.Write(v.Content)
+		case *util.FileReader:
+			if _, err = bw.CreateFormFile(k, v.Name); err != nil {
+				return nil, "", 0, err
+			}
+			segments = append(segments, sw.buf)
+			if length >= 0 {
+				length += int64(sw.buf.Len())
+			}
+			if v.Size >= 0 && length >= 0 {
+				segments = append(segments, io.LimitReader(v.Reader, v.Size))
+				length += v.Size
+			} else {
+				segments = append(segments, v.Reader)
+				length = -1
+			}
+			sw.buf = new(bytes.Buffer)
+		}
+	}
+	if err = bw.Close(); err != nil {
+		return nil, "", 0, err
+	}
+	segments = append(segments, sw.buf)
+	if length >= 0 {
+		length += int64(sw.buf.Len())
+	}
+	return io.MultiReader(segments...), bw.FormDataContentType(), length, nil
+}
@@ -0,0 +1,48 @@
+package xpem
+
+import (
+	"encoding/pem"
+	"fmt"
+
+	"github.com/misu99/gopay/pkg/sm2"
+)
+
+// DecodeSM2PublicKey 解析国密 SM2 公钥，支持 SM2 证书（CERTIFICATE）和 公钥（PUBLIC KEY）
+func DecodeSM2PublicKey(pemContent []byte) (publicKey *sm2.PublicKey, err error) {
+	block, _ := pem.Decode(pemContent)
+	if block == nil {
+		return nil, fmt.Errorf("pem.Decode(%s)：pemContent decode error", pemContent)
+	}
+	switch block.Type {
+	case "CERTIFICATE":
+		cert, err := sm2.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("sm2.ParseCertificate(%s)：%w", pemContent, err)
+		}
+		publicKey = cert.PublicKey
+	case "PUBLIC KEY":
+		pubKey, err := sm2.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("sm2.ParsePKIXPublicKey(%s),err:%w", pemContent, err)
+		}
+		publicKey = pubKey
+	default:
+		return nil, fmt.Errorf("不支持的SM2公钥类型 [%s]", block.Type)
+	}
+	return publicKey, nil
+}
+
+// DecodeSM2PrivateKey 解析国密 SM2 私钥，支持 PKCS8（PRIVATE KEY）和 SEC1（EC PRIVATE KEY）
+func DecodeSM2PrivateKey(pemContent []byte) (privateKey *sm2.PrivateKey, err error) {
+	block, _ := pem.Decode(pemContent)
+	if block == nil {
+		return nil, fmt.Errorf("pem.Decode(%s)：pemContent decode error", pemContent)
+	}
+	privateKey, err = sm2.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		if privateKey, err = sm2.ParseECPrivateKey(block.Bytes); err != nil {
+			return nil, fmt.Errorf("SM2私钥解析出错 [%s]", pemContent)
+		}
+	}
+	return privateKey, nil
+}
@@ -0,0 +1,223 @@
+package xtls
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/misu99/gopay/pkg/xlog"
+	"golang.org/x/crypto/pkcs12"
+)
+
+// 默认证书文件变更检查间隔
+const DefaultCheckInterval = time.Minute
+
+// Cert 商户API证书（双向TLS）
+// 证书只加载一次，缓存 tls.Config 及可复用连接的 http.Transport
+// 证书通过文件路径添加时，按检查间隔对比文件修改时间，文件变更后自动重新加载
+type Cert struct {
+	certFile, keyFile, pkcs12File string // 证书文件路径
+	certPem, keyPem, pfxData      []byte // 证书内容
+	password                      string // pkcs12 证书密码
+
+	mu            sync.RWMutex
+	certificate   *tls.Certificate
+	config        *tls.Config
+	transport     *http.Transport
+	modTimes      map[string]time.Time
+	lastCheck     time.Time
+	checkInterval time.Duration
+}
+
+// NewPemCert 加载 pem 格式证书
+// certFile：apiclient_cert.pem 文件路径（string）或内容（[]byte）
+// keyFile：apiclient_key.pem 文件路径（string）或内容（[]byte）
+func NewPemCert(certFile, keyFile any) (c *Cert, err error) {
+	c = &Cert{checkInterval: DefaultCheckInterval}
+	if c.certFile, c.certPem, err = pathOrContent("certFile", certFile); err != nil {
+		return nil, err
+	}
+	if c.keyFile, c.keyPem, err = pathOrContent("keyFile", keyFile); err != nil {
+		return nil, err
+	}
+	if err = c.Reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// NewPkcs12Cert 加载 pkcs12 格式证书
+// pkcs12File：apiclient_cert.p12 文件路径（string）或内容（[]byte）
+// password：证书密码，微信、QQ 默认为商户号
+func NewPkcs12Cert(pkcs12File any, password string) (c *Cert, err error) {
+	c = &Cert{password: password, checkInterval: DefaultCheckInterval}
+	if c.pkcs12File, c.pfxData, err = pathOrContent("pkcs12File", pkcs12File); err != nil {
+		return nil, err
+	}
+	if err = c.Reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// NewCertFromCertificate 使用已解析的证书
+func NewCertFromCertificate(certificate tls.Certificate) (c *Cert) {
+	c = &Cert{checkInterval: DefaultCheckInterval}
+	c.set(&certificate)
+	return c
+}
+
+// SetCheckInterval 设置证书文件变更检查间隔，默认 1 分钟，小于等于 0 时每次使用前检查
+func (c *Cert) SetCheckInterval(interval time.Duration) *Cert {
+	c.mu.Lock()
+	c.checkInterval = interval
+	c.mu.Unlock()
+	return c
+}
+
+// Certificate 获取证书
+func (c *Cert) Certificate() (*tls.Certificate, error) {
+	c.checkReload()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.certificate == nil {
+		return nil, errors.New("cert parse failed or nil")
+	}
+	return c.certificate, nil
+}
+
+// TLSConfig 获取携带证书的 tls.Config
+func (c *Cert) TLSConfig() (*tls.Config, error) {
+	c.checkReload()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.config == nil {
+		return nil, errors.New("cert parse failed or nil")
+	}
+	return c.config, nil
+}
+
+// Transport 获取携带证书、可复用连接的 http.Transport
+func (c *Cert) Transport() (*http.Transport, error) {
+	c.checkReload()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.transport == nil {
+		return nil, errors.New("cert parse failed or nil")
+	}
+	return c.transport, nil
+}
+
+// Reload 重新读取并解析证书
+func (c *Cert) Reload() (err error) {
+	modTimes := make(map[string]time.Time)
+	certPem, keyPem, pfxData := c.certPem, c.keyPem, c.pfxData
+	if certPem, err = readFile(c.certFile, certPem, modTimes); err != nil {
+		return err
+	}
+	if keyPem, err = readFile(c.keyFile, keyPem, modTimes); err != nil {
+		return err
+	}
+	if pfxData, err = readFile(c.pkcs12File, pfxData, modTimes); err != nil {
+		return err
+	}
+	if pfxData != nil {
+		blocks, err := pkcs12.ToPEM(pfxData, c.password)
+		if err != nil {
+			return fmt.Errorf("pkcs12.ToPEM：%w", err)
+		}
+		for _, b := range blocks {
+			keyPem = append(keyPem, pem.EncodeToMemory(b)...)
+		}
+		certPem = keyPem
+	}
+	certificate, err := tls.X509KeyPair(certPem, keyPem)
+	if err != nil {
+		return fmt.Errorf("tls.X509KeyPair：%w", err)
+	}
+	c.set(&certificate)
+	c.mu.Lock()
+	c.modTimes = modTimes
+	c.lastCheck = time.Now()
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *Cert) set(certificate *tls.Certificate) {
+	config := &tls.Config{
+		Certificates:       []tls.Certificate{*certificate},
+		InsecureSkipVerify: true,
+	}
+	transport := &http.Transport{
+		TLSClientConfig: config,
+		Proxy:           http.ProxyFromEnvironment,
+	}
+	c.mu.Lock()
+	old := c.transport
+	c.certificate, c.config, c.transport = certificate, config, transport
+	c.mu.Unlock()
+	if old != nil {
+		old.CloseIdleConnections()
+	}
+}
+
+// 证书文件修改时间变化时重新加载，加载失败继续使用原证书
+func (c *Cert) checkReload() {
+	c.mu.Lock()
+	if len(c.modTimes) == 0 || time.Since(c.lastCheck) < c.checkInterval {
+		c.mu.Unlock()
+		return
+	}
+	c.lastCheck = time.Now()
+	changed := false
+	for file, modTime := range c.modTimes {
+		if info, err := os.Stat(file); err == nil && !info.ModTime().Equal(modTime) {
+			changed = true
+			break
+		}
+	}
+	c.mu.Unlock()
+	if changed {
+		if err := c.Reload(); err != nil {
+			xlog.Errorf("xtls: reload cert error: %v", err)
+		}
+	}
+}
+
+func pathOrContent(name string, file any) (path string, content []byte, err error) {
+	switch v := file.(type) {
+	case string:
+		if v == "" {
+			return "", nil, fmt.Errorf("%s is empty", name)
+		}
+		return v, nil, nil
+	case []byte:
+		if len(v) == 0 {
+			return "", nil, fmt.Errorf("%s is empty", name)
+		}
+		return "", v, nil
+	default:
+		return "", nil, fmt.Errorf("%s type error", name)
+	}
+}
+
+func readFile(path string, content []byte, modTimes map[string]time.Time) ([]byte, error) {
+	if path == "" {
+		return content, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.Stat：%w", err)
+	}
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile：%w", err)
+	}
+	modTimes[path] = info.ModTime()
+	return bs, nil
+}
@@ -0,0 +1,99 @@
+package xtls
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func genCertPem(t *testing.T, cn string) (certPem, keyPem []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPem = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPem = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return certPem, keyPem
+}
+
+func TestPemCertContent(t *testing.T) {
+	certPem, keyPem := genCertPem(t, "content")
+	c, err := NewPemCert(certPem, keyPem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t1, err := c.Transport()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t2, _ := c.Transport()
+	if t1 != t2 {
+		t.Fatal("transport should be cached")
+	}
+	if _, err = NewPemCert([]byte{}, keyPem); err == nil {
+		t.Fatal("empty certFile should return error")
+	}
+}
+
+func TestPemCertReload(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "apiclient_cert.pem"), filepath.Join(dir, "apiclient_key.pem")
+	certPem, keyPem := genCertPem(t, "old")
+	if err := os.WriteFile(certFile, certPem, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, keyPem, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewPemCert(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetCheckInterval(0)
+	t1, _ := c.Transport()
+	if t2, _ := c.Transport(); t1 != t2 {
+		t.Fatal("transport should be reused when files unchanged")
+	}
+
+	newCertPem, newKeyPem := genCertPem(t, "new")
+	if err = os.WriteFile(certFile, newCertPem, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(keyFile, newKeyPem, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Minute)
+	_ = os.Chtimes(certFile, future, future)
+	_ = os.Chtimes(keyFile, future, future)
+
+	t3, err := c.Transport()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if t3 == t1 {
+		t.Fatal("transport should be rebuilt after cert files changed")
+	}
+	certificate, _ := c.Certificate()
+	block, _ := pem.Decode(newCertPem)
+	if !bytes.Equal(certificate.Certificate[0], block.Bytes) {
+		t.Fatal("certificate not reloaded")
+	}
+}
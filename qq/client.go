@@ -6,6 +6,7 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/misu99/gopay/pkg/util"
 	"github.com/misu99/gopay/pkg/xhttp"
 	"github.com/misu99/gopay/pkg/xlog"
+	"github.com/misu99/gopay/pkg/xtls"
 )
 
 type Client struct {
@@ -21,7 +23,7 @@ type Client struct {
 	IsProd      bool
 	bodySize    int // http response body size(MB), default is 10MB
 	DebugSwitch gopay.DebugSwitch
-	certificate *tls.Certificate
+	cert        *xtls.Cert // 商户API证书，缓存 tls.Config 及 http.Transport
 	mu          sync.RWMutex
 }
 
@@ -48,7 +50,11 @@ func (q *Client) SetBodySize(sizeMB int) {
 // url：完整url地址，例如：https://qpay.qq.com/cgi-bin/pay/qpay_unified_order.cgi
 // tlsConfig：tls配置，如无需证书请求，传nil
 func (q *Client) PostQQAPISelf(ctx context.Context, bm gopay.BodyMap, url string, tlsConfig *tls.Config) (bs []byte, err error) {
-	return q.doQQ(ctx, bm, url, tlsConfig)
+	var transport *http.Transport
+	if tlsConfig != nil {
+		transport = &http.Transport{TLSClientConfig: tlsConfig, DisableKeepAlives: true, Proxy: http.ProxyFromEnvironment}
+	}
+	return q.doQQ(ctx, bm, url, transport)
 }
 
 // 提交付款码支付
@@ -146,12 +152,9 @@ func (q *Client) CloseOrder(ctx context.Context, bm gopay.BodyMap) (qqRsp *Close
 }
 
 // 申请退款
-// 注意：如已使用client.AddCertFilePath()添加过证书，参数certFilePath、keyFilePath、pkcs12FilePath全传空字符串 nil，否则，3证书Path均不可空
+// 注意：请在初始化client时，调用 client.AddCertFilePath() 等方法添加证书
 // 文档地址：https://qpay.qq.com/buss/wiki/38/1207
-func (q *Client) Refund(ctx context.Context, bm gopay.BodyMap, certFilePath, keyFilePath, pkcs12FilePath any) (qqRsp *RefundResponse, err error) {
-	if err = checkCertFilePathOrContent(certFilePath, keyFilePath, pkcs12FilePath); err != nil {
-		return nil, err
-	}
+func (q *Client) Refund(ctx context.Context, bm gopay.BodyMap) (qqRsp *RefundResponse, err error) {
 	err = bm.CheckEmptyError("nonce_str", "out_refund_no", "refund_fee", "op_user_id", "op_user_passwd")
 	if err != nil {
 		return nil, err
@@ -159,11 +162,11 @@ func (q *Client) Refund(ctx context.Context, bm gopay.BodyMap, certFilePath, key
 	if bm.GetString("out_trade_no") == util.NULL && bm.GetString("transaction_id") == util.NULL {
 		return nil, errors.New("out_trade_no and transaction_id are not allowed to be null at the same time")
 	}
-	tlsConfig, err := q.addCertConfig(certFilePath, keyFilePath, pkcs12FilePath)
+	transport, err := q.certTransport()
 	if err != nil {
 		return nil, err
 	}
-	bs, err := q.doQQ(ctx, bm, refund, tlsConfig)
+	bs, err := q.doQQ(ctx, bm, refund, transport)
 	if err != nil {
 		return nil, err
 	}
@@ -232,7 +235,7 @@ func (q *Client) AccRoll(ctx context.Context, bm gopay.BodyMap) (qqRsp string, e
 }
 
 // 向QQ发送请求
-func (q *Client) doQQ(ctx context.Context, bm gopay.BodyMap, url string, tlsConfig *tls.Config) (bs []byte, err error) {
+func (q *Client) doQQ(ctx context.Context, bm gopay.BodyMap, url string, transport *http.Transport) (bs []byte, err error) {
 
 	if bm.GetString("mch_id") == util.NULL {
 		bm.Set("mch_id", q.MchId)
@@ -250,8 +253,8 @@ func (q *Client) doQQ(ctx context.Context, bm gopay.BodyMap, url string, tlsConf
 	if q.bodySize > 0 {
 		httpClient.SetBodySize(q.bodySize)
 	}
-	if tlsConfig != nil {
-		httpClient.SetTLSConfig(tlsConfig)
+	if transport != nil {
+		httpClient.SetTransport(transport)
 	}
 	if q.DebugSwitch == gopay.DebugOn {
 		xlog.Debugf("QQ_Request: %s", bm.JsonBody())
@@ -307,7 +310,7 @@ func (q *Client) doQQGet(ctx context.Context, bm gopay.BodyMap, url, signType st
 	return bs, nil
 }
 
-func (q *Client) doQQRed(ctx context.Context, bm gopay.BodyMap, url string, tlsConfig *tls.Config) (bs []byte, err error) {
+func (q *Client) doQQRed(ctx context.Context, bm gopay.BodyMap, url string, transport *http.Transport) (bs []byte, err error) {
 
 	if bm.GetString("mch_id") == util.NULL {
 		bm.Set("mch_id", q.MchId)
@@ -318,8 +321,8 @@ func (q *Client) doQQRed(ctx context.Context, bm gopay.BodyMap, url string, tlsC
 	}
 
 	httpClient := xhttp.NewClient()
-	if tlsConfig != nil {
-		httpClient.SetTLSConfig(tlsConfig)
+	if transport != nil {
+		httpClient.SetTransport(transport)
 	}
 	if q.bodySize > 0 {
 		httpClient.SetBodySize(q.bodySize)
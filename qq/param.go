@@ -4,37 +4,44 @@ import (
 	"crypto/hmac"
 	"crypto/md5"
 	"crypto/sha256"
-	"crypto/tls"
 	"encoding/hex"
-	"encoding/pem"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"hash"
-	"io/ioutil"
+	"net/http"
 	"strings"
 
 	"github.com/misu99/gopay"
 	"github.com/misu99/gopay/pkg/util"
-	"golang.org/x/crypto/pkcs12"
+	"github.com/misu99/gopay/pkg/xtls"
 )
 
 // 添加QQ证书 Path 路径
 //
 //	certFilePath：apiclient_cert.pem 路径
 //	keyFilePath：apiclient_key.pem 路径
-//	pkcs12FilePath：apiclient_cert.p12 路径
+//	pkcs12FilePath：apiclient_cert.p12 路径，证书密码默认为商户号
 //	返回err
+//
+// 注意：只传pem证书或只传pkcs12证书均可；证书加载后缓存，证书文件变更后自动重新加载
 func (q *Client) AddCertFilePath(certFilePath, keyFilePath, pkcs12FilePath any) (err error) {
 	if err = checkCertFilePathOrContent(certFilePath, keyFilePath, pkcs12FilePath); err != nil {
 		return err
 	}
-	var config *tls.Config
-	if config, err = q.addCertConfig(certFilePath, keyFilePath, pkcs12FilePath); err != nil {
-		return
+	var cert *xtls.Cert
+	if certFilePath != nil && keyFilePath != nil {
+		cert, err = xtls.NewPemCert(certFilePath, keyFilePath)
+	} else if pkcs12FilePath != nil {
+		cert, err = xtls.NewPkcs12Cert(pkcs12FilePath, q.MchId)
+	} else {
+		return errors.New("cert files must all nil or all not nil")
+	}
+	if err != nil {
+		return err
 	}
 	q.mu.Lock()
-	q.certificate = &config.Certificates[0]
+	q.cert = cert
 	q.mu.Unlock()
 	return nil
 }
@@ -46,7 +53,43 @@ func (q *Client) AddCertFilePath(certFilePath, keyFilePath, pkcs12FilePath any)
 //	pkcs12FileContent：apiclient_cert.p12 内容
 //	返回err
 func (q *Client) AddCertFileContent(certFileContent, keyFileContent, pkcs12FileContent []byte) (err error) {
-	return q.AddCertFilePath(certFileContent, keyFileContent, pkcs12FileContent)
+	var certFile, keyFile, pkcs12File any
+	if certFileContent != nil {
+		certFile = certFileContent
+	}
+	if keyFileContent != nil {
+		keyFile = keyFileContent
+	}
+	if pkcs12FileContent != nil {
+		pkcs12File = pkcs12FileContent
+	}
+	return q.AddCertFilePath(certFile, keyFile, pkcs12File)
+}
+
+// 添加QQ pkcs12证书，并指定证书密码
+//
+//	pkcs12File：apiclient_cert.p12 路径（string）或内容（[]byte）
+//	password：证书密码
+func (q *Client) AddCertPkcs12WithPassword(pkcs12File any, password string) (err error) {
+	cert, err := xtls.NewPkcs12Cert(pkcs12File, password)
+	if err != nil {
+		return err
+	}
+	q.mu.Lock()
+	q.cert = cert
+	q.mu.Unlock()
+	return nil
+}
+
+// 获取携带商户API证书、可复用连接的 http.Transport
+func (q *Client) certTransport() (transport *http.Transport, err error) {
+	q.mu.RLock()
+	cert := q.cert
+	q.mu.RUnlock()
+	if cert == nil {
+		return nil, errors.New("cert parse failed or nil")
+	}
+	return cert.Transport()
 }
 
 func checkCertFilePathOrContent(certFile, keyFile, pkcs12File any) error {
@@ -109,66 +152,3 @@ func GetReleaseSign(apiKey string, signType string, bm gopay.BodyMap) (sign stri
 	h.Write([]byte(bm.EncodeWeChatSignParams(apiKey)))
 	return strings.ToUpper(hex.EncodeToString(h.Sum(nil)))
 }
-
-func (q *Client) addCertConfig(certFile, keyFile, pkcs12File any) (tlsConfig *tls.Config, err error) {
-	if certFile == nil && keyFile == nil && pkcs12File == nil {
-		q.mu.RLock()
-		defer q.mu.RUnlock()
-		if q.certificate != nil {
-			tlsConfig = &tls.Config{
-				Certificates:       []tls.Certificate{*q.certificate},
-				InsecureSkipVerify: true,
-			}
-			return tlsConfig, nil
-		}
-		return nil, errors.New("cert parse failed")
-	}
-
-	var (
-		certPem, keyPem []byte
-		certificate     tls.Certificate
-	)
-	if certFile != nil && keyFile != nil {
-		if _, ok := certFile.([]byte); ok {
-			certPem = certFile.([]byte)
-		} else {
-			certPem, err = ioutil.ReadFile(certFile.(string))
-		}
-		if _, ok := keyFile.([]byte); ok {
-			keyPem = keyFile.([]byte)
-		} else {
-			keyPem, err = ioutil.ReadFile(keyFile.(string))
-		}
-		if err != nil {
-			return nil, fmt.Errorf("ioutil.ReadFile：%w", err)
-		}
-	} else if pkcs12File != nil {
-		var pfxData []byte
-		if _, ok := pkcs12File.([]byte); ok {
-			pfxData = pkcs12File.([]byte)
-		} else {
-			if pfxData, err = ioutil.ReadFile(pkcs12File.(string)); err != nil {
-				return nil, fmt.Errorf("ioutil.ReadFile：%w", err)
-			}
-		}
-		blocks, err := pkcs12.ToPEM(pfxData, q.MchId)
-		if err != nil {
-			return nil, fmt.Errorf("pkcs12.ToPEM：%w", err)
-		}
-		for _, b := range blocks {
-			keyPem = append(keyPem, pem.EncodeToMemory(b)...)
-		}
-		certPem = keyPem
-	}
-	if certPem != nil && keyPem != nil {
-		if certificate, err = tls.X509KeyPair(certPem, keyPem); err != nil {
-			return nil, fmt.Errorf("tls.LoadX509KeyPair：%w", err)
-		}
-		tlsConfig = &tls.Config{
-			Certificates:       []tls.Certificate{certificate},
-			InsecureSkipVerify: true,
-		}
-		return tlsConfig, nil
-	}
-	return nil, errors.New("cert files must all nil or all not nil")
-}
@@ -2,10 +2,10 @@ package wechat
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/misu99/gopay"
 	"github.com/misu99/gopay/pkg/util"
@@ -130,13 +130,13 @@ func (w *Client) Refund(ctx context.Context, bm gopay.BodyMap) (wxRsp *RefundRes
 	}
 	var (
 		bs        []byte
-		tlsConfig *tls.Config
+		transport *http.Transport
 	)
 	if w.IsProd {
-		if tlsConfig, err = w.addCertConfig(nil, nil, nil); err != nil {
+		if transport, err = w.certTransport(); err != nil {
 			return nil, nil, err
 		}
-		bs, err = w.doProdPost(ctx, bm, refund, tlsConfig)
+		bs, err = w.doProdPost(ctx, bm, refund, transport)
 	} else {
 		bs, err = w.doSanBoxPost(ctx, bm, sandboxRefund)
 	}
@@ -196,13 +196,13 @@ func (w *Client) Reverse(ctx context.Context, bm gopay.BodyMap) (wxRsp *ReverseR
 	}
 	var (
 		bs        []byte
-		tlsConfig *tls.Config
+		transport *http.Transport
 	)
 	if w.IsProd {
-		if tlsConfig, err = w.addCertConfig(nil, nil, nil); err != nil {
+		if transport, err = w.certTransport(); err != nil {
 			return nil, err
 		}
-		bs, err = w.doProdPost(ctx, bm, reverse, tlsConfig)
+		bs, err = w.doProdPost(ctx, bm, reverse, transport)
 	} else {
 		bs, err = w.doSanBoxPost(ctx, bm, sandboxReverse)
 	}
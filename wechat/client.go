@@ -2,10 +2,12 @@ package wechat
 
 import (
 	"context"
+	"crypto/rsa"
 	"crypto/tls"
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/misu99/gopay/pkg/util"
 	"github.com/misu99/gopay/pkg/xhttp"
 	"github.com/misu99/gopay/pkg/xlog"
+	"github.com/misu99/gopay/pkg/xtls"
 )
 
 type Client struct {
@@ -25,6 +28,13 @@ type Client struct {
 	DebugSwitch gopay.DebugSwitch
 	Certificate *tls.Certificate
 	mu          sync.RWMutex
+
+	cert       *xtls.Cert       // 商户API证书，缓存 tls.Config 及 http.Transport
+	certSource *tls.Certificate // cert 对应的 Certificate，用于判断 Certificate 是否被直接修改
+
+	rsaPublicKey      *rsa.PublicKey // 企业付款到银行卡 RSA加密公钥缓存
+	rsaPublicKeyMchId string         // rsaPublicKey 所属商户号
+	rsaPublicKeyUrl   string         // 获取RSA加密公钥API地址，为空时使用 getPublicKey
 }
 
 // 初始化微信客户端 V2
@@ -54,7 +64,11 @@ func (w *Client) SetBodySize(sizeMB int) {
 // path：接口地址去掉baseURL的path，例如：url为https://api.mch.weixin.qq.com/pay/micropay，只需传 pay/micropay
 // tlsConfig：tls配置，如无需证书请求，传nil
 func (w *Client) PostWeChatAPISelf(ctx context.Context, bm gopay.BodyMap, path string, tlsConfig *tls.Config) (bs []byte, err error) {
-	return w.doProdPost(ctx, bm, path, tlsConfig)
+	var transport *http.Transport
+	if tlsConfig != nil {
+		transport = &http.Transport{TLSClientConfig: tlsConfig, DisableKeepAlives: true, Proxy: http.ProxyFromEnvironment}
+	}
+	return w.doProdPost(ctx, bm, path, transport)
 }
 
 // 授权码查询openid（正式）
@@ -113,11 +127,11 @@ func (w *Client) DownloadFundFlow(ctx context.Context, bm gopay.BodyMap) (wxRsp
 		return util.NULL, errors.New("account_type error, please reference: https://pay.weixin.qq.com/wiki/doc/api/jsapi.php?chapter=9_18&index=7")
 	}
 	bm.Set("sign_type", SignType_HMAC_SHA256)
-	tlsConfig, err := w.addCertConfig(nil, nil, nil)
+	transport, err := w.certTransport()
 	if err != nil {
 		return util.NULL, err
 	}
-	bs, err := w.doProdPost(ctx, bm, downloadFundFlow, tlsConfig)
+	bs, err := w.doProdPost(ctx, bm, downloadFundFlow, transport)
 	if err != nil {
 		return util.NULL, err
 	}
@@ -163,11 +177,11 @@ func (w *Client) BatchQueryComment(ctx context.Context, bm gopay.BodyMap) (wxRsp
 		return util.NULL, err
 	}
 	bm.Set("sign_type", SignType_HMAC_SHA256)
-	tlsConfig, err := w.addCertConfig(nil, nil, nil)
+	transport, err := w.certTransport()
 	if err != nil {
 		return util.NULL, err
 	}
-	bs, err := w.doProdPost(ctx, bm, batchQueryComment, tlsConfig)
+	bs, err := w.doProdPost(ctx, bm, batchQueryComment, transport)
 	if err != nil {
 		return util.NULL, err
 	}
@@ -217,7 +231,7 @@ func (w *Client) doSanBoxPost(ctx context.Context, bm gopay.BodyMap, path string
 }
 
 // Post请求、正式
-func (w *Client) doProdPost(ctx context.Context, bm gopay.BodyMap, path string, tlsConfig *tls.Config) (bs []byte, err error) {
+func (w *Client) doProdPost(ctx context.Context, bm gopay.BodyMap, path string, transport *http.Transport) (bs []byte, err error) {
 	var url = baseUrlCh + path
 	if bm.GetString("appid") == util.NULL {
 		bm.Set("appid", w.AppId)
@@ -231,8 +245,8 @@ func (w *Client) doProdPost(ctx context.Context, bm gopay.BodyMap, path string,
 	}
 
 	httpClient := xhttp.NewClient()
-	if w.IsProd && tlsConfig != nil {
-		httpClient.SetTLSConfig(tlsConfig)
+	if w.IsProd && transport != nil {
+		httpClient.SetTransport(transport)
 	}
 	if w.bodySize > 0 {
 		httpClient.SetBodySize(w.bodySize)
@@ -260,11 +274,11 @@ func (w *Client) doProdPost(ctx context.Context, bm gopay.BodyMap, path string,
 	return bs, nil
 }
 
-func (w *Client) doProdPostPure(ctx context.Context, bm gopay.BodyMap, path string, tlsConfig *tls.Config) (bs []byte, err error) {
+func (w *Client) doProdPostPure(ctx context.Context, bm gopay.BodyMap, path string, transport *http.Transport) (bs []byte, err error) {
 	var url = baseUrlCh + path
 	httpClient := xhttp.NewClient()
-	if w.IsProd && tlsConfig != nil {
-		httpClient.SetTLSConfig(tlsConfig)
+	if w.IsProd && transport != nil {
+		httpClient.SetTransport(transport)
 	}
 	if w.bodySize > 0 {
 		httpClient.SetBodySize(w.bodySize)
@@ -51,6 +51,9 @@ const (
 	queryBank                   = "/mmpaysptrans/query_bank"                          // 查询企业付款到银行卡API
 	getPublicKey                = "https://fraud.mch.weixin.qq.com/risk/getpublickey" // 获取RSA加密公钥API
 
+	// 企业付款到银行卡 enc_bank_no、enc_true_name 解密失败的错误码
+	payBankEncryptError = "ENCRYPT_ERROR"
+
 	// 海关自助清关
 	customsDeclareOrder   = "/cgi-bin/mch/customs/customdeclareorder"        // 订单附加信息提交
 	customsDeclareQuery   = "/cgi-bin/mch/customs/customdeclarequery"        // 订单附加信息查询
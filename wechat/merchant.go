@@ -2,15 +2,20 @@ package wechat
 
 import (
 	"context"
-	"crypto/tls"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/base64"
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/misu99/gopay"
 	"github.com/misu99/gopay/pkg/util"
 	"github.com/misu99/gopay/pkg/xhttp"
 	"github.com/misu99/gopay/pkg/xlog"
+	"github.com/misu99/gopay/pkg/xpem"
 )
 
 // 企业付款（企业向微信用户个人付款）
@@ -24,15 +29,15 @@ func (w *Client) Transfer(ctx context.Context, bm gopay.BodyMap) (wxRsp *Transfe
 	bm.Set("mch_appid", w.AppId)
 	bm.Set("mchid", w.MchId)
 	var (
-		tlsConfig *tls.Config
+		transport *http.Transport
 		url       = baseUrlCh + transfers
 	)
-	if tlsConfig, err = w.addCertConfig(nil, nil, nil); err != nil {
+	if transport, err = w.certTransport(); err != nil {
 		return nil, err
 	}
 	bm.Set("sign", w.getReleaseSign(w.ApiKey, SignType_MD5, bm))
 
-	httpClient := xhttp.NewClient().SetTLSConfig(tlsConfig).Type(xhttp.TypeXML)
+	httpClient := xhttp.NewClient().SetTransport(transport).Type(xhttp.TypeXML)
 	if w.BaseURL != util.NULL {
 		w.mu.RLock()
 		url = w.BaseURL + transfers
@@ -70,15 +75,15 @@ func (w *Client) GetTransferInfo(ctx context.Context, bm gopay.BodyMap) (wxRsp *
 	bm.Set("appid", w.AppId)
 	bm.Set("mch_id", w.MchId)
 	var (
-		tlsConfig *tls.Config
+		transport *http.Transport
 		url       = baseUrlCh + getTransferInfo
 	)
-	if tlsConfig, err = w.addCertConfig(nil, nil, nil); err != nil {
+	if transport, err = w.certTransport(); err != nil {
 		return nil, err
 	}
 	bm.Set("sign", w.getReleaseSign(w.ApiKey, SignType_MD5, bm))
 
-	httpClient := xhttp.NewClient().SetTLSConfig(tlsConfig).Type(xhttp.TypeXML)
+	httpClient := xhttp.NewClient().SetTransport(transport).Type(xhttp.TypeXML)
 	if w.BaseURL != util.NULL {
 		w.mu.RLock()
 		url = w.BaseURL + getTransferInfo
@@ -108,25 +113,45 @@ func (w *Client) GetTransferInfo(ctx context.Context, bm gopay.BodyMap) (wxRsp *
 // 企业付款到银行卡API（正式）
 // 注意：请在初始化client时，调用 client 添加证书的相关方法添加证书
 // 注意：此方法未支持沙箱环境，默认正式环境，转账请慎重
-// 注意：enc_bank_no、enc_true_name 两参数，开发者需自行获取RSA公钥，加密后再 Set 到 BodyMap，参考 client_test.go 里的 TestClient_PayBank() 方法
+// 注意：enc_bank_no、enc_true_name 两参数，可直接 Set 明文 bank_no、true_name，将自动获取并缓存RSA公钥加密后替换为 enc_bank_no、enc_true_name（加密传入 bm 的副本，不修改 bm）
+// 或开发者自行获取RSA公钥，加密后再 Set 到 BodyMap，参考 merchant_test.go 里的 TestClient_PayBank() 方法
 // 文档地址：https://pay.weixin.qq.com/wiki/doc/api/tools/mch_pay.php?chapter=24_2
 // RSA加密文档地址：https://pay.weixin.qq.com/wiki/doc/api/tools/mch_pay.php?chapter=24_7
 // 银行编码查看地址：https://pay.weixin.qq.com/wiki/doc/api/tools/mch_pay.php?chapter=24_4&index=5
 func (w *Client) PayBank(ctx context.Context, bm gopay.BodyMap) (wxRsp *PayBankResponse, err error) {
+	encrypted, auto, err := w.encryptPayBankFields(ctx, bm)
+	if err != nil {
+		return nil, err
+	}
+	if wxRsp, err = w.payBank(ctx, encrypted); err != nil {
+		return nil, err
+	}
+	// 微信更换RSA公钥后，使用缓存的旧公钥加密会失败，清除缓存后重新获取公钥加密，仅重试一次
+	if auto && wxRsp.ErrCode == payBankEncryptError {
+		w.ResetRSAPublicKey()
+		if encrypted, _, err = w.encryptPayBankFields(ctx, bm); err != nil {
+			return nil, err
+		}
+		return w.payBank(ctx, encrypted)
+	}
+	return wxRsp, nil
+}
+
+func (w *Client) payBank(ctx context.Context, bm gopay.BodyMap) (wxRsp *PayBankResponse, err error) {
 	if err = bm.CheckEmptyError("partner_trade_no", "nonce_str", "enc_bank_no", "enc_true_name", "bank_code", "amount"); err != nil {
 		return nil, err
 	}
 	bm.Set("mch_id", w.MchId)
 	var (
-		tlsConfig *tls.Config
+		transport *http.Transport
 		url       = baseUrlCh + payBank
 	)
-	if tlsConfig, err = w.addCertConfig(nil, nil, nil); err != nil {
+	if transport, err = w.certTransport(); err != nil {
 		return nil, err
 	}
 	bm.Set("sign", w.getReleaseSign(w.ApiKey, SignType_MD5, bm))
 
-	httpClient := xhttp.NewClient().SetTLSConfig(tlsConfig).Type(xhttp.TypeXML)
+	httpClient := xhttp.NewClient().SetTransport(transport).Type(xhttp.TypeXML)
 	if w.BaseURL != util.NULL {
 		w.mu.RLock()
 		url = w.BaseURL + payBank
@@ -162,15 +187,15 @@ func (w *Client) QueryBank(ctx context.Context, bm gopay.BodyMap) (wxRsp *QueryB
 	}
 	bm.Set("mch_id", w.MchId)
 	var (
-		tlsConfig *tls.Config
+		transport *http.Transport
 		url       = baseUrlCh + queryBank
 	)
-	if tlsConfig, err = w.addCertConfig(nil, nil, nil); err != nil {
+	if transport, err = w.certTransport(); err != nil {
 		return nil, err
 	}
 	bm.Set("sign", w.getReleaseSign(w.ApiKey, SignType_MD5, bm))
 
-	httpClient := xhttp.NewClient().SetTLSConfig(tlsConfig).Type(xhttp.TypeXML)
+	httpClient := xhttp.NewClient().SetTransport(transport).Type(xhttp.TypeXML)
 	if w.BaseURL != util.NULL {
 		w.mu.RLock()
 		url = w.BaseURL + queryBank
@@ -206,15 +231,18 @@ func (w *Client) GetRSAPublicKey(ctx context.Context, bm gopay.BodyMap) (wxRsp *
 	}
 	bm.Set("mch_id", w.MchId)
 	var (
-		tlsConfig *tls.Config
+		transport *http.Transport
 		url       = getPublicKey
 	)
-	if tlsConfig, err = w.addCertConfig(nil, nil, nil); err != nil {
+	if w.rsaPublicKeyUrl != util.NULL {
+		url = w.rsaPublicKeyUrl
+	}
+	if transport, err = w.certTransport(); err != nil {
 		return nil, err
 	}
 	bm.Set("sign", w.getReleaseSign(w.ApiKey, bm.GetString("sign_type"), bm))
 
-	httpClient := xhttp.NewClient().SetTLSConfig(tlsConfig).Type(xhttp.TypeXML)
+	httpClient := xhttp.NewClient().SetTransport(transport).Type(xhttp.TypeXML)
 	req := GenerateXml(bm)
 	if w.DebugSwitch == gopay.DebugOn {
 		xlog.Debugf("Wechat_Request: %s", req)
@@ -236,6 +264,106 @@ func (w *Client) GetRSAPublicKey(ctx context.Context, bm gopay.BodyMap) (wxRsp *
 	return wxRsp, nil
 }
 
+// 获取企业付款到银行卡的RSA加密公钥（带缓存）
+// 首次调用时通过 client.GetRSAPublicKey() 获取 PKCS#1 格式公钥，解析后按商户号缓存，后续调用直接返回缓存
+// 注意：请在初始化client时，调用 client 添加证书的相关方法添加证书
+func (w *Client) RSAPublicKey(ctx context.Context) (publicKey *rsa.PublicKey, err error) {
+	w.mu.RLock()
+	publicKey, mchId := w.rsaPublicKey, w.rsaPublicKeyMchId
+	w.mu.RUnlock()
+	if publicKey != nil && mchId == w.MchId {
+		return publicKey, nil
+	}
+	bm := make(gopay.BodyMap)
+	bm.Set("nonce_str", util.RandomString(32)).
+		Set("sign_type", SignType_MD5)
+	wxRsp, err := w.GetRSAPublicKey(ctx, bm)
+	if err != nil {
+		return nil, err
+	}
+	if wxRsp.ReturnCode != "SUCCESS" {
+		return nil, fmt.Errorf("get rsa public key error, return_msg: %s", wxRsp.ReturnMsg)
+	}
+	if wxRsp.ResultCode != "SUCCESS" {
+		return nil, fmt.Errorf("get rsa public key error, err_code: %s, err_code_des: %s", wxRsp.ErrCode, wxRsp.ErrCodeDes)
+	}
+	if err = w.SetRSAPublicKey(wxRsp.PubKey); err != nil {
+		return nil, err
+	}
+	w.mu.RLock()
+	publicKey = w.rsaPublicKey
+	w.mu.RUnlock()
+	return publicKey, nil
+}
+
+// 设置企业付款到银行卡的RSA加密公钥缓存
+// pubKey：client.GetRSAPublicKey() 返回的 pub_key 内容（PKCS#1），也支持 PKCS#8 格式
+func (w *Client) SetRSAPublicKey(pubKey string) (err error) {
+	publicKey, err := xpem.DecodePublicKey([]byte(pubKey))
+	if err != nil {
+		return err
+	}
+	if publicKey == nil {
+		return errors.New("rsa public key decode error")
+	}
+	w.mu.Lock()
+	w.rsaPublicKey = publicKey
+	w.rsaPublicKeyMchId = w.MchId
+	w.mu.Unlock()
+	return nil
+}
+
+// 清除企业付款到银行卡的RSA加密公钥缓存，下次加密时重新获取
+// 注意：client.PayBank() 返回加密错误时会自动清除缓存并重试一次
+func (w *Client) ResetRSAPublicKey() {
+	w.mu.Lock()
+	w.rsaPublicKey = nil
+	w.rsaPublicKeyMchId = util.NULL
+	w.mu.Unlock()
+}
+
+// 企业付款到银行卡 RSA加密（RSA_PKCS1_OAEP_PADDING，SHA1），返回 base64 编码后的密文
+// 用于加密 enc_bank_no、enc_true_name，公钥通过 client.RSAPublicKey() 获取并缓存
+func (w *Client) RSAEncrypt(ctx context.Context, originData string) (cipherText string, err error) {
+	publicKey, err := w.RSAPublicKey(ctx)
+	if err != nil {
+		return util.NULL, err
+	}
+	cipherBytes, err := rsa.EncryptOAEP(sha1.New(), rand.Reader, publicKey, []byte(originData), nil)
+	if err != nil {
+		return util.NULL, fmt.Errorf("rsa.EncryptOAEP, err:%w", err)
+	}
+	return base64.StdEncoding.EncodeToString(cipherBytes), nil
+}
+
+// 明文 bank_no、true_name 自动加密为 enc_bank_no、enc_true_name
+// 不修改调用方传入的 bm，需要加密时返回加密后的副本 encrypted，auto 表示是否使用了自动获取的RSA公钥加密
+func (w *Client) encryptPayBankFields(ctx context.Context, bm gopay.BodyMap) (encrypted gopay.BodyMap, auto bool, err error) {
+	if bm.GetString("bank_no") == util.NULL && bm.GetString("true_name") == util.NULL {
+		return bm, false, nil
+	}
+	encrypted = make(gopay.BodyMap, len(bm))
+	for k, v := range bm {
+		encrypted[k] = v
+	}
+	for plainKey, encKey := range map[string]string{"bank_no": "enc_bank_no", "true_name": "enc_true_name"} {
+		plain := bm.GetString(plainKey)
+		if plain == util.NULL {
+			continue
+		}
+		if bm.GetString(encKey) == util.NULL {
+			cipherText, err := w.RSAEncrypt(ctx, plain)
+			if err != nil {
+				return nil, false, err
+			}
+			encrypted.Set(encKey, cipherText)
+			auto = true
+		}
+		encrypted.Remove(plainKey)
+	}
+	return encrypted, auto, nil
+}
+
 // 请求单次分账
 // 单次分账请求按照传入的分账接收方账号和资金进行分账，
 // 同时会将订单剩余的待分账金额解冻给本商户。
@@ -265,11 +393,11 @@ func (w *Client) profitSharing(ctx context.Context, bm gopay.BodyMap, uri string
 
 	// 设置签名类型，官方文档此接口只支持 HMAC_SHA256
 	bm.Set("sign_type", SignType_HMAC_SHA256)
-	tlsConfig, err := w.addCertConfig(nil, nil, nil)
+	transport, err := w.certTransport()
 	if err != nil {
 		return nil, err
 	}
-	bs, err := w.doProdPost(ctx, bm, uri, tlsConfig)
+	bs, err := w.doProdPost(ctx, bm, uri, transport)
 	if err != nil {
 		return nil, err
 	}
@@ -361,11 +489,11 @@ func (w *Client) ProfitSharingFinish(ctx context.Context, bm gopay.BodyMap) (wxR
 	}
 	// 设置签名类型，官方文档此接口只支持 HMAC_SHA256
 	bm.Set("sign_type", SignType_HMAC_SHA256)
-	tlsConfig, err := w.addCertConfig(nil, nil, nil)
+	transport, err := w.certTransport()
 	if err != nil {
 		return nil, err
 	}
-	bs, err := w.doProdPost(ctx, bm, profitSharingFinish, tlsConfig)
+	bs, err := w.doProdPost(ctx, bm, profitSharingFinish, transport)
 	if err != nil {
 		return nil, err
 	}
@@ -394,11 +522,11 @@ func (w *Client) ProfitSharingReturn(ctx context.Context, bm gopay.BodyMap) (wxR
 	}
 	// 设置签名类型，官方文档此接口只支持 HMAC_SHA256
 	bm.Set("sign_type", SignType_HMAC_SHA256)
-	tlsConfig, err := w.addCertConfig(nil, nil, nil)
+	transport, err := w.certTransport()
 	if err != nil {
 		return nil, err
 	}
-	bs, err := w.doProdPost(ctx, bm, profitSharingReturn, tlsConfig)
+	bs, err := w.doProdPost(ctx, bm, profitSharingReturn, transport)
 	if err != nil {
 		return nil, err
 	}
@@ -1,9 +1,17 @@
 package wechat
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/sha1"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/misu99/gopay"
@@ -127,6 +135,123 @@ func TestClient_GetRSAPublicKey(t *testing.T) {
 	xlog.Debugf("publicKey:%#v", publicKey)
 }
 
+func TestClient_PayBankEncrypt(t *testing.T) {
+	priKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 微信返回的 pub_key 为 PKCS#1 格式
+	pubKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&priKey.PublicKey)})
+	wxClient := NewClient(appId, mchId, apiKey, true)
+	if err = wxClient.SetRSAPublicKey(string(pubKey)); err != nil {
+		t.Fatal(err)
+	}
+
+	bm := make(gopay.BodyMap)
+	bm.Set("bank_no", "621400000000567").
+		Set("true_name", "Jerry")
+	encrypted, auto, err := wxClient.encryptPayBankFields(ctx, bm)
+	if err != nil || !auto {
+		t.Fatalf("encryptPayBankFields() auto: %v, err: %v", auto, err)
+	}
+	if encrypted.GetString("bank_no") != util.NULL || encrypted.GetString("true_name") != util.NULL {
+		t.Fatalf("plain fields not removed: %v", encrypted)
+	}
+	if bm.GetString("bank_no") != "621400000000567" || bm.GetString("enc_bank_no") != util.NULL {
+		t.Fatalf("encryptPayBankFields() modified bm: %v", bm)
+	}
+	for key, want := range map[string]string{"enc_bank_no": "621400000000567", "enc_true_name": "Jerry"} {
+		cipherBytes, err := base64.StdEncoding.DecodeString(encrypted.GetString(key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		plain, err := rsa.DecryptOAEP(sha1.New(), rand.Reader, priKey, cipherBytes, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(plain) != want {
+			t.Fatalf("%s decrypt got %s, want %s", key, plain, want)
+		}
+	}
+}
+
+func TestClient_PayBankRSAPublicKeyRefresh(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		servedKey                    = newKey // getpublickey 返回的公钥
+		payBankCount, publicKeyCount int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/risk/getpublickey" {
+			publicKeyCount++
+			pubKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&servedKey.PublicKey)})
+			_, _ = w.Write([]byte("<xml><return_code>SUCCESS</return_code><result_code>SUCCESS</result_code><pub_key><![CDATA[" + string(pubKey) + "]]></pub_key></xml>"))
+			return
+		}
+		payBankCount++
+		req := new(struct {
+			EncBankNo string `xml:"enc_bank_no"`
+		})
+		_ = xml.NewDecoder(r.Body).Decode(req)
+		cipherBytes, _ := base64.StdEncoding.DecodeString(req.EncBankNo)
+		if _, err := rsa.DecryptOAEP(sha1.New(), rand.Reader, newKey, cipherBytes, nil); err != nil {
+			_, _ = w.Write([]byte("<xml><return_code>SUCCESS</return_code><result_code>FAIL</result_code><err_code>ENCRYPT_ERROR</err_code></xml>"))
+			return
+		}
+		_, _ = w.Write([]byte("<xml><return_code>SUCCESS</return_code><result_code>SUCCESS</result_code><payment_no>10000600500852017030900000020006012</payment_no></xml>"))
+	}))
+	defer srv.Close()
+
+	wxClient := NewClient(appId, mchId, apiKey, true)
+	wxClient.BaseURL = srv.URL
+	wxClient.rsaPublicKeyUrl = srv.URL + "/risk/getpublickey"
+	wxClient.Certificate = &tls.Certificate{}
+	// 缓存中为微信已更换的旧公钥
+	pubKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&oldKey.PublicKey)})
+	if err = wxClient.SetRSAPublicKey(string(pubKey)); err != nil {
+		t.Fatal(err)
+	}
+
+	bm := make(gopay.BodyMap)
+	bm.Set("partner_trade_no", "1212121221227").
+		Set("nonce_str", util.RandomString(32)).
+		Set("bank_no", "621400000000567").
+		Set("true_name", "Jerry").
+		Set("bank_code", "1001").
+		Set("amount", 1)
+	wxRsp, err := wxClient.PayBank(ctx, bm)
+	if err != nil || wxRsp.ResultCode != "SUCCESS" {
+		t.Fatalf("PayBank() = %+v, %v", wxRsp, err)
+	}
+	if payBankCount != 2 || publicKeyCount != 1 {
+		t.Fatalf("PayBank() requests: %d, getpublickey requests: %d", payBankCount, publicKeyCount)
+	}
+	if publicKey, _ := wxClient.RSAPublicKey(ctx); !publicKey.Equal(&newKey.PublicKey) {
+		t.Fatal("RSAPublicKey() not refreshed")
+	}
+	if bm.GetString("bank_no") != "621400000000567" || bm.GetString("enc_bank_no") != util.NULL || bm.GetString("sign") != util.NULL {
+		t.Fatalf("PayBank() modified bm: %v", bm)
+	}
+
+	// 重新获取公钥后仍加密失败，不再重试
+	wxClient.ResetRSAPublicKey()
+	servedKey = oldKey
+	payBankCount, publicKeyCount = 0, 0
+	if wxRsp, err = wxClient.PayBank(ctx, bm); err != nil || wxRsp.ErrCode != "ENCRYPT_ERROR" {
+		t.Fatalf("PayBank() = %+v, %v", wxRsp, err)
+	}
+	if payBankCount != 2 || publicKeyCount != 2 {
+		t.Fatalf("PayBank() requests: %d, getpublickey requests: %d", payBankCount, publicKeyCount)
+	}
+}
+
 func TestClient_PayBank(t *testing.T) {
 	// 初始化参数结构体
 	bm := make(gopay.BodyMap)
@@ -5,21 +5,18 @@ import (
 	"crypto/hmac"
 	"crypto/md5"
 	"crypto/sha256"
-	"crypto/tls"
 	"encoding/hex"
-	"encoding/pem"
 	"encoding/xml"
 	"errors"
-	"fmt"
 	"hash"
-	"io/ioutil"
+	"net/http"
 	"strings"
 
 	"github.com/misu99/gopay"
 	"github.com/misu99/gopay/pkg/util"
 	"github.com/misu99/gopay/pkg/xhttp"
 	"github.com/misu99/gopay/pkg/xlog"
-	"golang.org/x/crypto/pkcs12"
+	"github.com/misu99/gopay/pkg/xtls"
 )
 
 type Country int
@@ -48,146 +45,89 @@ func (w *Client) SetCountry(country Country) (client *Client) {
 // 添加微信pem证书文件路径
 // certFilePath：apiclient_cert.pem 文件路径
 // keyFilePath：apiclient_key.pem 文件路径
+// 注意：证书加载后缓存，证书文件变更后自动重新加载
 func (w *Client) AddCertPemFilePath(certFilePath, keyFilePath string) (err error) {
-	return w.addCertFileContentOrPath(certFilePath, keyFilePath, nil)
+	cert, err := xtls.NewPemCert(certFilePath, keyFilePath)
+	if err != nil {
+		return err
+	}
+	return w.setCert(cert)
 }
 
 // 添加微信pkcs12证书文件路径
-// pkcs12FilePath：apiclient_cert.p12 文件路径
+// pkcs12FilePath：apiclient_cert.p12 文件路径，证书密码默认为商户号
+// 注意：证书加载后缓存，证书文件变更后自动重新加载
 func (w *Client) AddCertPkcs12FilePath(pkcs12FilePath string) (err error) {
-	return w.addCertFileContentOrPath(nil, nil, pkcs12FilePath)
+	return w.AddCertPkcs12FilePathWithPassword(pkcs12FilePath, w.MchId)
+}
+
+// 添加微信pkcs12证书文件路径，并指定证书密码
+// pkcs12FilePath：apiclient_cert.p12 文件路径
+// password：证书密码
+func (w *Client) AddCertPkcs12FilePathWithPassword(pkcs12FilePath, password string) (err error) {
+	cert, err := xtls.NewPkcs12Cert(pkcs12FilePath, password)
+	if err != nil {
+		return err
+	}
+	return w.setCert(cert)
 }
 
 // 添加微信pem证书内容[]byte
 // certFileContent：apiclient_cert.pem 证书内容[]byte
 // keyFileContent：apiclient_key.pem 证书内容[]byte
 func (w *Client) AddCertPemFileContent(certFileContent, keyFileContent []byte) (err error) {
-	return w.addCertFileContentOrPath(certFileContent, keyFileContent, nil)
+	cert, err := xtls.NewPemCert(certFileContent, keyFileContent)
+	if err != nil {
+		return err
+	}
+	return w.setCert(cert)
 }
 
 // 添加微信pkcs12证书内容[]byte
-// p12FileContent：apiclient_cert.p12 证书内容[]byte
+// p12FileContent：apiclient_cert.p12 证书内容[]byte，证书密码默认为商户号
 func (w *Client) AddCertPkcs12FileContent(p12FileContent []byte) (err error) {
-	return w.addCertFileContentOrPath(nil, nil, p12FileContent)
+	return w.AddCertPkcs12FileContentWithPassword(p12FileContent, w.MchId)
 }
 
-// 添加微信证书文件 Path 路径或证书内容
-// 注意：只传pem证书或只传pkcs12证书均可，无需3个证书全传
-func (w *Client) addCertFileContentOrPath(certFile, keyFile, pkcs12File any) (err error) {
-	if err = checkCertFilePathOrContent(certFile, keyFile, pkcs12File); err != nil {
-		return
-	}
-	var config *tls.Config
-	if config, err = w.addCertConfig(certFile, keyFile, pkcs12File); err != nil {
-		return
+// 添加微信pkcs12证书内容[]byte，并指定证书密码
+// p12FileContent：apiclient_cert.p12 证书内容[]byte
+// password：证书密码
+func (w *Client) AddCertPkcs12FileContentWithPassword(p12FileContent []byte, password string) (err error) {
+	cert, err := xtls.NewPkcs12Cert(p12FileContent, password)
+	if err != nil {
+		return err
 	}
-	w.mu.Lock()
-	w.Certificate = &config.Certificates[0]
-	w.mu.Unlock()
-	return
+	return w.setCert(cert)
 }
 
-func (w *Client) addCertConfig(certFile, keyFile, pkcs12File any) (tlsConfig *tls.Config, err error) {
-	if certFile == nil && keyFile == nil && pkcs12File == nil {
-		w.mu.RLock()
-		defer w.mu.RUnlock()
-		if w.Certificate != nil {
-			tlsConfig = &tls.Config{
-				Certificates:       []tls.Certificate{*w.Certificate},
-				InsecureSkipVerify: true,
-			}
-			return tlsConfig, nil
-		}
-		return nil, errors.New("cert parse failed or nil")
-	}
-
-	var (
-		certPem, keyPem []byte
-		certificate     tls.Certificate
-	)
-	if certFile != nil && keyFile != nil {
-		if _, ok := certFile.([]byte); ok {
-			certPem = certFile.([]byte)
-		} else {
-			certPem, err = ioutil.ReadFile(certFile.(string))
-		}
-		if _, ok := keyFile.([]byte); ok {
-			keyPem = keyFile.([]byte)
-		} else {
-			keyPem, err = ioutil.ReadFile(keyFile.(string))
-		}
-		if err != nil {
-			return nil, fmt.Errorf("ioutil.ReadFile：%w", err)
-		}
-	} else if pkcs12File != nil {
-		var pfxData []byte
-		if _, ok := pkcs12File.([]byte); ok {
-			pfxData = pkcs12File.([]byte)
-		} else {
-			if pfxData, err = ioutil.ReadFile(pkcs12File.(string)); err != nil {
-				return nil, fmt.Errorf("ioutil.ReadFile：%w", err)
-			}
-		}
-		blocks, err := pkcs12.ToPEM(pfxData, w.MchId)
-		if err != nil {
-			return nil, fmt.Errorf("pkcs12.ToPEM：%w", err)
-		}
-		for _, b := range blocks {
-			keyPem = append(keyPem, pem.EncodeToMemory(b)...)
-		}
-		certPem = keyPem
-	}
-	if certPem != nil && keyPem != nil {
-		if certificate, err = tls.X509KeyPair(certPem, keyPem); err != nil {
-			return nil, fmt.Errorf("tls.LoadX509KeyPair：%w", err)
-		}
-		tlsConfig = &tls.Config{
-			Certificates:       []tls.Certificate{certificate},
-			InsecureSkipVerify: true,
-		}
-		return tlsConfig, nil
+func (w *Client) setCert(cert *xtls.Cert) (err error) {
+	certificate, err := cert.Certificate()
+	if err != nil {
+		return err
 	}
-	return nil, errors.New("cert files must all nil or all not nil")
+	w.mu.Lock()
+	w.cert, w.certSource = cert, certificate
+	w.Certificate = certificate
+	w.mu.Unlock()
+	return nil
 }
 
-func checkCertFilePathOrContent(certFile, keyFile, pkcs12File any) error {
-	if certFile == nil && keyFile == nil && pkcs12File == nil {
-		return nil
-	}
-	if certFile != nil && keyFile != nil {
-		files := map[string]any{"certFile": certFile, "keyFile": keyFile}
-		for varName, v := range files {
-			switch v := v.(type) {
-			case string:
-				if v == util.NULL {
-					return fmt.Errorf("%s is empty", varName)
-				}
-			case []byte:
-				if len(v) == 0 {
-					return fmt.Errorf("%s is empty", varName)
-				}
-			default:
-				return fmt.Errorf("%s type error", varName)
-			}
+// 获取携带商户API证书、可复用连接的 http.Transport
+// 未通过 AddCert 相关方法添加证书而直接设置 client.Certificate 时，使用该证书
+func (w *Client) certTransport() (transport *http.Transport, err error) {
+	w.mu.RLock()
+	cert, certificate, source := w.cert, w.Certificate, w.certSource
+	w.mu.RUnlock()
+	if cert == nil || certificate != source {
+		if certificate == nil {
+			return nil, errors.New("cert parse failed or nil")
 		}
-		return nil
-	} else if pkcs12File != nil {
-		switch pkcs12File := pkcs12File.(type) {
-		case string:
-			if pkcs12File == util.NULL {
-				return errors.New("pkcs12File is empty")
-			}
-		case []byte:
-			if len(pkcs12File) == 0 {
-				return errors.New("pkcs12File is empty")
-			}
-		default:
-			return errors.New("pkcs12File type error")
-		}
-		return nil
-	} else {
-		return errors.New("certFile keyFile must all nil or all not nil")
+		cert = xtls.NewCertFromCertificate(*certificate)
+		w.mu.Lock()
+		w.cert, w.certSource = cert, certificate
+		w.mu.Unlock()
 	}
+	return cert.Transport()
 }
 
 // 获取微信支付正式环境Sign值
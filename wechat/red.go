@@ -34,12 +34,12 @@ func (w *Client) SendCashRed(ctx context.Context, bm gopay.BodyMap) (wxRsp *Send
 		bm.Set("sign", sign)
 	}
 
-	tlsConfig, err := w.addCertConfig(nil, nil, nil)
+	transport, err := w.certTransport()
 	if err != nil {
 		return nil, err
 	}
 
-	bs, err := w.doProdPostPure(ctx, bm, sendCashRed, tlsConfig)
+	bs, err := w.doProdPostPure(ctx, bm, sendCashRed, transport)
 	if err != nil {
 		return nil, err
 	}
@@ -71,12 +71,12 @@ func (w *Client) SendGroupCashRed(ctx context.Context, bm gopay.BodyMap) (wxRsp
 		bm.Set("sign", sign)
 	}
 
-	tlsConfig, err := w.addCertConfig(nil, nil, nil)
+	transport, err := w.certTransport()
 	if err != nil {
 		return nil, err
 	}
 
-	bs, err := w.doProdPostPure(ctx, bm, sendGroupCashRed, tlsConfig)
+	bs, err := w.doProdPostPure(ctx, bm, sendGroupCashRed, transport)
 	if err != nil {
 		return nil, err
 	}
@@ -108,12 +108,12 @@ func (w *Client) SendAppletRed(ctx context.Context, bm gopay.BodyMap) (wxRsp *Se
 		bm.Set("sign", sign)
 	}
 
-	tlsConfig, err := w.addCertConfig(nil, nil, nil)
+	transport, err := w.certTransport()
 	if err != nil {
 		return nil, err
 	}
 
-	bs, err := w.doProdPostPure(ctx, bm, sendAppletRed, tlsConfig)
+	bs, err := w.doProdPostPure(ctx, bm, sendAppletRed, transport)
 	if err != nil {
 		return nil, err
 	}
@@ -145,12 +145,12 @@ func (w *Client) QueryRedRecord(ctx context.Context, bm gopay.BodyMap) (wxRsp *Q
 		bm.Set("sign", sign)
 	}
 
-	tlsConfig, err := w.addCertConfig(nil, nil, nil)
+	transport, err := w.certTransport()
 	if err != nil {
 		return nil, err
 	}
 
-	bs, err := w.doProdPostPure(ctx, bm, getRedRecord, tlsConfig)
+	bs, err := w.doProdPostPure(ctx, bm, getRedRecord, transport)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,125 @@
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/misu99/gopay"
+)
+
+// 商户开户意愿确认-提交申请单API
+// 注意：本接口会提交一些敏感信息，需调用 client.V3EncryptText() 进行加密
+// 或使用 ContactInfo、IdCardInfo 等结构体设置参数，标记 sensitive 的字段会自动加密
+// Code = 0 is success
+func (c *ClientV3) V3Apply4SubjectSubmit(ctx context.Context, bm gopay.BodyMap) (*Apply4SubjectSubmitRsp, error) {
+	if err := bm.CheckEmptyError("business_code", "contact_info", "subject_info", "identification_info"); err != nil {
+		return nil, err
+	}
+	bm, wxSerialNo, err := c.encryptSensitiveBodyMap(bm)
+	if err != nil {
+		return nil, err
+	}
+	authorization, err := c.authorization(MethodPost, v3Apply4SubjectSubmit, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPostWithHeader(ctx, map[string]string{HeaderSerial: wxSerialNo}, bm, v3Apply4SubjectSubmit, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp := &Apply4SubjectSubmitRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(Apply4SubjectSubmit)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 商户开户意愿确认-通过业务申请编号撤销申请单API
+// Code = 0 is success
+func (c *ClientV3) V3Apply4SubjectCancelByBusinessCode(ctx context.Context, businessCode string) (*EmptyRsp, error) {
+	return c.apply4SubjectCancel(ctx, fmt.Sprintf(v3Apply4SubjectCancelByBusinessCode, businessCode))
+}
+
+// 商户开户意愿确认-通过申请单号撤销申请单API
+// Code = 0 is success
+func (c *ClientV3) V3Apply4SubjectCancelByApplyId(ctx context.Context, applymentId int64) (*EmptyRsp, error) {
+	return c.apply4SubjectCancel(ctx, fmt.Sprintf(v3Apply4SubjectCancelByApplyId, applymentId))
+}
+
+func (c *ClientV3) apply4SubjectCancel(ctx context.Context, url string) (*EmptyRsp, error) {
+	bm := make(gopay.BodyMap)
+	authorization, err := c.authorization(MethodPost, url, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(ctx, bm, url, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp := &EmptyRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 商户开户意愿确认-查询申请单审核结果API
+// 注意：bm 中参数为 query 参数，business_code、applyment_id 二选一
+// Code = 0 is success
+func (c *ClientV3) V3Apply4SubjectQueryState(ctx context.Context, bm gopay.BodyMap) (*Apply4SubjectQueryStateRsp, error) {
+	uri := v3Apply4SubjectQueryState + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(ctx, uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp := &Apply4SubjectQueryStateRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(Apply4SubjectQueryState)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 商户开户意愿确认-获取商户开户意愿确认状态API
+// Code = 0 is success
+func (c *ClientV3) V3Apply4SubjectQueryAuthorizeState(ctx context.Context, subMchid string) (*Apply4SubjectAuthorizeStateRsp, error) {
+	uri := fmt.Sprintf(v3Apply4SubjectQueryAuthorizeState, subMchid)
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(ctx, uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp := &Apply4SubjectAuthorizeStateRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(Apply4SubjectAuthorizeState)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
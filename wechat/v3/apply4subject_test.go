@@ -0,0 +1,86 @@
+package wechat
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/misu99/gopay"
+)
+
+func TestV3Apply4SubjectSubmit(t *testing.T) {
+	srv, c := newAPIServer(t)
+
+	// 缺少必填参数时不发送请求
+	bm := make(gopay.BodyMap)
+	bm.Set("business_code", "1900013511_10000")
+	if _, err := c.V3Apply4SubjectSubmit(ctx, bm); !errors.Is(err, gopay.MissParamErr) || srv.method != "" {
+		t.Fatalf("V3Apply4SubjectSubmit() err: %v, request: %s", err, srv.method)
+	}
+
+	// contact_info 中的敏感字段自动加密，且不修改调用方的 bm
+	srv.reply(http.StatusOK, `{"applyment_id":20000011111}`)
+	contact := &ContactInfo{ContactName: "张三", ContactIdNumber: "320311770706001", MobilePhone: "13900000000"}
+	bm.Set("contact_info", contact).
+		SetBodyMap("subject_info", func(bm gopay.BodyMap) {
+			bm.Set("subject_type", "SUBJECT_TYPE_ENTERPRISE")
+		}).
+		SetBodyMap("identification_info", func(bm gopay.BodyMap) {
+			bm.Set("identification_type", "IDENTIFICATION_TYPE_IDCARD")
+		})
+	wxRsp, err := c.V3Apply4SubjectSubmit(ctx, bm)
+	if err != nil || wxRsp.Response.ApplymentId != 20000011111 {
+		t.Fatalf("V3Apply4SubjectSubmit() = %+v, %v", wxRsp, err)
+	}
+	srv.check(t, http.MethodPost, v3Apply4SubjectSubmit)
+	reqContact, _ := srv.req["contact_info"].(map[string]any)
+	for k, want := range map[string]string{"contact_name": "张三", "contact_id_number": "320311770706001", "mobile_phone": "13900000000"} {
+		if text, err := c.V3DecryptText(reqContact[k].(string)); err != nil || text != want {
+			t.Fatalf("V3Apply4SubjectSubmit() contact_info.%s: %s, %v", k, text, err)
+		}
+	}
+	if srv.header.Get(HeaderSerial) != "SN1" || contact.ContactName != "张三" {
+		t.Fatalf("V3Apply4SubjectSubmit() Wechatpay-Serial: %s, contact: %+v", srv.header.Get(HeaderSerial), contact)
+	}
+
+	srv.reply(http.StatusBadRequest, `{"code":"PARAM_ERROR","message":"业务申请编号已存在"}`)
+	if wxRsp, err = c.V3Apply4SubjectSubmit(ctx, bm); err != nil || wxRsp.Code != http.StatusBadRequest || wxRsp.Error == "" {
+		t.Fatalf("V3Apply4SubjectSubmit() = %+v, %v", wxRsp, err)
+	}
+}
+
+func TestV3Apply4SubjectQuery(t *testing.T) {
+	srv, c := newAPIServer(t)
+
+	srv.reply(http.StatusNoContent, "")
+	emptyRsp, err := c.V3Apply4SubjectCancelByBusinessCode(ctx, "1900013511_10000")
+	if err != nil || emptyRsp.Code != Success {
+		t.Fatalf("V3Apply4SubjectCancelByBusinessCode() = %+v, %v", emptyRsp, err)
+	}
+	srv.check(t, http.MethodPost, "/v3/apply4subject/applyment/1900013511_10000/cancel")
+	if emptyRsp, err = c.V3Apply4SubjectCancelByApplyId(ctx, 20000011111); err != nil || emptyRsp.Code != Success {
+		t.Fatalf("V3Apply4SubjectCancelByApplyId() = %+v, %v", emptyRsp, err)
+	}
+	srv.check(t, http.MethodPost, "/v3/apply4subject/applyment/20000011111/cancel")
+
+	srv.reply(http.StatusOK, `{"applyment_state":"APPLYMENT_STATE_WAITTING_FOR_CONFIRM_CONTACT","qrcode_data":"aGVsbG8="}`)
+	bm := make(gopay.BodyMap)
+	bm.Set("business_code", "1900013511_10000")
+	stateRsp, err := c.V3Apply4SubjectQueryState(ctx, bm)
+	if err != nil || stateRsp.Response.ApplymentState != "APPLYMENT_STATE_WAITTING_FOR_CONFIRM_CONTACT" || stateRsp.Response.QrcodeData == "" {
+		t.Fatalf("V3Apply4SubjectQueryState() = %+v, %v", stateRsp, err)
+	}
+	srv.check(t, http.MethodGet, v3Apply4SubjectQueryState+"?business_code=1900013511_10000")
+
+	srv.reply(http.StatusOK, `{"authorize_state":"AUTHORIZE_STATE_AUTHORIZED"}`)
+	authRsp, err := c.V3Apply4SubjectQueryAuthorizeState(ctx, "1511101111")
+	if err != nil || authRsp.Response.AuthorizeState != "AUTHORIZE_STATE_AUTHORIZED" {
+		t.Fatalf("V3Apply4SubjectQueryAuthorizeState() = %+v, %v", authRsp, err)
+	}
+	srv.check(t, http.MethodGet, "/v3/apply4subject/applyment/merchants/1511101111/state")
+
+	srv.sign = testSignInfo(t, c, "SN1").HeaderSignature
+	if _, err = c.V3Apply4SubjectQueryAuthorizeState(ctx, "1511101111"); !errors.Is(err, gopay.VerifySignatureErr) {
+		t.Fatalf("V3Apply4SubjectQueryAuthorizeState() with bad sign err: %v", err)
+	}
+}
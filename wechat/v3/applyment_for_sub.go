@@ -11,17 +11,22 @@ import (
 
 // 提交申请单API
 // 注意：本接口会提交一些敏感信息，需调用 client.V3EncryptText() 进行加密
+// 或使用 ContactInfo、IdCardInfo、BankAccountInfo 等结构体设置参数，标记 sensitive 的字段会自动加密
 // Code = 0 is success
 // 服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/apis/chapter11_1_1.shtml
 func (c *ClientV3) V3Apply4SubSubmit(ctx context.Context, bm gopay.BodyMap) (*Apply4SubSubmitRsp, error) {
 	if err := bm.CheckEmptyError("business_code", "contact_info", "subject_info", "business_info", "settlement_info", "bank_account_info"); err != nil {
 		return nil, err
 	}
+	bm, wxSerialNo, err := c.encryptSensitiveBodyMap(bm)
+	if err != nil {
+		return nil, err
+	}
 	authorization, err := c.authorization(MethodPost, v3Apply4SubSubmit, bm)
 	if err != nil {
 		return nil, err
 	}
-	res, si, bs, err := c.doProdPost(ctx, bm, v3Apply4SubSubmit, authorization)
+	res, si, bs, err := c.doProdPostWithHeader(ctx, map[string]string{HeaderSerial: wxSerialNo}, bm, v3Apply4SubSubmit, authorization)
 	if err != nil {
 		return nil, err
 	}
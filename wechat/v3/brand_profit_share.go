@@ -0,0 +1,248 @@
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/misu99/gopay"
+)
+
+// 查询连锁品牌最大分账比例API
+// Code = 0 is success
+func (c *ClientV3) V3BrandProfitShareConfig(ctx context.Context, brandMchid string) (*BrandProfitShareConfigRsp, error) {
+	url := fmt.Sprintf(v3BrandProfitShareConfig, brandMchid)
+	authorization, err := c.authorization(MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(ctx, url, authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	wxRsp := &BrandProfitShareConfigRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(BrandProfitShareConfig)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 添加连锁品牌分账接收方API
+// 注意：name 自动加密，无需调用 client.V3EncryptText()
+// Code = 0 is success
+func (c *ClientV3) V3BrandProfitShareAddReceiver(ctx context.Context, bm gopay.BodyMap) (*BrandProfitShareReceiverRsp, error) {
+	bm, wxSerialNo, err := c.encryptSensitiveBodyMap(bm, "name")
+	if err != nil {
+		return nil, err
+	}
+	authorization, err := c.authorization(MethodPost, v3BrandProfitShareAddReceiver, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPostWithHeader(ctx, map[string]string{HeaderSerial: wxSerialNo}, bm, v3BrandProfitShareAddReceiver, authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	wxRsp := &BrandProfitShareReceiverRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(BrandProfitShareReceiver)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 删除连锁品牌分账接收方API
+// Code = 0 is success
+func (c *ClientV3) V3BrandProfitShareDeleteReceiver(ctx context.Context, bm gopay.BodyMap) (*BrandProfitShareReceiverRsp, error) {
+	authorization, err := c.authorization(MethodPost, v3BrandProfitShareDeleteReceiver, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(ctx, bm, v3BrandProfitShareDeleteReceiver, authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	wxRsp := &BrandProfitShareReceiverRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(BrandProfitShareReceiver)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 请求连锁品牌分账API
+// 微信会在接到请求后立刻返回请求接收结果，分账结果需要自行调用查询接口来获取
+// Code = 0 is success
+func (c *ClientV3) V3BrandProfitShareOrder(ctx context.Context, bm gopay.BodyMap) (*BrandProfitShareOrderRsp, error) {
+	authorization, err := c.authorization(MethodPost, v3BrandProfitShareOrder, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(ctx, bm, v3BrandProfitShareOrder, authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	wxRsp := &BrandProfitShareOrderRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(BrandProfitShareOrder)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 查询连锁品牌分账结果API
+// 注意：bm 中参数为 query 参数
+// Code = 0 is success
+func (c *ClientV3) V3BrandProfitShareOrderQuery(ctx context.Context, bm gopay.BodyMap) (*BrandProfitShareOrderRsp, error) {
+	uri := v3BrandProfitShareOrder + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(ctx, uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	wxRsp := &BrandProfitShareOrderRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(BrandProfitShareOrder)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 请求连锁品牌分账回退API
+// Code = 0 is success
+func (c *ClientV3) V3BrandProfitShareReturn(ctx context.Context, bm gopay.BodyMap) (*BrandProfitShareReturnRsp, error) {
+	authorization, err := c.authorization(MethodPost, v3BrandProfitShareReturn, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(ctx, bm, v3BrandProfitShareReturn, authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	wxRsp := &BrandProfitShareReturnRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(BrandProfitShareReturn)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 查询连锁品牌分账回退结果API
+// 注意：bm 中参数为 query 参数
+// Code = 0 is success
+func (c *ClientV3) V3BrandProfitShareReturnQuery(ctx context.Context, bm gopay.BodyMap) (*BrandProfitShareReturnRsp, error) {
+	uri := v3BrandProfitShareReturn + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(ctx, uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	wxRsp := &BrandProfitShareReturnRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(BrandProfitShareReturn)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 完结连锁品牌分账API
+// 解冻剩余资金，剩余待分金额解冻给特约商户
+// Code = 0 is success
+func (c *ClientV3) V3BrandProfitShareFinish(ctx context.Context, bm gopay.BodyMap) (*BrandProfitShareFinishRsp, error) {
+	authorization, err := c.authorization(MethodPost, v3BrandProfitShareFinish, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(ctx, bm, v3BrandProfitShareFinish, authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	wxRsp := &BrandProfitShareFinishRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(BrandProfitShareFinish)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 查询连锁品牌分账剩余待分金额API
+// Code = 0 is success
+func (c *ClientV3) V3BrandProfitShareUnsplitAmount(ctx context.Context, transId string) (*ProfitShareUnsplitAmountRsp, error) {
+	url := fmt.Sprintf(v3BrandProfitShareUnsplitAmount, transId)
+	authorization, err := c.authorization(MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(ctx, url, authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	wxRsp := &ProfitShareUnsplitAmountRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(ProfitShareUnsplitAmount)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
@@ -0,0 +1,130 @@
+package wechat
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/misu99/gopay"
+)
+
+func TestV3BrandProfitShareReceiver(t *testing.T) {
+	srv, c := newAPIServer(t)
+	srv.reply(http.StatusOK, `{"brand_mchid":"1900000108","type":"PERSONAL_OPENID","account":"oUpF8uMuAJO_M2pxb1Q9zNjWeS6o"}`)
+
+	// name 自动加密，且不修改调用方的 bm
+	bm := make(gopay.BodyMap)
+	bm.Set("brand_mchid", "1900000108").
+		Set("appid", "wx8888888888888888").
+		Set("type", "PERSONAL_OPENID").
+		Set("account", "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o").
+		Set("name", "张三").
+		Set("relation_type", "STAFF")
+	wxRsp, err := c.V3BrandProfitShareAddReceiver(ctx, bm)
+	if err != nil || wxRsp.Response.Account != "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o" {
+		t.Fatalf("V3BrandProfitShareAddReceiver() = %+v, %v", wxRsp, err)
+	}
+	srv.check(t, http.MethodPost, v3BrandProfitShareAddReceiver)
+	if text, err := c.V3DecryptText(srv.req.GetString("name")); err != nil || text != "张三" {
+		t.Fatalf("V3BrandProfitShareAddReceiver() name: %s, %v", text, err)
+	}
+	if srv.header.Get(HeaderSerial) != "SN1" || bm.GetString("name") != "张三" {
+		t.Fatalf("V3BrandProfitShareAddReceiver() Wechatpay-Serial: %s, bm name: %s", srv.header.Get(HeaderSerial), bm.GetString("name"))
+	}
+
+	del := make(gopay.BodyMap)
+	del.Set("brand_mchid", "1900000108").Set("type", "PERSONAL_OPENID").Set("account", "oUpF8uMuAJO_M2pxb1Q9zNjWeS6o")
+	if wxRsp, err = c.V3BrandProfitShareDeleteReceiver(ctx, del); err != nil || wxRsp.Response.BrandMchid != "1900000108" {
+		t.Fatalf("V3BrandProfitShareDeleteReceiver() = %+v, %v", wxRsp, err)
+	}
+	srv.check(t, http.MethodPost, v3BrandProfitShareDeleteReceiver)
+
+	srv.reply(http.StatusOK, `{"brand_mchid":"1900000108","max_ratio":2000}`)
+	cfgRsp, err := c.V3BrandProfitShareConfig(ctx, "1900000108")
+	if err != nil || cfgRsp.Response.MaxRatio != 2000 {
+		t.Fatalf("V3BrandProfitShareConfig() = %+v, %v", cfgRsp, err)
+	}
+	srv.check(t, http.MethodGet, "/v3/brand/profitsharing/brand-configs/1900000108")
+
+	// 未获取平台证书时无法加密 name，不发送请求
+	c2 := newTestClientV3(t, "1900000001", srv.URL)
+	srv.method = ""
+	if _, err = c2.V3BrandProfitShareAddReceiver(ctx, bm); err == nil || srv.method != "" {
+		t.Fatalf("V3BrandProfitShareAddReceiver() without platform cert err: %v, request: %s", err, srv.method)
+	}
+}
+
+func TestV3BrandProfitShareOrder(t *testing.T) {
+	srv, c := newAPIServer(t)
+	order := `{"brand_mchid":"1900000108","sub_mchid":"1900000109","transaction_id":"4208450740201411110007820472","out_order_no":"P20150806125346","order_id":"3008450740201411110007820472","status":"FINISHED","receivers":[{"type":"MERCHANT_ID","account":"1900000109","amount":100,"description":"分给商户1900000109","result":"SUCCESS"}]}`
+	srv.reply(http.StatusOK, order)
+
+	bm := make(gopay.BodyMap)
+	bm.Set("brand_mchid", "1900000108").
+		Set("sub_mchid", "1900000109").
+		Set("transaction_id", "4208450740201411110007820472").
+		Set("out_order_no", "P20150806125346")
+	wxRsp, err := c.V3BrandProfitShareOrder(ctx, bm)
+	if err != nil || wxRsp.Response.OrderId != "3008450740201411110007820472" || len(wxRsp.Response.Receivers) != 1 {
+		t.Fatalf("V3BrandProfitShareOrder() = %+v, %v", wxRsp, err)
+	}
+	srv.check(t, http.MethodPost, v3BrandProfitShareOrder)
+
+	query := make(gopay.BodyMap)
+	query.Set("sub_mchid", "1900000109").Set("transaction_id", "4208450740201411110007820472").Set("out_order_no", "P20150806125346")
+	if wxRsp, err = c.V3BrandProfitShareOrderQuery(ctx, query); err != nil || wxRsp.Response.Status != "FINISHED" {
+		t.Fatalf("V3BrandProfitShareOrderQuery() = %+v, %v", wxRsp, err)
+	}
+	srv.check(t, http.MethodGet, v3BrandProfitShareOrder+"?out_order_no=P20150806125346&sub_mchid=1900000109&transaction_id=4208450740201411110007820472")
+
+	srv.reply(http.StatusOK, `{"sub_mchid":"1900000109","order_id":"3008450740201411110007820472","out_order_no":"P20150806125346","out_return_no":"R20190516001","return_no":"3008450740201411110007820472","return_mchid":"86693852","amount":10,"description":"用户退款","result":"SUCCESS","finish_time":"2015-05-20T13:29:35+08:00"}`)
+	ret := make(gopay.BodyMap)
+	ret.Set("sub_mchid", "1900000109").
+		Set("order_id", "3008450740201411110007820472").
+		Set("out_return_no", "R20190516001").
+		Set("return_mchid", "86693852").
+		Set("amount", 10).
+		Set("description", "用户退款")
+	retRsp, err := c.V3BrandProfitShareReturn(ctx, ret)
+	if err != nil || retRsp.Response.Result != "SUCCESS" || retRsp.Response.Amount != 10 {
+		t.Fatalf("V3BrandProfitShareReturn() = %+v, %v", retRsp, err)
+	}
+	srv.check(t, http.MethodPost, v3BrandProfitShareReturn)
+
+	retQuery := make(gopay.BodyMap)
+	retQuery.Set("sub_mchid", "1900000109").Set("out_return_no", "R20190516001").Set("out_order_no", "P20150806125346")
+	if retRsp, err = c.V3BrandProfitShareReturnQuery(ctx, retQuery); err != nil || retRsp.Response.OutReturnNo != "R20190516001" {
+		t.Fatalf("V3BrandProfitShareReturnQuery() = %+v, %v", retRsp, err)
+	}
+	srv.check(t, http.MethodGet, v3BrandProfitShareReturn+"?out_order_no=P20150806125346&out_return_no=R20190516001&sub_mchid=1900000109")
+
+	srv.reply(http.StatusOK, `{"sub_mchid":"1900000109","transaction_id":"4208450740201411110007820472","out_order_no":"P20150806125346","order_id":"3008450740201411110007820472"}`)
+	finish := make(gopay.BodyMap)
+	finish.Set("sub_mchid", "1900000109").
+		Set("transaction_id", "4208450740201411110007820472").
+		Set("out_order_no", "P20150806125346").
+		Set("description", "分账完结")
+	finishRsp, err := c.V3BrandProfitShareFinish(ctx, finish)
+	if err != nil || finishRsp.Response.OrderId != "3008450740201411110007820472" {
+		t.Fatalf("V3BrandProfitShareFinish() = %+v, %v", finishRsp, err)
+	}
+	srv.check(t, http.MethodPost, v3BrandProfitShareFinish)
+
+	srv.reply(http.StatusOK, `{"transaction_id":"4208450740201411110007820472","unsplit_amount":1000}`)
+	amountRsp, err := c.V3BrandProfitShareUnsplitAmount(ctx, "4208450740201411110007820472")
+	if err != nil || amountRsp.Response.UnsplitAmount != 1000 {
+		t.Fatalf("V3BrandProfitShareUnsplitAmount() = %+v, %v", amountRsp, err)
+	}
+	srv.check(t, http.MethodGet, "/v3/brand/profitsharing/orders/4208450740201411110007820472/amounts")
+
+	// 业务错误、应答验签失败
+	srv.reply(http.StatusBadRequest, `{"code":"PARAM_ERROR","message":"订单不存在"}`)
+	if wxRsp, err = c.V3BrandProfitShareOrder(ctx, bm); err != nil || wxRsp.Code != http.StatusBadRequest || wxRsp.Error == "" {
+		t.Fatalf("V3BrandProfitShareOrder() = %+v, %v", wxRsp, err)
+	}
+	srv.reply(http.StatusOK, order)
+	srv.sign = testSignInfo(t, c, "SN1").HeaderSignature
+	if _, err = c.V3BrandProfitShareOrder(ctx, bm); !errors.Is(err, gopay.VerifySignatureErr) {
+		t.Fatalf("V3BrandProfitShareOrder() with bad sign err: %v", err)
+	}
+}
@@ -2,23 +2,18 @@ package wechat
 
 import (
 	"context"
-	"crypto"
-	"crypto/rand"
 	"crypto/rsa"
-	"crypto/sha256"
-	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"runtime"
 	"sort"
 	"sync"
 	"time"
 
 	"github.com/misu99/gopay"
-	"github.com/misu99/gopay/pkg/aes"
 	"github.com/misu99/gopay/pkg/errgroup"
-	"github.com/misu99/gopay/pkg/retry"
+	"github.com/misu99/gopay/pkg/sm2"
 	"github.com/misu99/gopay/pkg/util"
 	"github.com/misu99/gopay/pkg/xhttp"
 	"github.com/misu99/gopay/pkg/xlog"
@@ -37,7 +32,6 @@ func GetPlatformCerts(ctx context.Context, mchid, apiV3Key, serialNo, privateKey
 	var (
 		eg  = new(errgroup.Group)
 		mu  sync.Mutex
-		jb  = ""
 		uri = v3GetCerts
 	)
 	if len(certType) > 1 {
@@ -46,26 +40,18 @@ func GetPlatformCerts(ctx context.Context, mchid, apiV3Key, serialNo, privateKey
 	if len(certType) == 1 {
 		uri += "?algorithm_type=" + string(certType[0])
 	}
-	// Prepare
-	priKey, err := xpem.DecodePrivateKey([]byte(privateKey))
+	// Prepare：商户私钥支持 RSA 和 国密SM2
+	c, err := NewClientV3(mchid, serialNo, apiV3Key, privateKey)
 	if err != nil {
-		return nil, err
+		if c, err = NewClientV3SM2(mchid, serialNo, apiV3Key, privateKey); err != nil {
+			return nil, err
+		}
 	}
-
-	timestamp := time.Now().Unix()
-	nonceStr := util.RandomString(32)
-	ts := util.Int642String(timestamp)
-	_str := MethodGet + "\n" + uri + "\n" + ts + "\n" + nonceStr + "\n" + jb + "\n"
-	// Sign
-	h := sha256.New()
-	h.Write([]byte(_str))
-	result, err := rsa.SignPKCS1v15(rand.Reader, priKey, crypto.SHA256, h.Sum(nil))
+	// Authorization
+	authorization, err := c.authorization(MethodGet, uri, nil)
 	if err != nil {
-		return nil, fmt.Errorf("[%w]: %+v", gopay.SignatureErr, err)
+		return nil, err
 	}
-	sign := base64.StdEncoding.EncodeToString(result)
-	// Authorization
-	authorization := Authorization + ` mchid="` + mchid + `",nonce_str="` + nonceStr + `",timestamp="` + ts + `",serial_no="` + serialNo + `",signature="` + sign + `"`
 	// Request
 	var url = v3BaseUrlCh + uri
 	httpClient := xhttp.NewClient()
@@ -93,15 +79,14 @@ func GetPlatformCerts(ctx context.Context, mchid, apiV3Key, serialNo, privateKey
 		if cert.EncryptCertificate != nil {
 			ec := cert.EncryptCertificate
 			eg.Go(func(ctx context.Context) error {
-				cipherBytes, _ := base64.StdEncoding.DecodeString(ec.Ciphertext)
-				pubKeyBytes, err := aes.GCMDecrypt(cipherBytes, []byte(ec.Nonce), []byte(ec.AssociatedData), []byte(apiV3Key))
+				pubKey, err := c.decryptCerts(ec.Algorithm, ec.Ciphertext, ec.Nonce, ec.AssociatedData)
 				if err != nil {
-					return fmt.Errorf("aes.GCMDecrypt, err:%+v", err)
+					return err
 				}
 				pci := &PlatformCertItem{
 					EffectiveTime: cert.EffectiveTime,
 					ExpireTime:    cert.ExpireTime,
-					PublicKey:     string(pubKeyBytes),
+					PublicKey:     pubKey,
 					SerialNo:      cert.SerialNo,
 				}
 				mu.Lock()
@@ -131,7 +116,19 @@ func GetPlatformSM2Certs(ctx context.Context, mchid, apiV3Key, serialNo, private
 // 注意1：如已开启自动验签功能 client.AutoVerifySign()，无需再调用此方法设置
 // 注意2：请预先通过 wechat.GetPlatformCerts() 获取 微信平台公钥证书 和 证书序列号
 // 部分接口请求参数中敏感信息加密，使用此 微信支付平台公钥 和 证书序列号
+// 注意3：国密模式下，请设置 SM2 国密平台证书
 func (c *ClientV3) SetPlatformCert(wxPublicKeyContent []byte, wxSerialNo string) (client *ClientV3) {
+	if c.signType == SignTypeSM2 {
+		pubKey, err := xpem.DecodeSM2PublicKey(wxPublicKeyContent)
+		if err != nil {
+			xlog.Errorf("SetPlatformCert(%s),err:%+v", wxPublicKeyContent, err)
+		}
+		if pubKey != nil {
+			c.wxSM2PublicKey = pubKey
+		}
+		c.WxSerialNo = wxSerialNo
+		return c
+	}
 	pubKey, err := xpem.DecodePublicKey(wxPublicKeyContent)
 	if err != nil {
 		xlog.Errorf("SetPlatformCert(%s),err:%+v", wxPublicKeyContent, err)
@@ -149,22 +146,83 @@ func (c *ClientV3) WxPublicKey() (wxPublicKey *rsa.PublicKey) {
 }
 
 // 获取 微信平台证书 Map（readonly）
+// 已配置微信支付公钥时，Map 中同时包含 key 为 微信支付公钥ID 的公钥
 // wxPublicKeyMap: key:serialNo, value:WxPublicKey
 func (c *ClientV3) WxPublicKeyMap() (wxPublicKeyMap map[string]*rsa.PublicKey) {
-	wxPublicKeyMap = make(map[string]*rsa.PublicKey, len(c.SnCertMap))
+	c.rwMu.RLock()
+	defer c.rwMu.RUnlock()
+	wxPublicKeyMap = make(map[string]*rsa.PublicKey, len(c.SnCertMap)+1)
 	for k, v := range c.SnCertMap {
 		wxPublicKeyMap[k] = v
 	}
+	if c.wxPayPublicKey != nil {
+		wxPublicKeyMap[c.wxPublicKeyID] = c.wxPayPublicKey
+	}
+	return wxPublicKeyMap
+}
+
+// 获取 微信支付公钥ID（未配置微信支付公钥时为空）
+func (c *ClientV3) WxPublicKeyID() (wxPublicKeyID string) {
+	c.rwMu.RLock()
+	defer c.rwMu.RUnlock()
+	return c.wxPublicKeyID
+}
+
+// 设置 微信支付公钥 和 微信支付公钥ID
+func (c *ClientV3) setWxPayPublicKey(wxPublicKeyContent []byte, wxPublicKeyID string) error {
+	if c.signType == SignTypeSM2 {
+		return errors.New("wechat pay public key mode does not support SM2 client")
+	}
+	if wxPublicKeyID == gopay.NULL {
+		return errors.New("wxPublicKeyID can't be empty")
+	}
+	pubKey, err := xpem.DecodePublicKey(wxPublicKeyContent)
+	if err != nil {
+		return err
+	}
+	if pubKey == nil {
+		return fmt.Errorf("wechat pay public key decode error: %s", wxPublicKeyContent)
+	}
+	c.rwMu.Lock()
+	c.wxPublicKeyID = wxPublicKeyID
+	c.wxPayPublicKey = pubKey
+	c.WxSerialNo = wxPublicKeyID
+	c.wxPublicKey = pubKey
+	c.rwMu.Unlock()
+	return nil
+}
+
+// 获取最新的 微信国密平台证书（国密模式）
+func (c *ClientV3) WxSM2PublicKey() (wxPublicKey *sm2.PublicKey) {
+	c.rwMu.RLock()
+	defer c.rwMu.RUnlock()
+	return c.wxSM2PublicKey
+}
+
+// 获取 微信国密平台证书 Map（readonly，国密模式）
+// wxPublicKeyMap: key:serialNo, value:WxSM2PublicKey
+func (c *ClientV3) WxSM2PublicKeyMap() (wxPublicKeyMap map[string]*sm2.PublicKey) {
+	c.rwMu.RLock()
+	defer c.rwMu.RUnlock()
+	wxPublicKeyMap = make(map[string]*sm2.PublicKey, len(c.SnSM2CertMap))
+	for k, v := range c.SnSM2CertMap {
+		wxPublicKeyMap[k] = v
+	}
 	return wxPublicKeyMap
 }
 
 // 获取证书Map集并选择最新的有效证书序列号（默认RSA证书）
 // 文档说明：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/wechatpay5_1.shtml
 func (c *ClientV3) GetAndSelectNewestCert(certType ...CertType) (serialNo string, snCertMap map[string]string, err error) {
-	certs, err := c.getPlatformCerts(certType...)
+	certs, err := c.getPlatformCerts(c.ctx, certType...)
 	if err != nil {
 		return gopay.NULL, nil, err
 	}
+	return selectNewestCert(certs)
+}
+
+// 从平台证书列表中选择最新的有效证书序列号，并返回有效证书Map集
+func selectNewestCert(certs *PlatformCertRsp) (serialNo string, snCertMap map[string]string, err error) {
 	if certs.Code == Success && len(certs.Certs) > 0 {
 		snCertMap = make(map[string]string)
 		// only one
@@ -240,7 +298,7 @@ func (c *ClientV3) GetAndSelectNewestCertALL() (serialNo string, snCertMap map[s
 //   - 加密请求消息中的敏感信息时，使用最新的平台证书（即：证书启用时间较晚的证书）
 //
 // 文档说明：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/wechatpay5_1.shtml
-func (c *ClientV3) getPlatformCerts(certType ...CertType) (certs *PlatformCertRsp, err error) {
+func (c *ClientV3) getPlatformCerts(ctx context.Context, certType ...CertType) (certs *PlatformCertRsp, err error) {
 	var (
 		eg  = new(errgroup.Group)
 		mu  sync.Mutex
@@ -256,7 +314,7 @@ func (c *ClientV3) getPlatformCerts(certType ...CertType) (certs *PlatformCertRs
 	if err != nil {
 		return nil, err
 	}
-	res, _, bs, err := c.doProdGet(c.ctx, uri, authorization)
+	res, _, bs, err := c.doProdGet(ctx, uri, authorization)
 	if err != nil {
 		return nil, err
 	}
@@ -275,7 +333,7 @@ func (c *ClientV3) getPlatformCerts(certType ...CertType) (certs *PlatformCertRs
 		if cert.EncryptCertificate != nil {
 			ec := cert.EncryptCertificate
 			eg.Go(func(ctx context.Context) error {
-				pubKey, err := c.decryptCerts(ec.Ciphertext, ec.Nonce, ec.AssociatedData)
+				pubKey, err := c.decryptCerts(ec.Algorithm, ec.Ciphertext, ec.Nonce, ec.AssociatedData)
 				if err != nil {
 					return err
 				}
@@ -299,51 +357,65 @@ func (c *ClientV3) getPlatformCerts(certType ...CertType) (certs *PlatformCertRs
 }
 
 // 解密加密的证书
-func (c *ClientV3) decryptCerts(ciphertext, nonce, additional string) (wxCerts string, err error) {
-	cipherBytes, _ := base64.StdEncoding.DecodeString(ciphertext)
-	decrypt, err := aes.GCMDecrypt(cipherBytes, []byte(nonce), []byte(additional), c.ApiV3Key)
+func (c *ClientV3) decryptCerts(algorithm, ciphertext, nonce, additional string) (wxCerts string, err error) {
+	decrypt, err := v3DecryptResource(algorithm, ciphertext, nonce, additional, c.ApiV3Key)
 	if err != nil {
-		return "", fmt.Errorf("aes.GCMDecrypt, err:%w", err)
+		return "", err
 	}
 	return string(decrypt), nil
 }
 
-func (c *ClientV3) autoCheckCertProc() {
-	xlog.Info("auto refresh wechat platform public key")
-	defer func() {
-		if r := recover(); r != nil {
-			buf := make([]byte, 64<<10)
-			buf = buf[:runtime.Stack(buf, false)]
-			xlog.Errorf("autoCheckCertProc: panic recovered: %s\n%s", r, buf)
-			// 重启
-			c.autoCheckCertProc()
-		}
-	}()
-	for {
-		time.Sleep(time.Hour * 12)
-		err := retry.Retry(func() error {
-			serialNo, snCertMap, err := c.GetAndSelectNewestCert()
+// 根据客户端签名类型确定获取的平台证书类型，国密模式下获取 SM2 平台证书
+func (c *ClientV3) certTypes() []CertType {
+	if c.signType == SignTypeSM2 {
+		return []CertType{CertTypeSM2}
+	}
+	return nil
+}
+
+// 解析并设置平台证书，reset 为 true 时替换已有的证书 Map
+func (c *ClientV3) setPlatformCerts(serialNo string, snCertMap map[string]string, reset bool) error {
+	if c.signType == SignTypeSM2 {
+		snPkMap := make(map[string]*sm2.PublicKey, len(snCertMap))
+		for sn, cert := range snCertMap {
+			pubKey, err := xpem.DecodeSM2PublicKey([]byte(cert))
 			if err != nil {
 				return err
 			}
-			snPkMap := make(map[string]*rsa.PublicKey)
-			for sn, cert := range snCertMap {
-				pubKey, err := xpem.DecodePublicKey([]byte(cert))
-				if err != nil {
-					return err
-				}
-				snPkMap[sn] = pubKey
-			}
-			c.rwMu.Lock()
-			c.SnCertMap = snPkMap
-			c.WxSerialNo = serialNo
-			c.wxPublicKey = snPkMap[serialNo]
-			c.rwMu.Unlock()
-			return nil
-		}, 3, time.Second)
+			snPkMap[sn] = pubKey
+		}
+		c.rwMu.Lock()
+		if reset || c.SnSM2CertMap == nil {
+			c.SnSM2CertMap = make(map[string]*sm2.PublicKey, len(snPkMap))
+		}
+		for sn, pk := range snPkMap {
+			c.SnSM2CertMap[sn] = pk
+		}
+		c.WxSerialNo = serialNo
+		c.wxSM2PublicKey = c.SnSM2CertMap[serialNo]
+		c.rwMu.Unlock()
+		return nil
+	}
+	snPkMap := make(map[string]*rsa.PublicKey, len(snCertMap))
+	for sn, cert := range snCertMap {
+		pubKey, err := xpem.DecodePublicKey([]byte(cert))
 		if err != nil {
-			xlog.Errorf("c.GetAndSelectNewestCert()，err:%+v", err)
-			continue
+			return err
 		}
+		snPkMap[sn] = pubKey
+	}
+	c.rwMu.Lock()
+	if reset || c.SnCertMap == nil {
+		c.SnCertMap = make(map[string]*rsa.PublicKey, len(snPkMap))
+	}
+	for sn, pk := range snPkMap {
+		c.SnCertMap[sn] = pk
+	}
+	// 已配置微信支付公钥时，Wechatpay-Serial 及敏感信息加密继续使用 微信支付公钥
+	if c.wxPayPublicKey == nil {
+		c.WxSerialNo = serialNo
+		c.wxPublicKey = c.SnCertMap[serialNo]
 	}
+	c.rwMu.Unlock()
+	return nil
 }
@@ -0,0 +1,331 @@
+package wechat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/misu99/gopay/pkg/xlog"
+	"github.com/misu99/gopay/pkg/xtime"
+)
+
+const (
+	defaultCertRefreshInterval  = time.Hour * 12      // 默认证书轮询间隔
+	defaultCertExpireAhead      = time.Hour * 24 * 30 // 默认证书即将过期提醒提前量
+	defaultCertOnDemandInterval = time.Minute         // 未知序列号触发刷新的最小间隔
+)
+
+// CertManager 微信平台证书管理器
+// 负责定时轮询、按需刷新平台证书，并将证书同步到所有共享该管理器的 client
+// 同一商户的多个 client 可通过 AddClient() 共享同一个管理器，只需下载一次证书
+type CertManager struct {
+	client           *ClientV3 // 用于请求平台证书的 client
+	interval         time.Duration
+	expireAhead      time.Duration
+	onDemandInterval time.Duration
+
+	onRefreshSuccess func(serialNo string, snCertMap map[string]string)
+	onRefreshFailure func(err error)
+	onCertExpiring   func(serialNo string, expireTime time.Time)
+
+	mu           sync.RWMutex
+	clients      []*ClientV3
+	serialNo     string
+	snCertMap    map[string]string
+	expireTimes  map[string]time.Time
+	stats        CertManagerStats
+	lastOnDemand time.Time
+
+	refreshMu sync.Mutex
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// CertManagerStats 平台证书管理器运行统计
+type CertManagerStats struct {
+	RefreshSuccess  int64                // 刷新成功次数
+	RefreshFailure  int64                // 刷新失败次数
+	LastRefreshTime time.Time            // 最近一次刷新成功时间
+	LastError       error                // 最近一次刷新失败的错误，刷新成功后清空
+	SerialNo        string               // 当前最新证书序列号
+	ExpireTimes     map[string]time.Time // 证书序列号 -> 过期时间
+}
+
+// NewCertManager 初始化平台证书管理器
+// client：用于请求平台证书的 client，同时绑定到该管理器
+func NewCertManager(client *ClientV3) (m *CertManager) {
+	m = &CertManager{
+		client:           client,
+		interval:         defaultCertRefreshInterval,
+		expireAhead:      defaultCertExpireAhead,
+		onDemandInterval: defaultCertOnDemandInterval,
+	}
+	m.AddClient(client)
+	return m
+}
+
+// SetInterval 设置证书轮询间隔，默认 12 小时
+func (m *CertManager) SetInterval(interval time.Duration) *CertManager {
+	if interval > 0 {
+		m.interval = interval
+	}
+	return m
+}
+
+// SetExpireAhead 设置证书即将过期提醒的提前量，默认 30 天
+func (m *CertManager) SetExpireAhead(ahead time.Duration) *CertManager {
+	if ahead > 0 {
+		m.expireAhead = ahead
+	}
+	return m
+}
+
+// SetOnDemandInterval 设置遇到未知 Wechatpay-Serial 时触发刷新的最小间隔，默认 1 分钟
+func (m *CertManager) SetOnDemandInterval(interval time.Duration) *CertManager {
+	if interval >= 0 {
+		m.onDemandInterval = interval
+	}
+	return m
+}
+
+// OnRefreshSuccess 设置证书刷新成功回调
+func (m *CertManager) OnRefreshSuccess(fn func(serialNo string, snCertMap map[string]string)) *CertManager {
+	m.onRefreshSuccess = fn
+	return m
+}
+
+// OnRefreshFailure 设置证书刷新失败回调
+func (m *CertManager) OnRefreshFailure(fn func(err error)) *CertManager {
+	m.onRefreshFailure = fn
+	return m
+}
+
+// OnCertExpiring 设置证书即将过期回调，每次刷新时对过期时间在提前量内的证书触发
+func (m *CertManager) OnCertExpiring(fn func(serialNo string, expireTime time.Time)) *CertManager {
+	m.onCertExpiring = fn
+	return m
+}
+
+// AddClient 添加共享该管理器的 client（同一商户的多个 client）
+// 已获取到证书时，立即同步到新添加的 client
+func (m *CertManager) AddClient(clients ...*ClientV3) *CertManager {
+	m.mu.Lock()
+	serialNo, snCertMap, started := m.serialNo, m.snCertMap, m.cancel != nil
+	for _, c := range clients {
+		c.setCertManager(m)
+		m.clients = append(m.clients, c)
+	}
+	m.mu.Unlock()
+	if len(snCertMap) == 0 {
+		return m
+	}
+	for _, c := range clients {
+		if err := c.setPlatformCerts(serialNo, snCertMap, true); err != nil {
+			xlog.Errorf("CertManager.AddClient(%s), err:%+v", c.Mchid, err)
+			continue
+		}
+		if started {
+			c.setAutoSign(true)
+		}
+	}
+	return m
+}
+
+// Start 立即获取一次平台证书，开启所有 client 的自动验签，并按轮询间隔定时刷新
+// ctx 取消或调用 Stop() 后停止轮询
+func (m *CertManager) Start(ctx context.Context) (err error) {
+	m.mu.Lock()
+	if m.cancel != nil {
+		m.mu.Unlock()
+		return errors.New("cert manager already started")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	m.cancel = cancel
+	m.done = make(chan struct{})
+	m.mu.Unlock()
+
+	if err = m.Refresh(ctx); err != nil {
+		cancel()
+		m.mu.Lock()
+		m.cancel, m.done = nil, nil
+		m.mu.Unlock()
+		return err
+	}
+	m.mu.RLock()
+	for _, c := range m.clients {
+		c.setAutoSign(true)
+	}
+	m.mu.RUnlock()
+	go m.loop(ctx, m.done)
+	return nil
+}
+
+// Stop 停止定时刷新，并等待轮询协程退出
+func (m *CertManager) Stop() {
+	m.mu.Lock()
+	cancel, done := m.cancel, m.done
+	m.cancel, m.done = nil, nil
+	m.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// Refresh 立即刷新平台证书，并同步到所有 client
+func (m *CertManager) Refresh(ctx context.Context) (err error) {
+	m.refreshMu.Lock()
+	defer m.refreshMu.Unlock()
+	return m.refresh(ctx)
+}
+
+// Stats 获取管理器运行统计
+func (m *CertManager) Stats() (stats CertManagerStats) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	stats = m.stats
+	stats.SerialNo = m.serialNo
+	stats.ExpireTimes = make(map[string]time.Time, len(m.expireTimes))
+	for k, v := range m.expireTimes {
+		stats.ExpireTimes[k] = v
+	}
+	return stats
+}
+
+func (m *CertManager) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.tick(ctx)
+		}
+	}
+}
+
+func (m *CertManager) tick(ctx context.Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			buf := make([]byte, 64<<10)
+			buf = buf[:runtime.Stack(buf, false)]
+			xlog.Errorf("CertManager: panic recovered: %s\n%s", r, buf)
+		}
+	}()
+	var err error
+	for i := 0; i < 3; i++ {
+		if err = m.Refresh(ctx); err == nil {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+	xlog.Errorf("CertManager.Refresh(), err:%+v", err)
+}
+
+// 遇到未知的 Wechatpay-Serial 时按需刷新，限制最小刷新间隔，避免伪造的序列号频繁触发请求
+func (m *CertManager) refreshForSerial(ctx context.Context, serialNo string) (err error) {
+	m.refreshMu.Lock()
+	defer m.refreshMu.Unlock()
+	m.mu.Lock()
+	if _, ok := m.snCertMap[serialNo]; ok {
+		m.mu.Unlock()
+		return nil
+	}
+	if !m.lastOnDemand.IsZero() && time.Since(m.lastOnDemand) < m.onDemandInterval {
+		m.mu.Unlock()
+		return fmt.Errorf("unknown Wechatpay-Serial [%s], refresh too frequently", serialNo)
+	}
+	m.lastOnDemand = time.Now()
+	m.mu.Unlock()
+	return m.refresh(ctx)
+}
+
+func (m *CertManager) refresh(ctx context.Context) (err error) {
+	serialNo, snCertMap, expireTimes, err := m.fetch(ctx)
+	if err != nil {
+		m.refreshFailed(err)
+		return err
+	}
+	m.mu.Lock()
+	m.serialNo = serialNo
+	m.snCertMap = snCertMap
+	m.expireTimes = expireTimes
+	clients := make([]*ClientV3, len(m.clients))
+	copy(clients, m.clients)
+	m.mu.Unlock()
+
+	// 单个 client 同步失败不影响其他 client，全部同步完成后再统计结果
+	var errs []string
+	for _, c := range clients {
+		if e := c.setPlatformCerts(serialNo, snCertMap, true); e != nil {
+			errs = append(errs, fmt.Sprintf("client(%s): %v", c.Mchid, e))
+		}
+	}
+	if m.onCertExpiring != nil {
+		for sn, expireTime := range expireTimes {
+			if time.Until(expireTime) < m.expireAhead {
+				m.onCertExpiring(sn, expireTime)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		err = fmt.Errorf("set platform certs failed: %s", strings.Join(errs, "; "))
+		m.refreshFailed(err)
+		return err
+	}
+	m.mu.Lock()
+	m.stats.RefreshSuccess++
+	m.stats.LastRefreshTime = time.Now()
+	m.stats.LastError = nil
+	m.mu.Unlock()
+	if m.onRefreshSuccess != nil {
+		m.onRefreshSuccess(serialNo, snCertMap)
+	}
+	return nil
+}
+
+// 记录刷新失败，并触发失败回调
+func (m *CertManager) refreshFailed(err error) {
+	m.mu.Lock()
+	m.stats.RefreshFailure++
+	m.stats.LastError = err
+	m.mu.Unlock()
+	if m.onRefreshFailure != nil {
+		m.onRefreshFailure(err)
+	}
+}
+
+func (m *CertManager) fetch(ctx context.Context) (serialNo string, snCertMap map[string]string, expireTimes map[string]time.Time, err error) {
+	certs, err := m.client.getPlatformCerts(ctx, m.client.certTypes()...)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	serialNo, snCertMap, err = selectNewestCert(certs)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	expireTimes = make(map[string]time.Time, len(snCertMap))
+	for _, v := range certs.Certs {
+		if _, ok := snCertMap[v.SerialNo]; !ok {
+			continue
+		}
+		formatExpire := xtime.FormatDateTime(v.ExpireTime)
+		expireTime, err := time.ParseInLocation(xtime.TimeLayout, formatExpire, time.Local)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("time.ParseInLocation(%s, %s),err:%w", xtime.TimeLayout, formatExpire, err)
+		}
+		expireTimes[v.SerialNo] = expireTime
+	}
+	return serialNo, snCertMap, expireTimes, nil
+}
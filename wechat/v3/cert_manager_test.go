@@ -0,0 +1,317 @@
+package wechat
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/misu99/gopay"
+)
+
+const testAPIv3Key = "0123456789abcdef0123456789abcdef"
+
+// certServer 模拟微信平台证书下载接口，返回 APIv3Key 加密后的平台证书
+type certServer struct {
+	*httptest.Server
+	requests int32
+
+	mu       sync.Mutex
+	fail     bool
+	serialNo []string
+}
+
+func newCertServer(t *testing.T, serialNo ...string) *certServer {
+	t.Helper()
+	s := &certServer{serialNo: serialNo}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.requests, 1)
+		s.mu.Lock()
+		fail, sns := s.fail, s.serialNo
+		s.mu.Unlock()
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"code":"SYSTEM_ERROR","message":"系统错误"}`))
+			return
+		}
+		block, _ := aes.NewCipher([]byte(testAPIv3Key))
+		gcm, _ := cipher.NewGCM(block)
+		rsp := &PlatformCert{}
+		for i, sn := range sns {
+			ciphertext := gcm.Seal(nil, []byte("nonce1234567"), []byte(publicPKCS1), []byte("certificate"))
+			rsp.Data = append(rsp.Data, &CertData{
+				SerialNo:      sn,
+				EffectiveTime: time.Now().Add(-time.Hour * time.Duration(24*(len(sns)-i))).Format(time.RFC3339),
+				ExpireTime:    time.Now().Add(time.Hour * 24 * 10).Format(time.RFC3339),
+				EncryptCertificate: &EncryptCert{
+					Algorithm:      AlgorithmAES256GCM,
+					AssociatedData: "certificate",
+					Ciphertext:     base64.StdEncoding.EncodeToString(ciphertext),
+					Nonce:          "nonce1234567",
+				},
+			})
+		}
+		bs, _ := json.Marshal(rsp)
+		_, _ = w.Write(bs)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *certServer) set(fail bool, serialNo ...string) {
+	s.mu.Lock()
+	s.fail = fail
+	if len(serialNo) > 0 {
+		s.serialNo = serialNo
+	}
+	s.mu.Unlock()
+}
+
+func (s *certServer) count() int32 {
+	return atomic.LoadInt32(&s.requests)
+}
+
+func newTestClientV3(t *testing.T, mchid, baseUrl string) *ClientV3 {
+	t.Helper()
+	c, err := NewClientV3(mchid, "MCH_SERIAL", testAPIv3Key, privatePKCS1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.baseUrl = baseUrl
+	return c
+}
+
+// 使用与 publicPKCS1 对应的私钥模拟微信签名
+func testSignInfo(t *testing.T, c *ClientV3, serialNo string) *SignInfo {
+	t.Helper()
+	si := &SignInfo{HeaderTimestamp: "1554208460", HeaderNonce: "593BEC0C930BF1AFEB40B4A08C8FB242", HeaderSerial: serialNo, SignBody: `{"code":"SUCCESS"}`}
+	sign, err := c.rsaSign(si.HeaderTimestamp + "\n" + si.HeaderNonce + "\n" + si.SignBody + "\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	si.HeaderSignature = sign
+	return si
+}
+
+// apiServer 模拟微信v3业务接口：记录最近一次请求，按预设的状态码和 body 应答，
+// 并使用与 publicPKCS1 对应的私钥以平台证书 SN1 签名应答
+type apiServer struct {
+	*httptest.Server
+	status int
+	body   string
+	sign   string // 非空时使用该签名替换应答签名
+
+	method string
+	uri    string
+	header http.Header
+	raw    []byte
+	req    gopay.BodyMap
+}
+
+// newAPIServer 返回模拟接口及已设置平台证书 SN1、开启自动验签的 client
+func newAPIServer(t *testing.T) (*apiServer, *ClientV3) {
+	t.Helper()
+	s := &apiServer{status: http.StatusOK}
+	signer := newTestClientV3(t, "1900000001", "")
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.method, s.uri, s.header = r.Method, r.URL.RequestURI(), r.Header
+		s.raw, _ = io.ReadAll(r.Body)
+		s.req = make(gopay.BodyMap)
+		_ = json.Unmarshal(s.raw, &s.req)
+
+		ts, nonce := "1554208460", "593BEC0C930BF1AFEB40B4A08C8FB242"
+		sign, _ := signer.rsaSign(ts + "\n" + nonce + "\n" + s.body + "\n")
+		if s.sign != "" {
+			sign = s.sign
+		}
+		w.Header().Set(HeaderTimestamp, ts)
+		w.Header().Set(HeaderNonce, nonce)
+		w.Header().Set(HeaderSerial, "SN1")
+		w.Header().Set(HeaderSignature, sign)
+		w.WriteHeader(s.status)
+		_, _ = w.Write([]byte(s.body))
+	}))
+	t.Cleanup(s.Close)
+
+	c := newTestClientV3(t, "1900000001", s.URL)
+	if err := c.setPlatformCerts("SN1", map[string]string{"SN1": publicPKCS1}, true); err != nil {
+		t.Fatal(err)
+	}
+	c.setAutoSign(true)
+	return s, c
+}
+
+// reply 设置应答状态码和 body，并恢复正常签名
+func (s *apiServer) reply(status int, body string) {
+	s.status, s.body, s.sign = status, body, ""
+}
+
+// check 校验最近一次请求的方法和 URI
+func (s *apiServer) check(t *testing.T, method, uri string) {
+	t.Helper()
+	if s.method != method || s.uri != uri {
+		t.Fatalf("request %s %s, want %s %s", s.method, s.uri, method, uri)
+	}
+}
+
+func TestCertManagerLifecycle(t *testing.T) {
+	srv := newCertServer(t, "SN1")
+	c1 := newTestClientV3(t, "1900000001", srv.URL)
+	c2 := newTestClientV3(t, "1900000002", srv.URL)
+
+	var success, expiring int32
+	m := NewCertManager(c1).AddClient(c2).
+		SetInterval(20 * time.Millisecond).
+		OnRefreshSuccess(func(serialNo string, snCertMap map[string]string) { atomic.AddInt32(&success, 1) }).
+		OnRefreshFailure(func(err error) { t.Errorf("OnRefreshFailure: %v", err) }).
+		OnCertExpiring(func(serialNo string, expireTime time.Time) { atomic.AddInt32(&expiring, 1) })
+	if c2.CertManager() != m {
+		t.Fatal("AddClient() should bind manager to client")
+	}
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Start(context.Background()); err == nil {
+		t.Fatal("Start() twice should return error")
+	}
+	for _, c := range []*ClientV3{c1, c2} {
+		if _, ok := c.WxPublicKeyMap()["SN1"]; !ok || !c.isAutoSign() {
+			t.Fatalf("client(%s) autoSign: %v, WxPublicKeyMap: %v", c.Mchid, c.isAutoSign(), c.WxPublicKeyMap())
+		}
+		if err := c.verifySyncSign(testSignInfo(t, c, "SN1")); err != nil {
+			t.Fatalf("client(%s) verifySyncSign() err: %v", c.Mchid, err)
+		}
+	}
+	// 启动后添加的 client 立即同步证书并开启自动验签
+	c3 := newTestClientV3(t, "1900000003", srv.URL)
+	m.AddClient(c3)
+	if _, ok := c3.WxPublicKeyMap()["SN1"]; !ok || !c3.isAutoSign() {
+		t.Fatalf("client added after Start() not synced, WxPublicKeyMap: %v", c3.WxPublicKeyMap())
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for m.Stats().RefreshSuccess < 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	m.Stop()
+	m.Stop()
+	stats := m.Stats()
+	if stats.RefreshSuccess < 3 || stats.RefreshFailure != 0 || stats.LastError != nil || stats.SerialNo != "SN1" || stats.ExpireTimes["SN1"].IsZero() {
+		t.Fatalf("Stats() = %+v", stats)
+	}
+	if atomic.LoadInt32(&success) != int32(stats.RefreshSuccess) || atomic.LoadInt32(&expiring) != int32(stats.RefreshSuccess) {
+		t.Fatalf("callbacks success: %d, expiring: %d, stats: %+v", success, expiring, stats)
+	}
+	// Stop() 后不再轮询
+	n := srv.count()
+	time.Sleep(60 * time.Millisecond)
+	if srv.count() != n {
+		t.Fatalf("refresh after Stop(), requests: %d -> %d", n, srv.count())
+	}
+}
+
+func TestCertManagerRefreshFailure(t *testing.T) {
+	srv := newCertServer(t, "SN1")
+	c1 := newTestClientV3(t, "1900000001", srv.URL)
+	// 国密 client 无法解析 RSA 平台证书，同步失败
+	bad := &ClientV3{Mchid: "1900000002", signType: SignTypeSM2}
+	c3 := newTestClientV3(t, "1900000003", srv.URL)
+
+	var success int32
+	var failures []error
+	m := NewCertManager(c1).AddClient(bad, c3).
+		OnRefreshSuccess(func(serialNo string, snCertMap map[string]string) { atomic.AddInt32(&success, 1) }).
+		OnRefreshFailure(func(err error) { failures = append(failures, err) })
+
+	err := m.Refresh(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "client(1900000002)") {
+		t.Fatalf("Refresh() err: %v", err)
+	}
+	// 失败的 client 不影响其后的 client
+	if c1.WxSerialNo != "SN1" || c3.WxSerialNo != "SN1" {
+		t.Fatalf("clients not synced, c1: %s, c3: %s", c1.WxSerialNo, c3.WxSerialNo)
+	}
+	stats := m.Stats()
+	if stats.RefreshSuccess != 0 || stats.RefreshFailure != 1 || stats.LastError != err || len(failures) != 1 || success != 0 {
+		t.Fatalf("Stats() = %+v, failures: %v, success: %d", stats, failures, success)
+	}
+
+	// 下载失败
+	m = NewCertManager(c1).OnRefreshFailure(func(err error) { failures = append(failures, err) })
+	srv.set(true)
+	if err = m.Refresh(context.Background()); err == nil {
+		t.Fatal("Refresh() should return error")
+	}
+	if stats = m.Stats(); stats.RefreshFailure != 1 || stats.LastError == nil || len(failures) != 2 {
+		t.Fatalf("Stats() = %+v, failures: %v", stats, failures)
+	}
+	// 再次成功后清空 LastError
+	srv.set(false)
+	if err = m.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if stats = m.Stats(); stats.RefreshSuccess != 1 || stats.RefreshFailure != 1 || stats.LastError != nil {
+		t.Fatalf("Stats() = %+v", stats)
+	}
+}
+
+func TestCertManagerOnDemand(t *testing.T) {
+	srv := newCertServer(t, "SN1")
+	c := newTestClientV3(t, "1900000001", srv.URL)
+	m := NewCertManager(c).SetOnDemandInterval(time.Hour)
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Stop()
+
+	// 平台证书轮换，未知序列号立即刷新
+	srv.set(false, "SN1", "SN2")
+	if err := c.verifySyncSign(testSignInfo(t, c, "SN2")); err != nil {
+		t.Fatalf("verifySyncSign(SN2) err: %v", err)
+	}
+	if srv.count() != 2 || c.WxSerialNo != "SN2" {
+		t.Fatalf("requests: %d, WxSerialNo: %s", srv.count(), c.WxSerialNo)
+	}
+	// 已知序列号不触发刷新
+	if err := c.verifySyncSign(testSignInfo(t, c, "SN1")); err != nil || srv.count() != 2 {
+		t.Fatalf("verifySyncSign(SN1) err: %v, requests: %d", err, srv.count())
+	}
+	// 伪造的序列号在最小间隔内不再请求
+	for i := 0; i < 3; i++ {
+		if err := c.verifySyncSign(testSignInfo(t, c, "FAKE")); err == nil || !strings.Contains(err.Error(), "too frequently") {
+			t.Fatalf("verifySyncSign(FAKE) err: %v", err)
+		}
+	}
+	if srv.count() != 2 {
+		t.Fatalf("requests: %d, want 2", srv.count())
+	}
+}
+
+func TestClientRefreshCertsWithoutManager(t *testing.T) {
+	srv := newCertServer(t, "SN1")
+	c := newTestClientV3(t, "1900000001", srv.URL)
+
+	if err := c.VerifyNotifySign(&V3NotifyReq{SignInfo: testSignInfo(t, c, "SN1")}); err != nil {
+		t.Fatalf("VerifyNotifySign(SN1) err: %v", err)
+	}
+	if srv.count() != 1 || c.CertManager() != nil {
+		t.Fatalf("requests: %d, CertManager: %v", srv.count(), c.CertManager())
+	}
+	for i := 0; i < 3; i++ {
+		if err := c.VerifyNotifySign(&V3NotifyReq{SignInfo: testSignInfo(t, c, "FAKE")}); err == nil || !strings.Contains(err.Error(), "too frequently") {
+			t.Fatalf("VerifyNotifySign(FAKE) err: %v", err)
+		}
+	}
+	if srv.count() != 1 {
+		t.Fatalf("requests: %d, want 1", srv.count())
+	}
+}
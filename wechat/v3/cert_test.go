@@ -0,0 +1,120 @@
+package wechat
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/misu99/gopay"
+)
+
+const testPublicKeyID = "PUB_KEY_ID_0114232134912410000000000000"
+
+func TestAutoVerifySignByPublicKey(t *testing.T) {
+	srv := newCertServer(t, "SN1")
+	c := newTestClientV3(t, "1900000001", srv.URL)
+
+	// 参数校验
+	if err := c.AutoVerifySignByPublicKey([]byte(publicPKCS1), ""); err == nil {
+		t.Fatal("AutoVerifySignByPublicKey() with empty wxPublicKeyID should return error")
+	}
+	if err := c.AutoVerifySignByPublicKey([]byte("bad public key"), testPublicKeyID); err == nil {
+		t.Fatal("AutoVerifySignByPublicKey() with bad public key should return error")
+	}
+	sm2Client := &ClientV3{Mchid: "1900000002", signType: SignTypeSM2}
+	if err := sm2Client.AutoVerifySignByPublicKey([]byte(publicPKCS1), testPublicKeyID); err == nil {
+		t.Fatal("AutoVerifySignByPublicKey() with SM2 client should return error")
+	}
+	if c.isAutoSign() || c.WxPublicKeyID() != "" {
+		t.Fatalf("failed AutoVerifySignByPublicKey() changed client, autoSign: %v, WxPublicKeyID: %s", c.isAutoSign(), c.WxPublicKeyID())
+	}
+
+	if err := c.AutoVerifySignByPublicKey([]byte(publicPKCS1), testPublicKeyID); err != nil {
+		t.Fatal(err)
+	}
+	pkMap := c.WxPublicKeyMap()
+	if _, ok := pkMap[testPublicKeyID]; !ok || len(pkMap) != 1 || !c.isAutoSign() {
+		t.Fatalf("WxPublicKeyMap() = %v, autoSign: %v", pkMap, c.isAutoSign())
+	}
+	// 请求 Header 中 Wechatpay-Serial 及敏感信息加密使用微信支付公钥
+	if c.WxPublicKeyID() != testPublicKeyID || c.WxSerialNo != testPublicKeyID || c.WxPublicKey() == nil {
+		t.Fatalf("WxPublicKeyID: %s, WxSerialNo: %s", c.WxPublicKeyID(), c.WxSerialNo)
+	}
+	if err := c.verifySyncSign(testSignInfo(t, c, testPublicKeyID)); err != nil {
+		t.Fatalf("verifySyncSign(%s) err: %v", testPublicKeyID, err)
+	}
+	// 仅公钥模式：拒绝其他序列号，且不下载平台证书
+	err := c.verifySyncSign(testSignInfo(t, c, "SN1"))
+	if !errors.Is(err, gopay.VerifySignatureErr) || !strings.Contains(err.Error(), "not match wechat pay public key id") {
+		t.Fatalf("verifySyncSign(SN1) err: %v", err)
+	}
+	if err = c.VerifyNotifySign(&V3NotifyReq{SignInfo: testSignInfo(t, c, "SN1")}); !errors.Is(err, gopay.VerifySignatureErr) {
+		t.Fatalf("VerifyNotifySign(SN1) err: %v", err)
+	}
+	if srv.count() != 0 {
+		t.Fatalf("public key mode requested platform certs %d times", srv.count())
+	}
+	// 签名被篡改
+	si := testSignInfo(t, c, testPublicKeyID)
+	si.SignBody += " "
+	if err = c.verifySyncSign(si); !errors.Is(err, gopay.VerifySignatureErr) {
+		t.Fatalf("verifySyncSign() with bad sign err: %v", err)
+	}
+}
+
+func TestAutoVerifySignByCertAndPublicKey(t *testing.T) {
+	srv := newCertServer(t, "SN1")
+	c := newTestClientV3(t, "1900000001", srv.URL)
+	if err := c.AutoVerifySignByCertAndPublicKey([]byte(publicPKCS1), testPublicKeyID); err != nil {
+		t.Fatal(err)
+	}
+	defer c.CertManager().Stop()
+
+	// 过渡模式同时接受平台证书序列号和微信支付公钥ID
+	pkMap := c.WxPublicKeyMap()
+	if _, ok := pkMap["SN1"]; !ok || len(pkMap) != 2 {
+		t.Fatalf("WxPublicKeyMap() = %v", pkMap)
+	}
+	if _, ok := pkMap[testPublicKeyID]; !ok {
+		t.Fatalf("WxPublicKeyMap() = %v", pkMap)
+	}
+	for _, serialNo := range []string{"SN1", testPublicKeyID} {
+		if err := c.verifySyncSign(testSignInfo(t, c, serialNo)); err != nil {
+			t.Fatalf("verifySyncSign(%s) err: %v", serialNo, err)
+		}
+	}
+	if srv.count() != 1 {
+		t.Fatalf("requests: %d, want 1", srv.count())
+	}
+	// 请求 Header 中 Wechatpay-Serial 使用微信支付公钥ID
+	if c.WxSerialNo != testPublicKeyID {
+		t.Fatalf("WxSerialNo: %s, want %s", c.WxSerialNo, testPublicKeyID)
+	}
+}
+
+// 重新设置微信支付公钥的同时验签、读取公钥ID，不应产生数据竞争
+func TestAutoVerifySignByPublicKeyConcurrent(t *testing.T) {
+	c := newTestClientV3(t, "1900000001", "")
+	if err := c.AutoVerifySignByPublicKey([]byte(publicPKCS1), testPublicKeyID); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			if err := c.AutoVerifySignByPublicKey([]byte(publicPKCS1), testPublicKeyID); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		if err := c.verifySyncSign(testSignInfo(t, c, "SN1")); !errors.Is(err, gopay.VerifySignatureErr) || !strings.Contains(err.Error(), testPublicKeyID) {
+			t.Fatalf("verifySyncSign(SN1) err: %v", err)
+		}
+		if id := c.WxPublicKeyID(); id != testPublicKeyID {
+			t.Fatalf("WxPublicKeyID() = %s", id)
+		}
+	}
+	<-done
+}
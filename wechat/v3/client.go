@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/pkg/sm2"
 	"github.com/misu99/gopay/pkg/util"
 	"github.com/misu99/gopay/pkg/xhttp"
 	"github.com/misu99/gopay/pkg/xlog"
@@ -17,18 +18,29 @@ import (
 
 // ClientV3 微信支付 V3
 type ClientV3 struct {
-	Mchid       string
-	ApiV3Key    []byte
-	SerialNo    string
-	WxSerialNo  string
-	autoSign    bool
-	bodySize    int // http response body size(MB), default is 10MB
-	rwMu        sync.RWMutex
-	privateKey  *rsa.PrivateKey
-	wxPublicKey *rsa.PublicKey
-	ctx         context.Context
-	DebugSwitch gopay.DebugSwitch
-	SnCertMap   map[string]*rsa.PublicKey // key: serial_no
+	Mchid          string
+	ApiV3Key       []byte
+	SerialNo       string
+	WxSerialNo     string
+	autoSign       bool
+	bodySize       int    // http response body size(MB), default is 10MB
+	signType       string // 签名类型：SignTypeRSA、SignTypeSM2
+	rwMu           sync.RWMutex
+	privateKey     *rsa.PrivateKey
+	wxPublicKey    *rsa.PublicKey
+	sm2PrivateKey  *sm2.PrivateKey
+	wxSM2PublicKey *sm2.PublicKey
+	ctx            context.Context
+	DebugSwitch    gopay.DebugSwitch
+	SnCertMap      map[string]*rsa.PublicKey // key: serial_no
+	SnSM2CertMap   map[string]*sm2.PublicKey // key: serial_no，国密模式下的微信平台证书
+	wxPublicKeyID  string                    // 微信支付公钥ID（PUB_KEY_ID_xxx）
+	wxPayPublicKey *rsa.PublicKey            // 微信支付公钥
+	onlyPublicKey  bool                      // 仅使用微信支付公钥验签（不下载平台证书）
+	certManager    *CertManager              // 平台证书管理器
+	refreshMu      sync.Mutex                // 未绑定证书管理器时，按需刷新平台证书的互斥锁
+	lastOnDemand   time.Time                 // 未绑定证书管理器时，最近一次按需刷新平台证书的时间
+	baseUrl        string                    // 请求域名，默认：中国国内
 }
 
 // NewClientV3 初始化微信客户端 V3
@@ -48,6 +60,7 @@ func NewClientV3(mchid, serialNo, apiV3Key, privateKey string) (client *ClientV3
 		Mchid:       mchid,
 		SerialNo:    serialNo,
 		ApiV3Key:    []byte(apiV3Key),
+		signType:    SignTypeRSA,
 		privateKey:  priKey,
 		ctx:         context.Background(),
 		DebugSwitch: gopay.DebugOff,
@@ -55,32 +68,138 @@ func NewClientV3(mchid, serialNo, apiV3Key, privateKey string) (client *ClientV3
 	return client, nil
 }
 
-// AutoVerifySign 开启请求完自动验签功能（默认不开启，推荐开启）
-// 开启自动验签，自动开启每12小时一次轮询，请求最新证书操作
-func (c *ClientV3) AutoVerifySign(autoRefresh ...bool) (err error) {
-	wxSerialNo, certMap, err := c.GetAndSelectNewestCert()
+// NewClientV3SM2 初始化国密（SM2/SM3/SM4）模式的微信客户端 V3
+// 请求使用 WECHATPAY2-SM2-WITH-SM3 签名，应答及回调使用 SM2 国密平台证书验签，回调报文使用 AEAD_SM4_GCM 解密
+// mchid：商户ID 或者服务商模式的 sp_mchid
+// serialNo：商户国密API证书的证书序列号
+// apiV3Key：APIv3Key，商户平台获取
+// privateKey：商户国密API证书的 SM2 私钥内容（PKCS8 或 SEC1 格式）
+// 文档：https://pay.weixin.qq.com/docs/merchant/development/shangmi/guide.html
+func NewClientV3SM2(mchid, serialNo, apiV3Key, privateKey string) (client *ClientV3, err error) {
+	if mchid == util.NULL || serialNo == util.NULL || apiV3Key == util.NULL || privateKey == util.NULL {
+		return nil, gopay.MissWechatInitParamErr
+	}
+	priKey, err := xpem.DecodeSM2PrivateKey([]byte(privateKey))
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if len(c.SnCertMap) <= 0 {
-		c.SnCertMap = make(map[string]*rsa.PublicKey)
+	client = &ClientV3{
+		Mchid:         mchid,
+		SerialNo:      serialNo,
+		ApiV3Key:      []byte(apiV3Key),
+		signType:      SignTypeSM2,
+		sm2PrivateKey: priKey,
+		ctx:           context.Background(),
+		DebugSwitch:   gopay.DebugOff,
 	}
-	for sn, cert := range certMap {
-		// decode cert
-		pubKey, err := xpem.DecodePublicKey([]byte(cert))
+	return client, nil
+}
+
+// AutoVerifySign 开启请求完自动验签功能（默认不开启，推荐开启）
+// 开启自动验签，自动开启每12小时一次轮询，请求最新证书操作
+// 国密模式下，自动获取并使用 SM2 平台证书
+// 如需自定义轮询间隔、停止轮询、多个 client 共享证书或监听刷新结果，请使用 wechat.NewCertManager()
+func (c *ClientV3) AutoVerifySign(autoRefresh ...bool) (err error) {
+	if len(autoRefresh) == 1 && !autoRefresh[0] {
+		wxSerialNo, certMap, err := c.GetAndSelectNewestCert(c.certTypes()...)
 		if err != nil {
 			return err
 		}
-		c.SnCertMap[sn] = pubKey
+		return c.setPlatformCerts(wxSerialNo, certMap, false)
 	}
-	c.WxSerialNo = wxSerialNo
-	c.wxPublicKey = c.SnCertMap[wxSerialNo]
-	if len(autoRefresh) == 1 && !autoRefresh[0] {
-		return
+	if m := c.CertManager(); m != nil {
+		if err = m.Refresh(c.ctx); err != nil {
+			return err
+		}
+		c.setAutoSign(true)
+		return nil
+	}
+	return NewCertManager(c).Start(c.ctx)
+}
+
+// CertManager 获取 client 绑定的平台证书管理器，未绑定时返回 nil
+func (c *ClientV3) CertManager() *CertManager {
+	c.rwMu.RLock()
+	defer c.rwMu.RUnlock()
+	return c.certManager
+}
+
+func (c *ClientV3) setCertManager(m *CertManager) {
+	c.rwMu.Lock()
+	c.certManager = m
+	c.rwMu.Unlock()
+}
+
+func (c *ClientV3) setAutoSign(autoSign bool) {
+	c.rwMu.Lock()
+	c.autoSign = autoSign
+	c.rwMu.Unlock()
+}
+
+func (c *ClientV3) isAutoSign() bool {
+	c.rwMu.RLock()
+	defer c.rwMu.RUnlock()
+	return c.autoSign
+}
+
+// AutoVerifySignByPublicKey 开启请求完自动验签功能（微信支付公钥模式）
+// 使用微信支付公钥验签，无需下载和轮询平台证书，请求 Header 中 Wechatpay-Serial 及敏感信息加密均使用 微信支付公钥
+// wxPublicKeyContent：微信支付公钥内容（pub_key.pem）
+// wxPublicKeyID：微信支付公钥ID，如 PUB_KEY_ID_0000000000000000000000000000000000
+// 文档：https://pay.weixin.qq.com/doc/v3/merchant/4012153196
+func (c *ClientV3) AutoVerifySignByPublicKey(wxPublicKeyContent []byte, wxPublicKeyID string) (err error) {
+	if err = c.setWxPayPublicKey(wxPublicKeyContent, wxPublicKeyID); err != nil {
+		return err
 	}
+	c.rwMu.Lock()
+	c.onlyPublicKey = true
 	c.autoSign = true
-	go c.autoCheckCertProc()
-	return
+	c.rwMu.Unlock()
+	return nil
+}
+
+// AutoVerifySignByCertAndPublicKey 开启请求完自动验签功能（平台证书 切换至 微信支付公钥 的过渡模式）
+// 同时接受 平台证书序列号 和 微信支付公钥ID 签名的应答及回调，平台证书仍每12小时轮询更新
+// 请求 Header 中 Wechatpay-Serial 及敏感信息加密使用 微信支付公钥
+// wxPublicKeyContent：微信支付公钥内容（pub_key.pem）
+// wxPublicKeyID：微信支付公钥ID，如 PUB_KEY_ID_0000000000000000000000000000000000
+func (c *ClientV3) AutoVerifySignByCertAndPublicKey(wxPublicKeyContent []byte, wxPublicKeyID string, autoRefresh ...bool) (err error) {
+	if err = c.setWxPayPublicKey(wxPublicKeyContent, wxPublicKeyID); err != nil {
+		return err
+	}
+	c.rwMu.Lock()
+	c.onlyPublicKey = false
+	c.rwMu.Unlock()
+	return c.AutoVerifySign(autoRefresh...)
+}
+
+// SetCountry 设置支付国家（默认：中国国内）
+// 根据支付地区情况设置国家，境外商户使用跨境支付接口时，选择对应地区的请求域名
+// country：<China：中国国内，China2：中国国内（冗灾方案），SoutheastAsia：东南亚，Other：其他国家>
+func (c *ClientV3) SetCountry(country Country) (client *ClientV3) {
+	switch country {
+	case China2:
+		c.baseUrl = v3BaseUrlCh2
+	case SoutheastAsia:
+		c.baseUrl = v3BaseUrlHk
+	case Other:
+		c.baseUrl = v3BaseUrlUs
+	default:
+		c.baseUrl = v3BaseUrlCh
+	}
+	return c
+}
+
+func (c *ClientV3) host() string {
+	if c.baseUrl != "" {
+		return c.baseUrl
+	}
+	return v3BaseUrlCh
+}
+
+// BaseUrl 获取当前请求域名，可通过 SetCountry() 设置
+func (c *ClientV3) BaseUrl() string {
+	return c.host()
 }
 
 // SetBodySize 设置http response body size(MB)
@@ -91,7 +210,7 @@ func (c *ClientV3) SetBodySize(sizeMB int) {
 }
 
 func (c *ClientV3) doProdPostWithHeader(ctx context.Context, headerMap map[string]string, bm gopay.BodyMap, path, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
-	var url = v3BaseUrlCh + path
+	var url = c.host() + path
 	httpClient := xhttp.NewClient()
 	if c.bodySize > 0 {
 		httpClient.SetBodySize(c.bodySize)
@@ -105,7 +224,10 @@ func (c *ClientV3) doProdPostWithHeader(ctx context.Context, headerMap map[strin
 	}
 	httpClient.Header.Add(HeaderAuthorization, authorization)
 	httpClient.Header.Add(HeaderRequestID, fmt.Sprintf("%s-%d", util.RandomString(21), time.Now().Unix()))
-	httpClient.Header.Add(HeaderSerial, c.WxSerialNo)
+	// 敏感信息加密的请求，Wechatpay-Serial 使用加密时的平台证书序列号
+	if _, ok := headerMap[HeaderSerial]; !ok {
+		httpClient.Header.Add(HeaderSerial, c.WxSerialNo)
+	}
 	httpClient.Header.Add("Accept", "*/*")
 	res, bs, err = httpClient.Type(xhttp.TypeJSON).Post(url).SendBodyMap(bm).EndBytes(ctx)
 	if err != nil {
@@ -127,7 +249,7 @@ func (c *ClientV3) doProdPostWithHeader(ctx context.Context, headerMap map[strin
 }
 
 func (c *ClientV3) doProdPost(ctx context.Context, bm gopay.BodyMap, path, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
-	var url = v3BaseUrlCh + path
+	var url = c.host() + path
 	httpClient := xhttp.NewClient()
 	if c.bodySize > 0 {
 		httpClient.SetBodySize(c.bodySize)
@@ -160,7 +282,7 @@ func (c *ClientV3) doProdPost(ctx context.Context, bm gopay.BodyMap, path, autho
 }
 
 func (c *ClientV3) doProdGet(ctx context.Context, uri, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
-	var url = v3BaseUrlCh + uri
+	var url = c.host() + uri
 	httpClient := xhttp.NewClient()
 	if c.bodySize > 0 {
 		httpClient.SetBodySize(c.bodySize)
@@ -193,7 +315,7 @@ func (c *ClientV3) doProdGet(ctx context.Context, uri, authorization string) (re
 }
 
 func (c *ClientV3) doProdPut(ctx context.Context, bm gopay.BodyMap, path, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
-	var url = v3BaseUrlCh + path
+	var url = c.host() + path
 	httpClient := xhttp.NewClient()
 	if c.bodySize > 0 {
 		httpClient.SetBodySize(c.bodySize)
@@ -226,7 +348,7 @@ func (c *ClientV3) doProdPut(ctx context.Context, bm gopay.BodyMap, path, author
 }
 
 func (c *ClientV3) doProdDelete(ctx context.Context, bm gopay.BodyMap, path, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
-	var url = v3BaseUrlCh + path
+	var url = c.host() + path
 	httpClient := xhttp.NewClient()
 	if c.bodySize > 0 {
 		httpClient.SetBodySize(c.bodySize)
@@ -259,7 +381,7 @@ func (c *ClientV3) doProdDelete(ctx context.Context, bm gopay.BodyMap, path, aut
 }
 
 func (c *ClientV3) doProdPostFile(ctx context.Context, bm gopay.BodyMap, path, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
-	var url = v3BaseUrlCh + path
+	var url = c.host() + path
 	httpClient := xhttp.NewClient()
 	if c.bodySize > 0 {
 		httpClient.SetBodySize(c.bodySize)
@@ -292,7 +414,7 @@ func (c *ClientV3) doProdPostFile(ctx context.Context, bm gopay.BodyMap, path, a
 }
 
 func (c *ClientV3) doProdPatch(ctx context.Context, bm gopay.BodyMap, path, authorization string) (res *http.Response, si *SignInfo, bs []byte, err error) {
-	var url = v3BaseUrlCh + path
+	var url = c.host() + path
 	httpClient := xhttp.NewClient()
 	if c.bodySize > 0 {
 		httpClient.SetBodySize(c.bodySize)
@@ -58,20 +58,22 @@ func TestMain(m *testing.M) {
 	// 	serialNo：商户证书的证书序列号
 	//	apiV3Key：APIv3Key，商户平台获取
 	//	privateKey：商户API证书下载后，私钥 apiclient_key.pem 读取后的字符串内容
+	//	未配置商户信息或无法获取平台证书时，client 为 nil，仅跳过需要请求微信的测试，离线测试照常运行
 	client, err = NewClientV3(MchId, SerialNo, APIv3Key, PrivateKeyContent)
 	if err != nil {
-		xlog.Error(err)
-		return
+		xlog.Warnf("NewClientV3: %v, skip network tests", err)
+		client = nil
+		os.Exit(m.Run())
 	}
 
 	// 设置微信平台证书和序列号，如开启自动验签，请忽略此步骤
 	//client.SetPlatformCert([]byte(""), "")
 
 	// 启用自动同步返回验签，并定时更新微信平台API证书
-	err = client.AutoVerifySign()
-	if err != nil {
-		xlog.Error(err)
-		return
+	if err = client.AutoVerifySign(); err != nil {
+		xlog.Warnf("AutoVerifySign: %v, skip network tests", err)
+		client = nil
+		os.Exit(m.Run())
 	}
 
 	// 打开Debug开关，输出日志
@@ -80,7 +82,16 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
+// 未初始化 client 时跳过需要请求微信的测试
+func requireClient(t *testing.T) {
+	t.Helper()
+	if client == nil {
+		t.Skip("client not initialized, skip network test")
+	}
+}
+
 func TestGetPlatformCertsWithoutClient(t *testing.T) {
+	requireClient(t)
 	certs, err := GetPlatformCerts(ctx, MchId, APIv3Key, SerialNo, PrivateKeyContent, CertTypeALL)
 	if err != nil {
 		xlog.Error(err)
@@ -99,6 +110,7 @@ func TestGetPlatformCertsWithoutClient(t *testing.T) {
 }
 
 func TestGetAndSelectNewestCert(t *testing.T) {
+	requireClient(t)
 	serialNo, snCertMap, err := client.GetAndSelectNewestCert(CertTypeALL)
 	if err != nil {
 		xlog.Error(err)
@@ -134,6 +146,7 @@ func TestV3VerifySignByPK(t *testing.T) {
 }
 
 func TestV3Jsapi(t *testing.T) {
+	requireClient(t)
 	tradeNo := util.RandomString(32)
 	xlog.Debug("tradeNo:", tradeNo)
 	expire := time.Now().Add(10 * time.Minute).Format(time.RFC3339)
@@ -171,6 +184,7 @@ func TestV3Jsapi(t *testing.T) {
 }
 
 func TestV3PartnerJsapi(t *testing.T) {
+	requireClient(t)
 	tradeNo := util.RandomString(32)
 	xlog.Debug("tradeNo:", tradeNo)
 	expire := time.Now().Add(10 * time.Minute).Format(time.RFC3339)
@@ -210,6 +224,7 @@ func TestV3PartnerJsapi(t *testing.T) {
 }
 
 func TestV3Native(t *testing.T) {
+	requireClient(t)
 	tradeNo := util.RandomString(32)
 	xlog.Debug("tradeNo:", tradeNo)
 	expire := time.Now().Add(10 * time.Minute).Format(time.RFC3339)
@@ -238,6 +253,7 @@ func TestV3Native(t *testing.T) {
 }
 
 func TestV3PartnerNative(t *testing.T) {
+	requireClient(t)
 	tradeNo := util.RandomString(32)
 	xlog.Debug("tradeNo:", tradeNo)
 	expire := time.Now().Add(10 * time.Minute).Format(time.RFC3339)
@@ -269,6 +285,7 @@ func TestV3PartnerNative(t *testing.T) {
 }
 
 func TestV3TransactionH5(t *testing.T) {
+	requireClient(t)
 	number := util.RandomString(32)
 	xlog.Info("out_trade_no:", number)
 	// 初始化参数Map
@@ -311,6 +328,7 @@ func TestV3TransactionH5(t *testing.T) {
 }
 
 func TestV3QueryOrder(t *testing.T) {
+	requireClient(t)
 	//wxRsp, err := client.V3TransactionQueryOrder(TransactionId, "42000008462020122402449153433")
 	wxRsp, err := client.V3TransactionQueryOrder(ctx, OutTradeNo, "22LW55HDd8tuxgZgFM445kI52BZVk847")
 	if err != nil {
@@ -325,6 +343,7 @@ func TestV3QueryOrder(t *testing.T) {
 }
 
 func TestV3CloseOrder(t *testing.T) {
+	requireClient(t)
 	wxRsp, err := client.V3TransactionCloseOrder(ctx, "FY160932049419637602")
 	if err != nil {
 		xlog.Error(err)
@@ -338,6 +357,7 @@ func TestV3CloseOrder(t *testing.T) {
 }
 
 func TestV3BillTradeBill(t *testing.T) {
+	requireClient(t)
 	bm := make(gopay.BodyMap)
 	bm.Set("bill_date", "2020-12-30").
 		Set("tar_type", "GZIP")
@@ -355,6 +375,7 @@ func TestV3BillTradeBill(t *testing.T) {
 }
 
 func TestV3BillFundFlowBill(t *testing.T) {
+	requireClient(t)
 	bm := make(gopay.BodyMap)
 	bm.Set("bill_date", "2020-12-30").
 		Set("tar_type", "GZIP")
@@ -372,6 +393,7 @@ func TestV3BillFundFlowBill(t *testing.T) {
 }
 
 func TestV3BillDownLoadBill(t *testing.T) {
+	requireClient(t)
 	url := "https://api.mch.weixin.qq.com/v3/billdownload/file?token=4MWpG4bWfL3smAe2AeB8scfp1MN0LYORxW691-jI-wL9J9fA6F0qG0q66y44xrur&tartype=gzip"
 	fileBytes, err := client.V3BillDownLoadBill(ctx, url)
 	if err != nil {
@@ -389,6 +411,7 @@ func TestV3BillDownLoadBill(t *testing.T) {
 }
 
 func TestV3ProfitSharingOrder(t *testing.T) {
+	requireClient(t)
 	var rs []*ProfitSharingReceiver
 	item := &ProfitSharingReceiver{
 		Type:        "PERSONAL_OPENID",
@@ -418,6 +441,7 @@ func TestV3ProfitSharingOrder(t *testing.T) {
 }
 
 func TestV3ProfitSharingAddReceiver(t *testing.T) {
+	requireClient(t)
 	bm := make(gopay.BodyMap)
 	bm.Set("appid", "wx52a25f196830f677").
 		Set("type", "PERSONAL_OPENID").
@@ -437,6 +461,7 @@ func TestV3ProfitSharingAddReceiver(t *testing.T) {
 }
 
 func TestV3ProfitSharingDeleteReceiver(t *testing.T) {
+	requireClient(t)
 	bm := make(gopay.BodyMap)
 	bm.Set("appid", "wx52a25f196830f677").
 		Set("type", "PERSONAL_OPENID").
@@ -455,6 +480,7 @@ func TestV3ProfitSharingDeleteReceiver(t *testing.T) {
 }
 
 func TestV3ProfitSharingQuery(t *testing.T) {
+	requireClient(t)
 	bm := make(gopay.BodyMap)
 	bm.Set("transaction_id", "4200001149202106084654939138")
 	wxRsp, err := client.V3ProfitShareOrderQuery(ctx, "P20150806125346", bm)
@@ -470,6 +496,7 @@ func TestV3ProfitSharingQuery(t *testing.T) {
 }
 
 func TestV3ProfitSharingUnfreeze(t *testing.T) {
+	requireClient(t)
 	bm := make(gopay.BodyMap)
 	bm.Set("transaction_id", "202106071738581338")
 	bm.Set("out_order_no", "4200001037202106072686278117")
@@ -487,6 +514,7 @@ func TestV3ProfitSharingUnfreeze(t *testing.T) {
 }
 
 func TestV3ProfitSharingUnsplitQuery(t *testing.T) {
+	requireClient(t)
 	wxRsp, err := client.V3ProfitShareUnsplitAmount(ctx, "4200001149202106084654939138")
 	if err != nil {
 		xlog.Error(err)
@@ -500,6 +528,7 @@ func TestV3ProfitSharingUnsplitQuery(t *testing.T) {
 }
 
 func TestClientV3_V3MediaUploadImage(t *testing.T) {
+	requireClient(t)
 	fileName := "logo.png"
 	fileContent, err := ioutil.ReadFile("../../logo.png")
 	if err != nil {
@@ -529,6 +558,7 @@ func TestClientV3_V3MediaUploadImage(t *testing.T) {
 }
 
 func TestClientV3_V3ComplaintUploadImage(t *testing.T) {
+	requireClient(t)
 	fileName := "logo.png"
 	fileContent, err := ioutil.ReadFile("../../logo.png")
 	if err != nil {
@@ -558,6 +588,7 @@ func TestClientV3_V3ComplaintUploadImage(t *testing.T) {
 }
 
 func TestV3GoldPlanFilterManage(t *testing.T) {
+	requireClient(t)
 	var rs []string
 	rs = append(rs, "SOFTWARE")
 	rs = append(rs, "SECURITY")
@@ -576,6 +607,7 @@ func TestV3GoldPlanFilterManage(t *testing.T) {
 }
 
 func TestV3Withdraw(t *testing.T) {
+	requireClient(t)
 	bm := make(gopay.BodyMap)
 	bm.Set("sub_mchid", "2021060717").
 		Set("out_request_no", "123456").
@@ -594,6 +626,7 @@ func TestV3Withdraw(t *testing.T) {
 }
 
 func TestV3BankSearchBank(t *testing.T) {
+	requireClient(t)
 	encryptText, err := client.V3EncryptText("6214832172305216")
 	if err != nil {
 		xlog.Error(err)
@@ -613,6 +646,7 @@ func TestV3BankSearchBank(t *testing.T) {
 }
 
 func TestV3MerchantDayBalance(t *testing.T) {
+	requireClient(t)
 	wxRsp, err := client.V3MerchantDayBalance(ctx, "BASIC", "2022-04-17")
 	if err != nil {
 		xlog.Error(err)
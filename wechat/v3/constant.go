@@ -22,9 +22,39 @@ const (
 	HeaderSignature = "Wechatpay-Signature"
 	HeaderSerial    = "Wechatpay-Serial"
 
-	Authorization = "WECHATPAY2-SHA256-RSA2048"
-
-	v3BaseUrlCh = "https://api.mch.weixin.qq.com" // 中国国内
+	Authorization    = "WECHATPAY2-SHA256-RSA2048"
+	AuthorizationSM2 = "WECHATPAY2-SM2-WITH-SM3"
+
+	AlgorithmAES256GCM = "AEAD_AES_256_GCM"
+	AlgorithmSM4GCM    = "AEAD_SM4_GCM"
+
+	// 回调通知 event_type
+	EventTransactionSuccess     = "TRANSACTION.SUCCESS"        // 支付成功
+	EventRefundSuccess          = "REFUND.SUCCESS"             // 退款成功
+	EventRefundAbnormal         = "REFUND.ABNORMAL"            // 退款异常
+	EventRefundClosed           = "REFUND.CLOSED"              // 退款关闭
+	EventPayScoreUserPaid       = "PAYSCORE.USER_PAID"         // 支付分 用户支付成功
+	EventPayScoreUserConfirm    = "PAYSCORE.USER_CONFIRM"      // 支付分 用户确认订单
+	EventCouponSend             = "COUPON.SEND"                // 商家券 领券事件
+	EventTransferBatchFinished  = "MCHTRANSFER.BATCH.FINISHED" // 商家转账 批次完成
+	EventTransferBatchClosed    = "MCHTRANSFER.BATCH.CLOSED"   // 商家转账 批次关闭
+	EventTransferBillFinished   = "MCHTRANSFER.BILL.FINISHED"  // 商家转账（新版） 转账单据终态
+	EventComplaintCreate        = "COMPLAINT.CREATE"           // 投诉 产生新投诉
+	EventComplaintStateChange   = "COMPLAINT.STATE_CHANGE"     // 投诉 投诉状态变化
+	EventFapiaoUserApplied      = "FAPIAO.USER_APPLIED"        // 电子发票 用户发起开票申请
+	EventFapiaoIssued           = "FAPIAO.ISSUED"              // 电子发票 发票开具成功
+	EventFapiaoReversed         = "FAPIAO.REVERSED"            // 电子发票 发票冲红成功
+	EventFapiaoInserted         = "FAPIAO.INSERTED"            // 电子发票 发票插入用户卡包成功
+	EventPapayContractSign      = "PAPAY.SIGN"                 // 委托代扣 签约
+	EventPapayContractTerminate = "PAPAY.TERMINATE"            // 委托代扣 解约
+	EventViolationPunish        = "VIOLATION.PUNISH"           // 商户违规 处罚
+	EventViolationIntercept     = "VIOLATION.INTERCEPT"        // 商户违规 拦截
+	EventViolationAppeal        = "VIOLATION.APPEAL"           // 商户违规 申诉结果
+
+	v3BaseUrlCh  = "https://api.mch.weixin.qq.com"   // 中国国内
+	v3BaseUrlCh2 = "https://api2.mch.weixin.qq.com"  // 中国国内（冗灾方案）
+	v3BaseUrlHk  = "https://apihk.mch.weixin.qq.com" // 东南亚
+	v3BaseUrlUs  = "https://apius.mch.weixin.qq.com" // 其他国家
 
 	v3GetCerts = "/v3/certificates"
 	// 基础支付（直连模式）
@@ -162,6 +192,20 @@ const (
 	v3GoldPlanOpenAdShow   = "/v3/goldplan/merchants/open-advertising-show"           // 开通广告展示 PATCH
 	v3GoldPlanCloseAdShow  = "/v3/goldplan/merchants/close-advertising-show"          // 关闭广告展示 POST
 
+	// 电子发票
+	v3FapiaoCardTemplate        = "/v3/new-tax-control-fapiao/card-template"                       // 创建电子发票卡券模板 POST
+	v3FapiaoDevelopmentConfig   = "/v3/new-tax-control-fapiao/merchant/development-config"         // 配置开发选项 PATCH、查询商户配置的开发选项 GET
+	v3FapiaoMerchantBaseInfo    = "/v3/new-tax-control-fapiao/merchant/base-information"           // 查询商户开票基础信息 GET
+	v3FapiaoMerchantTaxCodes    = "/v3/new-tax-control-fapiao/merchant/tax-codes"                  // 获取商品和服务税收分类对照表 GET
+	v3FapiaoUserTitleUrl        = "/v3/new-tax-control-fapiao/user-title/title-url"                // 获取抬头填写链接 GET
+	v3FapiaoUserTitle           = "/v3/new-tax-control-fapiao/user-title"                          // 获取用户填写的抬头 GET
+	v3FapiaoApplications        = "/v3/new-tax-control-fapiao/fapiao-applications"                 // 开具电子发票 POST
+	v3FapiaoApplicationsQuery   = "/v3/new-tax-control-fapiao/fapiao-applications/%s"              // fapiao_apply_id 查询电子发票 GET
+	v3FapiaoApplicationsReverse = "/v3/new-tax-control-fapiao/fapiao-applications/%s/reverse"      // fapiao_apply_id 冲红电子发票 POST
+	v3FapiaoApplicationsFiles   = "/v3/new-tax-control-fapiao/fapiao-applications/%s/fapiao-files" // fapiao_apply_id 获取发票下载信息 GET
+	v3FapiaoUploadFile          = "/v3/new-tax-control-fapiao/fapiao-applications/upload-file"     // 上传电子发票文件 POST
+	v3FapiaoInsertCards         = "/v3/new-tax-control-fapiao/fapiao-applications/%s/insert-cards" // fapiao_apply_id 将电子发票插入微信用户卡包 POST
+
 	// 消费者投诉2.0
 	v3ComplaintList                 = "/v3/merchant-service/complaints-v2"                           // 查询投诉单列表 GET
 	v3ComplaintDetail               = "/v3/merchant-service/complaints-v2/%s"                        // complaint_id 查询投诉单详情 GET
@@ -187,6 +231,15 @@ const (
 	v3ProfitShareMerchantConfigs = "/v3/profitsharing/merchant-configs/%s"     // 查询最大分账比例API GET
 	v3ProfitShareBills           = "/v3/profitsharing/bills"                   // 申请分账账单 GET
 
+	// 连锁品牌分账
+	v3BrandProfitShareConfig         = "/v3/brand/profitsharing/brand-configs/%s"  // brand_mchid 查询最大分账比例 GET
+	v3BrandProfitShareAddReceiver    = "/v3/brand/profitsharing/receivers/add"     // 添加分账接收方 POST
+	v3BrandProfitShareDeleteReceiver = "/v3/brand/profitsharing/receivers/delete"  // 删除分账接收方 POST
+	v3BrandProfitShareOrder          = "/v3/brand/profitsharing/orders"            // 请求分账 POST、查询分账结果 GET
+	v3BrandProfitShareReturn         = "/v3/brand/profitsharing/returnorders"      // 请求分账回退 POST、查询分账回退结果 GET
+	v3BrandProfitShareFinish         = "/v3/brand/profitsharing/finish-order"      // 完结分账 POST
+	v3BrandProfitShareUnsplitAmount  = "/v3/brand/profitsharing/orders/%s/amounts" // transaction_id 查询订单剩余待分金额 GET
+
 	// 其他能力
 	v3MediaUploadImage = "/v3/merchant/media/upload"       // 图片上传 POST
 	v3MediaUploadVideo = "/v3/merchant/media/video_upload" // 视频上传 POST
@@ -202,6 +255,16 @@ const (
 	v3TransferDetailReceipt      = "/v3/transfer-detail/electronic-receipts"                       // 转账明细电子回单受理 POST
 	v3TransferDetailReceiptQuery = "/v3/transfer-detail/electronic-receipts"                       // 查询转账明细电子回单受理结果 GET
 
+	// 商家转账（新版）
+	v3TransferBills                     = "/v3/fund-app/mch-transfer/transfer-bills"                       // 发起转账 POST
+	v3TransferBillsCancel               = "/v3/fund-app/mch-transfer/transfer-bills/out-bill-no/%s/cancel" // out_bill_no 撤销转账 POST
+	v3TransferBillsQuery                = "/v3/fund-app/mch-transfer/transfer-bills/out-bill-no/%s"        // out_bill_no 商户单号查询转账单 GET
+	v3TransferBillsQueryByBillNo        = "/v3/fund-app/mch-transfer/transfer-bills/transfer-bill-no/%s"   // transfer_bill_no 微信单号查询转账单 GET
+	v3TransferBillsReceipt              = "/v3/fund-app/mch-transfer/elecsign/out-bill-no"                 // 商户单号申请电子回单 POST
+	v3TransferBillsReceiptQuery         = "/v3/fund-app/mch-transfer/elecsign/out-bill-no/%s"              // out_bill_no 商户单号查询电子回单 GET
+	v3TransferBillsReceiptByBillNo      = "/v3/fund-app/mch-transfer/elecsign/transfer-bill-no"            // 微信单号申请电子回单 POST
+	v3TransferBillsReceiptQueryByBillNo = "/v3/fund-app/mch-transfer/elecsign/transfer-bill-no/%s"         // transfer_bill_no 微信单号查询电子回单 GET
+
 	// 转账（服务商）
 	v3PartnerTransfer               = "/v3/partner-transfer/batches"                                          // 发起批量转账 POST
 	v3PartnerTransferQuery          = "/v3/partner-transfer/batches/batch-id/%s"                              // batch_id 微信批次单号查询批次单 GET
@@ -227,6 +290,32 @@ const (
 	v3Apply4SubQuerySettlement      = "/v3/apply4sub/sub_merchants/%s/settlement"        // sub_mchid 查询结算账户 GET
 	v3Apply4SubMerchantsApplication = "/v3/apply4sub/sub_merchants/%s/application/%s"    // sub_mchid、application_no 查询结算账户修改申请状态
 
+	// 跨境支付（境外商户）
+	v3GlobalApp                     = "/v3/global/transactions/app"                   // APP 下单
+	v3GlobalJsapi                   = "/v3/global/transactions/jsapi"                 // JSAPI、小程序 下单
+	v3GlobalNative                  = "/v3/global/transactions/native"                // Native 下单
+	v3GlobalH5                      = "/v3/global/transactions/mweb"                  // H5 下单
+	v3GlobalQueryOrderTransactionId = "/v3/global/transactions/id/%s"                 // transaction_id 查询订单 GET
+	v3GlobalQueryOrderOutTradeNo    = "/v3/global/transactions/out-trade-no/%s"       // out_trade_no 查询订单 GET
+	v3GlobalCloseOrder              = "/v3/global/transactions/out-trade-no/%s/close" // out_trade_no 关闭订单 POST
+	v3GlobalRefund                  = "/v3/global/refunds"                            // 申请退款 POST
+	v3GlobalRefundQuery             = "/v3/global/refunds/out-refund-no/%s"           // out_refund_no 查询单笔退款 GET
+	v3GlobalExchangeRate            = "/v3/global/fx/rate"                            // 查询汇率 GET
+	v3GlobalSettlement              = "/v3/global/settle/settlements"                 // 查询结算信息 GET
+
+	// 服务商-商户开户意愿确认
+	v3Apply4SubjectSubmit               = "/v3/apply4subject/applyment"                    // 提交申请单 POST
+	v3Apply4SubjectCancelByBusinessCode = "/v3/apply4subject/applyment/%s/cancel"          // business_code 撤销申请单 POST
+	v3Apply4SubjectCancelByApplyId      = "/v3/apply4subject/applyment/%d/cancel"          // applyment_id 撤销申请单 POST
+	v3Apply4SubjectQueryState           = "/v3/apply4subject/applyment"                    // 查询申请单审核结果 GET
+	v3Apply4SubjectQueryAuthorizeState  = "/v3/apply4subject/applyment/merchants/%s/state" // sub_mchid 获取商户开户意愿确认状态 GET
+
+	// 商户违规通知
+	v3ViolationNotifyUrlCreate = "/v3/merchant-risk-manage/violation-notifications" // 创建商户违规通知回调地址 POST
+	v3ViolationNotifyUrlQuery  = "/v3/merchant-risk-manage/violation-notifications" // 查询商户违规通知回调地址 GET
+	v3ViolationNotifyUrlUpdate = "/v3/merchant-risk-manage/violation-notifications" // 修改商户违规通知回调地址 PUT
+	v3ViolationNotifyUrlDelete = "/v3/merchant-risk-manage/violation-notifications" // 删除商户违规通知回调地址 DELETE
+
 	// 电商收付通（商户进件）
 	v3EcommerceApply          = "/v3/ecommerce/applyments/"                  // 二级商户进件 POST
 	v3EcommerceApplyQueryById = "/v3/ecommerce/applyments/%d"                // applyment_id 通过申请单ID查询申请状态 GET
@@ -262,8 +351,16 @@ const (
 	v3BankSearchCityList      = "/v3/capital/capitallhh/areas/provinces/%d/cities"          // province_code 查询城市列表 GET
 	v3BankSearchBranchList    = "/v3/capital/capitallhh/banks/%s/branches"                  // bank_alias_code 查询支行列表 GET
 
-	// 扣款服务-直连模式（其他相关接口在v2接口中）
-	v3EntrustPayNotify = "/v3/papay/contracts/%s/notify" // contract_id 预扣费通知 POST
+	// 扣款服务-直连模式
+	v3EntrustPayNotify         = "/v3/papay/contracts/%s/notify"                            // contract_id 预扣费通知 POST
+	v3PapayPreSignApp          = "/v3/papay/sign/contracts/pre-entrust-sign/app"            // APP预签约 POST
+	v3PapayPreSignJsapi        = "/v3/papay/sign/contracts/pre-entrust-sign/jsapi"          // JSAPI预签约 POST
+	v3PapayPreSignH5           = "/v3/papay/sign/contracts/pre-entrust-sign/h5"             // H5预签约 POST
+	v3PapayPreSignMiniProgram  = "/v3/papay/sign/contracts/pre-entrust-sign/mini-program"   // 小程序预签约 POST
+	v3PapayContractQuery       = "/v3/papay/sign/contracts/contract-id/%s"                  // contract_id 通过协议号查询签约 GET
+	v3PapayContractQueryByCode = "/v3/papay/sign/contracts/plan-id/%d/out-contract-code/%s" // plan_id、out_contract_code 通过商户签约号查询签约 GET
+	v3PapayContractTerminate   = "/v3/papay/sign/contracts/contract-id/%s/terminate"        // contract_id 申请解约 POST
+	v3PapayTransactionsApply   = "/v3/papay/pay/transactions/apply"                         // 申请扣款 POST
 
 	// 特约商户进件申请单状态
 	ApplyStateEditing       = "APPLYMENT_STATE_EDITTING"        // 编辑中
@@ -291,6 +388,12 @@ const (
 	OutTradeNo    OrderNoType = 2
 	QueryId       OrderNoType = 3
 
+	// 支付国家（地区），用于选择请求域名
+	China         Country = 1 // 中国国内
+	China2        Country = 2 // 中国国内（冗灾方案）
+	SoutheastAsia Country = 3 // 东南亚
+	Other         Country = 4 // 其他国家
+
 	// v3 异步通知订单状态
 	TradeStateSuccess  = "SUCCESS"    // 支付成功
 	TradeStateRefund   = "REFUND"     // 转入退款
@@ -11,14 +11,19 @@ import (
 
 // 二级商户进件API
 // 注意：本接口会提交一些敏感信息，需调用 client.V3EncryptText() 进行加密。部分图片参数，请先调用 client.V3MediaUploadImage() 上传，获取MediaId
+// 或使用 ContactInfo、IdCardInfo、BankAccountInfo 等结构体设置参数，标记 sensitive 的字段会自动加密
 // Code = 0 is success
 // 电商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/apis/chapter7_1_1.shtml
 func (c *ClientV3) V3EcommerceApply(ctx context.Context, bm gopay.BodyMap) (*EcommerceApplyRsp, error) {
+	bm, wxSerialNo, err := c.encryptSensitiveBodyMap(bm)
+	if err != nil {
+		return nil, err
+	}
 	authorization, err := c.authorization(MethodPost, v3EcommerceApply, bm)
 	if err != nil {
 		return nil, err
 	}
-	res, si, bs, err := c.doProdPost(ctx, bm, v3EcommerceApply, authorization)
+	res, si, bs, err := c.doProdPostWithHeader(ctx, map[string]string{HeaderSerial: wxSerialNo}, bm, v3EcommerceApply, authorization)
 	if err != nil {
 		return nil, err
 	}
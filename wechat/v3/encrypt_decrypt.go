@@ -11,12 +11,29 @@ import (
 
 	"github.com/misu99/gopay"
 	"github.com/misu99/gopay/pkg/aes"
+	"github.com/misu99/gopay/pkg/sm2"
+	"github.com/misu99/gopay/pkg/sm3"
+	"github.com/misu99/gopay/pkg/sm4"
 	"github.com/misu99/gopay/pkg/util"
 	"github.com/misu99/gopay/pkg/xpem"
 )
 
 // 敏感信息加密，默认使用最新的有效微信平台证书加密
+// 国密模式下使用 SM2 国密平台证书加密
 func (c *ClientV3) V3EncryptText(text string) (cipherText string, err error) {
+	if c.signType == SignTypeSM2 {
+		c.rwMu.RLock()
+		wxPublicKey, wxSerialNo := c.wxSM2PublicKey, c.WxSerialNo
+		c.rwMu.RUnlock()
+		if wxPublicKey == nil || wxSerialNo == "" {
+			return util.NULL, errors.New("WxSM2PublicKey or WxSerialNo is null")
+		}
+		cipherByte, err := sm2.EncryptASN1(rand.Reader, wxPublicKey, []byte(text))
+		if err != nil {
+			return "", fmt.Errorf("sm2.EncryptASN1：%w", err)
+		}
+		return base64.StdEncoding.EncodeToString(cipherByte), nil
+	}
 	if c.wxPublicKey == nil || c.WxSerialNo == "" {
 		return util.NULL, errors.New("WxPublicKey or WxSerialNo is null")
 	}
@@ -28,8 +45,16 @@ func (c *ClientV3) V3EncryptText(text string) (cipherText string, err error) {
 }
 
 // 敏感信息解密
+// 国密模式下使用 SM2 商户私钥解密
 func (c *ClientV3) V3DecryptText(cipherText string) (text string, err error) {
 	cipherByte, _ := base64.StdEncoding.DecodeString(cipherText)
+	if c.signType == SignTypeSM2 {
+		textByte, err := sm2.DecryptASN1(c.sm2PrivateKey, cipherByte)
+		if err != nil {
+			return "", fmt.Errorf("sm2.DecryptASN1：%w", err)
+		}
+		return string(textByte), nil
+	}
 	textByte, err := rsa.DecryptOAEP(sha1.New(), rand.Reader, c.privateKey, cipherByte, nil)
 	if err != nil {
 		return "", fmt.Errorf("rsa.DecryptOAEP：%w", err)
@@ -66,6 +91,64 @@ func V3DecryptText(cipherText string, privateKeyContent []byte) (text string, er
 	return string(textByte), nil
 }
 
+// 国密敏感参数信息加密
+// wxPublicKeyContent：微信 SM2 国密平台证书内容
+func V3EncryptTextSM2(text string, wxPublicKeyContent []byte) (cipherText string, err error) {
+	publicKey, err := xpem.DecodeSM2PublicKey(wxPublicKeyContent)
+	if err != nil {
+		return gopay.NULL, err
+	}
+	cipherByte, err := sm2.EncryptASN1(rand.Reader, publicKey, []byte(text))
+	if err != nil {
+		return "", fmt.Errorf("sm2.EncryptASN1：%w", err)
+	}
+	return base64.StdEncoding.EncodeToString(cipherByte), nil
+}
+
+// 国密敏感参数信息解密
+// privateKeyContent：SM2 商户私钥读取后的字符串内容
+func V3DecryptTextSM2(cipherText string, privateKeyContent []byte) (text string, err error) {
+	privateKey, err := xpem.DecodeSM2PrivateKey(privateKeyContent)
+	if err != nil {
+		return gopay.NULL, err
+	}
+	cipherByte, _ := base64.StdEncoding.DecodeString(cipherText)
+	textByte, err := sm2.DecryptASN1(privateKey, cipherByte)
+	if err != nil {
+		return "", fmt.Errorf("sm2.DecryptASN1：%w", err)
+	}
+	return string(textByte), nil
+}
+
+// 解密 国密 AEAD_SM4_GCM 加密的回调信息 通用方法ToBytes对象
+func V3DecryptNotifyCipherTextToBytesSM4(ciphertext, nonce, additional, apiV3Key string) (decrypt []byte, err error) {
+	return v3DecryptResource(AlgorithmSM4GCM, ciphertext, nonce, additional, []byte(apiV3Key))
+}
+
+// 根据加密算法解密回调、证书中的加密信息
+// algorithm：AEAD_AES_256_GCM 或 AEAD_SM4_GCM，为空时按 AEAD_AES_256_GCM 处理
+func v3DecryptResource(algorithm, ciphertext, nonce, additional string, apiV3Key []byte) (decrypt []byte, err error) {
+	cipherBytes, _ := base64.StdEncoding.DecodeString(ciphertext)
+	if algorithm == AlgorithmSM4GCM {
+		decrypt, err = sm4.GCMDecrypt(cipherBytes, []byte(nonce), []byte(additional), sm4Key(apiV3Key))
+		if err != nil {
+			return nil, fmt.Errorf("sm4.GCMDecrypt, err:%w", err)
+		}
+		return decrypt, nil
+	}
+	decrypt, err = aes.GCMDecrypt(cipherBytes, []byte(nonce), []byte(additional), apiV3Key)
+	if err != nil {
+		return nil, fmt.Errorf("aes.GCMDecrypt, err:%w", err)
+	}
+	return decrypt, nil
+}
+
+// SM4 密钥为 APIv3 密钥 SM3 摘要的前 16 字节
+func sm4Key(apiV3Key []byte) []byte {
+	sum := sm3.Sum(apiV3Key)
+	return sum[:sm4.BlockSize]
+}
+
 // 解密 通用方法ToBytes对象
 func V3DecryptNotifyCipherTextToBytes(ciphertext, nonce, additional, apiV3Key string) (decrypt []byte, err error) {
 	cipherBytes, _ := base64.StdEncoding.DecodeString(ciphertext)
@@ -187,3 +270,101 @@ func V3DecryptBusifavorNotifyCipherText(ciphertext, nonce, additional, apiV3Key
 	}
 	return result, nil
 }
+
+// 解密商家转账（新版）回调中的加密信息
+func V3DecryptTransferBillsNotifyCipherText(ciphertext, nonce, additional, apiV3Key string) (result *V3DecryptTransferBillsResult, err error) {
+	cipherBytes, _ := base64.StdEncoding.DecodeString(ciphertext)
+	decrypt, err := aes.GCMDecrypt(cipherBytes, []byte(nonce), []byte(additional), []byte(apiV3Key))
+	if err != nil {
+		return nil, fmt.Errorf("aes.GCMDecrypt, err:%w", err)
+	}
+	result = &V3DecryptTransferBillsResult{}
+	if err = json.Unmarshal(decrypt, result); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s), err:%w", string(decrypt), err)
+	}
+	return result, nil
+}
+
+// 解密委托代扣签约、解约回调中的加密信息
+func V3DecryptPapayContractNotifyCipherText(ciphertext, nonce, additional, apiV3Key string) (result *V3DecryptPapayContractResult, err error) {
+	cipherBytes, _ := base64.StdEncoding.DecodeString(ciphertext)
+	decrypt, err := aes.GCMDecrypt(cipherBytes, []byte(nonce), []byte(additional), []byte(apiV3Key))
+	if err != nil {
+		return nil, fmt.Errorf("aes.GCMDecrypt, err:%w", err)
+	}
+	result = &V3DecryptPapayContractResult{}
+	if err = json.Unmarshal(decrypt, result); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s), err:%w", string(decrypt), err)
+	}
+	return result, nil
+}
+
+// 解密委托代扣扣款回调中的加密信息
+func V3DecryptPapayPayNotifyCipherText(ciphertext, nonce, additional, apiV3Key string) (result *V3DecryptPapayPayResult, err error) {
+	cipherBytes, _ := base64.StdEncoding.DecodeString(ciphertext)
+	decrypt, err := aes.GCMDecrypt(cipherBytes, []byte(nonce), []byte(additional), []byte(apiV3Key))
+	if err != nil {
+		return nil, fmt.Errorf("aes.GCMDecrypt, err:%w", err)
+	}
+	result = &V3DecryptPapayPayResult{}
+	if err = json.Unmarshal(decrypt, result); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s), err:%w", string(decrypt), err)
+	}
+	return result, nil
+}
+
+// 解密电子发票回调中的加密信息
+func V3DecryptFapiaoNotifyCipherText(ciphertext, nonce, additional, apiV3Key string) (result *V3DecryptFapiaoResult, err error) {
+	cipherBytes, _ := base64.StdEncoding.DecodeString(ciphertext)
+	decrypt, err := aes.GCMDecrypt(cipherBytes, []byte(nonce), []byte(additional), []byte(apiV3Key))
+	if err != nil {
+		return nil, fmt.Errorf("aes.GCMDecrypt, err:%w", err)
+	}
+	result = &V3DecryptFapiaoResult{}
+	if err = json.Unmarshal(decrypt, result); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s), err:%w", string(decrypt), err)
+	}
+	return result, nil
+}
+
+// 解密连锁品牌分账动账回调中的加密信息
+func V3DecryptBrandProfitShareNotifyCipherText(ciphertext, nonce, additional, apiV3Key string) (result *V3DecryptBrandProfitShareResult, err error) {
+	cipherBytes, _ := base64.StdEncoding.DecodeString(ciphertext)
+	decrypt, err := aes.GCMDecrypt(cipherBytes, []byte(nonce), []byte(additional), []byte(apiV3Key))
+	if err != nil {
+		return nil, fmt.Errorf("aes.GCMDecrypt, err:%w", err)
+	}
+	result = &V3DecryptBrandProfitShareResult{}
+	if err = json.Unmarshal(decrypt, result); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s), err:%w", string(decrypt), err)
+	}
+	return result, nil
+}
+
+// 解密商户违规通知回调中的加密信息
+func V3DecryptViolationNotifyCipherText(ciphertext, nonce, additional, apiV3Key string) (result *V3DecryptViolationResult, err error) {
+	cipherBytes, _ := base64.StdEncoding.DecodeString(ciphertext)
+	decrypt, err := aes.GCMDecrypt(cipherBytes, []byte(nonce), []byte(additional), []byte(apiV3Key))
+	if err != nil {
+		return nil, fmt.Errorf("aes.GCMDecrypt, err:%w", err)
+	}
+	result = &V3DecryptViolationResult{}
+	if err = json.Unmarshal(decrypt, result); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s), err:%w", string(decrypt), err)
+	}
+	return result, nil
+}
+
+// 解密跨境支付回调中的加密信息
+func V3DecryptGlobalNotifyCipherText(ciphertext, nonce, additional, apiV3Key string) (result *V3DecryptGlobalResult, err error) {
+	cipherBytes, _ := base64.StdEncoding.DecodeString(ciphertext)
+	decrypt, err := aes.GCMDecrypt(cipherBytes, []byte(nonce), []byte(additional), []byte(apiV3Key))
+	if err != nil {
+		return nil, fmt.Errorf("aes.GCMDecrypt, err:%w", err)
+	}
+	result = &V3DecryptGlobalResult{}
+	if err = json.Unmarshal(decrypt, result); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s), err:%w", string(decrypt), err)
+	}
+	return result, nil
+}
@@ -1,8 +1,15 @@
 package wechat
 
 import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/misu99/gopay/pkg/sm2"
 	"github.com/misu99/gopay/pkg/xlog"
 )
 
@@ -62,3 +69,87 @@ func TestV3EncryptTextAndV3DecryptText(t *testing.T) {
 	}
 	xlog.Debugf("decrypt text: %s", originText)
 }
+
+// SM4 密钥为 APIv3Key 的 SM3 摘要前 16 字节，SM3("abc") 取自 GB/T 32905 示例
+func TestSM4Key(t *testing.T) {
+	if got := hex.EncodeToString(sm4Key([]byte("abc"))); got != "66c7f0f462eeedd9d1f2d46bdc10e4e2" {
+		t.Fatalf("sm4Key(abc) = %s", got)
+	}
+}
+
+// 国密回调：SM2 验签 + AEAD_SM4_GCM 解密
+func TestV3NotifySM4(t *testing.T) {
+	const (
+		apiV3Key   = "0123456789abcdef0123456789abcdef"
+		ciphertext = "zPGZCql98ud2FPLXYGSM4V4Cne7gqjyHovpQxfE7Q106AkCKLJDlbFoJIv0N9Jl1tOk/vXijFCknSA9NhmIOduAhdaHYN3d/zZFUlS0/nvkvNfFKRRrXo3+4MaMYuioC1vYk8GvDJzXVAX2j4QDzGmzCpx9sM/tpobZqJ7cy+x88TaCAiefGvhxLy7c56Gk75nKjQziIybpgXmYmYWPGkcDeTYJMrGwFJ1+g4O0EZf4SAWmp5N9ZbSMJX95RpLpSWvpXrNUCG/a6MdOamgiDCXI4X2AQtSphnuBMd9dx5u6gc4+x9rPFin2iiXFZ/6+4TrGNjhDSkS1C7C9I637yKWsfflrvnfFnaiOLC3RtWykcCv+z3w76Tb1ehQxrakv9FdyWqpvLP9h53iiCxABvNo/icEMMvN7pwWO0mZ8WFhwA3hP9rk/EpQX+oQ+3IwPlprloUmmw7e0Ht4DACIpDpLDfSq6J1VOuueL5jdGVCyf9mEr/F4bW3OTyf0i/8IAb2HoZCddW7NMey+UiqkTz8PovtDgyaDxYvoKN6KzSKssI7QoLRNM7FDRDibHis43i"
+	)
+	body := `{"id":"EV-2018022511223320873","create_time":"2018-06-08T10:34:56+08:00","resource_type":"encrypt-resource","event_type":"TRANSACTION.SUCCESS","summary":"支付成功","resource":{"original_type":"transaction","algorithm":"AEAD_SM4_GCM","ciphertext":"` + ciphertext + `","associated_data":"transaction","nonce":"fdasflkja484"}}`
+
+	priKey, err := sm2.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts, nonce := "1554208460", "593BEC0C930BF1AFEB40B4A08C8FB242"
+	sign, err := sm2.SignASN1(rand.Reader, priKey, []byte(ts+"\n"+nonce+"\n"+body+"\n"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest(http.MethodPost, "/notify", strings.NewReader(body))
+	req.Header.Set(HeaderTimestamp, ts)
+	req.Header.Set(HeaderNonce, nonce)
+	req.Header.Set(HeaderSerial, "SM2SERIAL")
+	req.Header.Set(HeaderSignature, base64.StdEncoding.EncodeToString(sign))
+
+	notifyReq, err := V3ParseNotify(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = notifyReq.VerifySignBySM2PKMap(map[string]*sm2.PublicKey{"SM2SERIAL": &priKey.PublicKey}); err != nil {
+		t.Fatalf("VerifySignBySM2PKMap() err: %v", err)
+	}
+	result, err := notifyReq.DecryptCipherText(apiV3Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.OutTradeNo != "1217752501201407033233368018" || result.TradeState != "SUCCESS" || result.Amount.Total != 100 {
+		t.Fatalf("DecryptCipherText() = %+v", result)
+	}
+	// APIv3Key 错误时 GCM 认证失败
+	if _, err = notifyReq.DecryptCipherText(strings.Repeat("0", 32)); err == nil {
+		t.Fatal("DecryptCipherText() with wrong key should return error")
+	}
+}
+
+// 证书管理器替换国密平台证书的同时读取平台证书，不应产生数据竞争
+func TestWxSM2PublicKeyConcurrent(t *testing.T) {
+	priKey, err := sm2.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := sm2.MarshalPKIXPublicKey(&priKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certMap := map[string]string{"SM2SERIAL": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))}
+	c := &ClientV3{Mchid: "1900000001", signType: SignTypeSM2}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			if err := c.setPlatformCerts("SM2SERIAL", certMap, true); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		_ = c.WxSM2PublicKeyMap()
+		_ = c.WxSM2PublicKey()
+		_, _ = c.V3EncryptText("13900000000")
+	}
+	<-done
+	if c.WxSM2PublicKeyMap()["SM2SERIAL"] == nil || c.WxSM2PublicKey() == nil {
+		t.Fatalf("WxSM2PublicKeyMap() = %v", c.WxSM2PublicKeyMap())
+	}
+}
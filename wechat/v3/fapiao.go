@@ -0,0 +1,370 @@
+package wechat
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/pkg/sm3"
+	"github.com/misu99/gopay/pkg/util"
+)
+
+// 创建电子发票卡券模板API
+// Code = 0 is success
+func (c *ClientV3) V3FapiaoCardTemplate(ctx context.Context, bm gopay.BodyMap) (wxRsp *FapiaoCardTemplateRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3FapiaoCardTemplate, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(ctx, bm, v3FapiaoCardTemplate, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &FapiaoCardTemplateRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(FapiaoCardTemplate)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 配置开发选项API
+// Code = 0 is success
+func (c *ClientV3) V3FapiaoDevelopmentConfig(ctx context.Context, bm gopay.BodyMap) (wxRsp *FapiaoDevelopmentConfigRsp, err error) {
+	authorization, err := c.authorization(MethodPATCH, v3FapiaoDevelopmentConfig, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPatch(ctx, bm, v3FapiaoDevelopmentConfig, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &FapiaoDevelopmentConfigRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(FapiaoDevelopmentConfig)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 查询商户配置的开发选项API
+// Code = 0 is success
+func (c *ClientV3) V3FapiaoDevelopmentConfigQuery(ctx context.Context) (wxRsp *FapiaoDevelopmentConfigRsp, err error) {
+	authorization, err := c.authorization(MethodGet, v3FapiaoDevelopmentConfig, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(ctx, v3FapiaoDevelopmentConfig, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &FapiaoDevelopmentConfigRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(FapiaoDevelopmentConfig)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 查询商户开票基础信息API
+// Code = 0 is success
+func (c *ClientV3) V3FapiaoMerchantBaseInfo(ctx context.Context) (wxRsp *FapiaoMerchantBaseInfoRsp, err error) {
+	authorization, err := c.authorization(MethodGet, v3FapiaoMerchantBaseInfo, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(ctx, v3FapiaoMerchantBaseInfo, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &FapiaoMerchantBaseInfoRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(FapiaoMerchantBaseInfo)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 获取商品和服务税收分类对照表API
+// 注意：bm 中 offset、limit 为 query 参数，全部数据可使用 client.V3FapiaoTaxCodesPager()
+// Code = 0 is success
+func (c *ClientV3) V3FapiaoMerchantTaxCodes(ctx context.Context, bm gopay.BodyMap) (wxRsp *FapiaoTaxCodesRsp, err error) {
+	uri := v3FapiaoMerchantTaxCodes + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(ctx, uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &FapiaoTaxCodesRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(FapiaoTaxCodes)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// V3FapiaoTaxCodesPager 获取商品和服务税收分类对照表 分页迭代器
+// 分页大小最大 20
+func (c *ClientV3) V3FapiaoTaxCodesPager(ctx context.Context) *Pager[*FapiaoTaxCode] {
+	return NewPager(ctx, func(ctx context.Context, offset, limit int) ([]*FapiaoTaxCode, int, error) {
+		wxRsp, err := c.V3FapiaoMerchantTaxCodes(ctx, pageBodyMap(nil, offset, limit))
+		if err != nil {
+			return nil, 0, err
+		}
+		if wxRsp.Code != Success {
+			return nil, 0, pageRspErr("V3FapiaoMerchantTaxCodes", wxRsp.Code, wxRsp.Error)
+		}
+		return wxRsp.Response.Data, wxRsp.Response.TotalCount, nil
+	}).setMaxLimit(20)
+}
+
+// 获取抬头填写链接API
+// 注意：bm 中参数为 query 参数
+// Code = 0 is success
+func (c *ClientV3) V3FapiaoUserTitleUrl(ctx context.Context, bm gopay.BodyMap) (wxRsp *FapiaoUserTitleUrlRsp, err error) {
+	uri := v3FapiaoUserTitleUrl + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(ctx, uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &FapiaoUserTitleUrlRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(FapiaoUserTitleUrl)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 获取用户填写的抬头API
+// 注意：bm 中参数为 query 参数，返回的 phone、email 自动解密
+// Code = 0 is success
+func (c *ClientV3) V3FapiaoUserTitle(ctx context.Context, bm gopay.BodyMap) (wxRsp *FapiaoUserTitleRsp, err error) {
+	uri := v3FapiaoUserTitle + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(ctx, uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &FapiaoUserTitleRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(FapiaoUserTitle)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	if err = c.verifySyncSign(si); err != nil {
+		return wxRsp, err
+	}
+	return wxRsp, c.V3DecryptSensitive(wxRsp.Response)
+}
+
+// 开具电子发票API
+// 注意：buyer_information 使用 *FapiaoBuyerInformation 时，phone、email 自动加密
+// 注意：受理成功后，开票结果以 FAPIAO.ISSUED 回调通知或查询电子发票结果为准
+// Code = 0 is success
+func (c *ClientV3) V3FapiaoApply(ctx context.Context, bm gopay.BodyMap) (wxRsp *EmptyRsp, err error) {
+	bm, wxSerialNo, err := c.encryptSensitiveBodyMap(bm)
+	if err != nil {
+		return nil, err
+	}
+	authorization, err := c.authorization(MethodPost, v3FapiaoApplications, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPostWithHeader(ctx, map[string]string{HeaderSerial: wxSerialNo}, bm, v3FapiaoApplications, authorization)
+	if err != nil {
+		return nil, err
+	}
+	return c.fapiaoAccepted(res, si, bs)
+}
+
+// 查询电子发票API
+// 注意：bm 中 fapiao_id、sub_mchid 为 query 参数，可传 nil
+// Code = 0 is success
+func (c *ClientV3) V3FapiaoQuery(ctx context.Context, fapiaoApplyId string, bm gopay.BodyMap) (wxRsp *FapiaoQueryRsp, err error) {
+	uri := fmt.Sprintf(v3FapiaoApplicationsQuery, fapiaoApplyId)
+	if len(bm) > 0 {
+		uri += "?" + bm.EncodeURLParams()
+	}
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(ctx, uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &FapiaoQueryRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(FapiaoQuery)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 冲红电子发票API
+// 注意：受理成功后，冲红结果以 FAPIAO.REVERSED 回调通知或查询电子发票结果为准
+// Code = 0 is success
+func (c *ClientV3) V3FapiaoReverse(ctx context.Context, fapiaoApplyId string, bm gopay.BodyMap) (wxRsp *EmptyRsp, err error) {
+	url := fmt.Sprintf(v3FapiaoApplicationsReverse, fapiaoApplyId)
+	authorization, err := c.authorization(MethodPost, url, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(ctx, bm, url, authorization)
+	if err != nil {
+		return nil, err
+	}
+	return c.fapiaoAccepted(res, si, bs)
+}
+
+// 获取发票下载信息API
+// 注意：bm 中 fapiao_id、sub_mchid 为 query 参数，可传 nil
+// Code = 0 is success
+func (c *ClientV3) V3FapiaoFiles(ctx context.Context, fapiaoApplyId string, bm gopay.BodyMap) (wxRsp *FapiaoFilesRsp, err error) {
+	uri := fmt.Sprintf(v3FapiaoApplicationsFiles, fapiaoApplyId)
+	if len(bm) > 0 {
+		uri += "?" + bm.EncodeURLParams()
+	}
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(ctx, uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &FapiaoFilesRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(FapiaoFiles)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 上传电子发票文件API
+// 注意：仅支持 PDF 文件，文件摘要（SM3）自动计算；返回的 file_media_id 用于 client.V3FapiaoInsertCards()
+// Code = 0 is success
+func (c *ClientV3) V3FapiaoUploadFile(ctx context.Context, file *util.File) (wxRsp *FapiaoUploadFileRsp, err error) {
+	if file == nil || len(file.Content) == 0 {
+		return nil, fmt.Errorf("[%w]: file", gopay.MissParamErr)
+	}
+	digest := sm3.Sum(file.Content)
+	bmFile := make(gopay.BodyMap)
+	bmFile.Set("file_type", "PDF").
+		Set("digest_alogrithm", "SM3").
+		Set("digest", hex.EncodeToString(digest[:]))
+	authorization, err := c.authorization(MethodPost, v3FapiaoUploadFile, bmFile)
+	if err != nil {
+		return nil, err
+	}
+
+	bm := make(gopay.BodyMap)
+	bm.SetBodyMap("meta", func(bm gopay.BodyMap) {
+		bm.Set("file_type", "PDF").
+			Set("digest_alogrithm", "SM3").
+			Set("digest", hex.EncodeToString(digest[:]))
+	}).SetFormFile("file", file)
+	res, si, bs, err := c.doProdPostFile(ctx, bm, v3FapiaoUploadFile, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &FapiaoUploadFileRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(FapiaoUploadFile)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 将电子发票插入微信用户卡包API
+// 注意：受理成功后，插卡结果以 FAPIAO.INSERTED 回调通知为准
+// Code = 0 is success
+func (c *ClientV3) V3FapiaoInsertCards(ctx context.Context, fapiaoApplyId string, bm gopay.BodyMap) (wxRsp *EmptyRsp, err error) {
+	bm, wxSerialNo, err := c.encryptSensitiveBodyMap(bm)
+	if err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf(v3FapiaoInsertCards, fapiaoApplyId)
+	authorization, err := c.authorization(MethodPost, url, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPostWithHeader(ctx, map[string]string{HeaderSerial: wxSerialNo}, bm, url, authorization)
+	if err != nil {
+		return nil, err
+	}
+	return c.fapiaoAccepted(res, si, bs)
+}
+
+// 开票、冲红、插卡等异步受理接口，受理成功返回 202
+func (c *ClientV3) fapiaoAccepted(res *http.Response, si *SignInfo, bs []byte) (wxRsp *EmptyRsp, err error) {
+	wxRsp = &EmptyRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusAccepted && res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
@@ -0,0 +1,178 @@
+package wechat
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/pkg/sm3"
+	"github.com/misu99/gopay/pkg/util"
+)
+
+func TestV3FapiaoConfig(t *testing.T) {
+	srv, c := newAPIServer(t)
+
+	srv.reply(http.StatusOK, `{"card_appid":"wxb1170446a4c0a5a2","card_id":"pjZ8Yt1XGILfDhvwHbbZQ2XgN9Hk"}`)
+	bm := make(gopay.BodyMap)
+	bm.Set("card_appid", "wxb1170446a4c0a5a2").
+		SetBodyMap("card_template_information", func(bm gopay.BodyMap) {
+			bm.Set("logo_url", "https://mmbiz.qpic.cn/mmbiz/iaL1LJM1mF9aRKPZJkmG8xXhiaHqkKSVMMWeN3hLut7X7hicFNjakmxibMLGWpXrEXB33367o7zHN0CwngnQY7zb7g/0")
+		})
+	tplRsp, err := c.V3FapiaoCardTemplate(ctx, bm)
+	if err != nil || tplRsp.Response.CardId != "pjZ8Yt1XGILfDhvwHbbZQ2XgN9Hk" {
+		t.Fatalf("V3FapiaoCardTemplate() = %+v, %v", tplRsp, err)
+	}
+	srv.check(t, http.MethodPost, v3FapiaoCardTemplate)
+
+	srv.reply(http.StatusOK, `{"callback_url":"https://pay.weixin.qq.com/callback","show_fapiao_cell":true}`)
+	bm = make(gopay.BodyMap)
+	bm.Set("callback_url", "https://pay.weixin.qq.com/callback").Set("show_fapiao_cell", true)
+	cfgRsp, err := c.V3FapiaoDevelopmentConfig(ctx, bm)
+	if err != nil || !cfgRsp.Response.ShowFapiaoCell {
+		t.Fatalf("V3FapiaoDevelopmentConfig() = %+v, %v", cfgRsp, err)
+	}
+	srv.check(t, http.MethodPatch, v3FapiaoDevelopmentConfig)
+	if srv.req.GetString("callback_url") != "https://pay.weixin.qq.com/callback" {
+		t.Fatalf("V3FapiaoDevelopmentConfig() request body: %s", srv.raw)
+	}
+	if cfgRsp, err = c.V3FapiaoDevelopmentConfigQuery(ctx); err != nil || cfgRsp.Response.CallbackUrl != "https://pay.weixin.qq.com/callback" {
+		t.Fatalf("V3FapiaoDevelopmentConfigQuery() = %+v, %v", cfgRsp, err)
+	}
+	srv.check(t, http.MethodGet, v3FapiaoDevelopmentConfig)
+
+	srv.reply(http.StatusOK, `{"seller_information":{"taxpayer_id":"202003261233701778","seller_name":"深圳市南山区测试企业"}}`)
+	baseRsp, err := c.V3FapiaoMerchantBaseInfo(ctx)
+	if err != nil || baseRsp.Response.SellerInformation == nil || baseRsp.Response.SellerInformation.TaxpayerId != "202003261233701778" {
+		t.Fatalf("V3FapiaoMerchantBaseInfo() = %+v, %v", baseRsp, err)
+	}
+	srv.check(t, http.MethodGet, v3FapiaoMerchantBaseInfo)
+}
+
+func TestV3FapiaoUserTitle(t *testing.T) {
+	srv, c := newAPIServer(t)
+	// 应答中的手机号、邮箱使用商户公钥加密，client 自动解密
+	phone, err := V3EncryptText("13900000000", []byte(publicPKCS1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	email, err := V3EncryptText("pay@example.com", []byte(publicPKCS1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.reply(http.StatusOK, `{"type":"INDIVIDUAL","name":"张三","phone":"`+phone+`","email":"`+email+`"}`)
+	bm := make(gopay.BodyMap)
+	bm.Set("fapiao_apply_id", "4200000444201910177461284488").Set("scene", "WITH_WECHATPAY")
+	wxRsp, err := c.V3FapiaoUserTitle(ctx, bm)
+	if err != nil || wxRsp.Response.Name != "张三" || wxRsp.Response.Phone != "13900000000" || wxRsp.Response.Email != "pay@example.com" {
+		t.Fatalf("V3FapiaoUserTitle() = %+v, %v", wxRsp, err)
+	}
+	srv.check(t, http.MethodGet, v3FapiaoUserTitle+"?fapiao_apply_id=4200000444201910177461284488&scene=WITH_WECHATPAY")
+
+	// 解密失败返回错误
+	srv.reply(http.StatusOK, `{"type":"INDIVIDUAL","name":"张三","phone":"bad cipher text"}`)
+	if _, err = c.V3FapiaoUserTitle(ctx, bm); err == nil {
+		t.Fatal("V3FapiaoUserTitle() with bad cipher text should return error")
+	}
+}
+
+func TestV3FapiaoApplications(t *testing.T) {
+	srv, c := newAPIServer(t)
+
+	// 开具发票：buyer_information 手机号、邮箱自动加密，受理成功返回 202
+	srv.reply(http.StatusAccepted, "")
+	bm := make(gopay.BodyMap)
+	bm.Set("scene", "WITH_WECHATPAY").
+		Set("fapiao_apply_id", "4200000444201910177461284488").
+		Set("buyer_information", &FapiaoBuyerInformation{Type: "INDIVIDUAL", Name: "张三", Phone: "13900000000"})
+	emptyRsp, err := c.V3FapiaoApply(ctx, bm)
+	if err != nil || emptyRsp.Code != Success {
+		t.Fatalf("V3FapiaoApply() = %+v, %v", emptyRsp, err)
+	}
+	srv.check(t, http.MethodPost, v3FapiaoApplications)
+	buyer, _ := srv.req["buyer_information"].(map[string]any)
+	if text, err := c.V3DecryptText(buyer["phone"].(string)); err != nil || text != "13900000000" || buyer["name"] != "张三" {
+		t.Fatalf("V3FapiaoApply() buyer_information: %v, phone: %s, %v", buyer, text, err)
+	}
+	if srv.header.Get(HeaderSerial) != "SN1" || bm["buyer_information"].(*FapiaoBuyerInformation).Phone != "13900000000" {
+		t.Fatalf("V3FapiaoApply() Wechatpay-Serial: %s, bm: %+v", srv.header.Get(HeaderSerial), bm["buyer_information"])
+	}
+
+	reverse := make(gopay.BodyMap)
+	reverse.Set("reverse_reason", "退款")
+	if emptyRsp, err = c.V3FapiaoReverse(ctx, "4200000444201910177461284488", reverse); err != nil || emptyRsp.Code != Success {
+		t.Fatalf("V3FapiaoReverse() = %+v, %v", emptyRsp, err)
+	}
+	srv.check(t, http.MethodPost, "/v3/new-tax-control-fapiao/fapiao-applications/4200000444201910177461284488/reverse")
+
+	insert := make(gopay.BodyMap)
+	insert.Set("scene", "WITH_WECHATPAY").Set("buyer_information", &FapiaoBuyerInformation{Type: "INDIVIDUAL", Name: "张三"})
+	if emptyRsp, err = c.V3FapiaoInsertCards(ctx, "4200000444201910177461284488", insert); err != nil || emptyRsp.Code != Success {
+		t.Fatalf("V3FapiaoInsertCards() = %+v, %v", emptyRsp, err)
+	}
+	srv.check(t, http.MethodPost, "/v3/new-tax-control-fapiao/fapiao-applications/4200000444201910177461284488/insert-cards")
+
+	srv.reply(http.StatusBadRequest, `{"code":"PARAM_ERROR","message":"发票已冲红"}`)
+	if emptyRsp, err = c.V3FapiaoReverse(ctx, "4200000444201910177461284488", reverse); err != nil || emptyRsp.Code != http.StatusBadRequest || emptyRsp.Error == "" {
+		t.Fatalf("V3FapiaoReverse() = %+v, %v", emptyRsp, err)
+	}
+
+	// 查询、下载信息，bm 为 nil 时不带 query 参数
+	srv.reply(http.StatusOK, `{"total_count":1,"fapiao_information":[{"fapiao_id":"20200701123456","status":"ISSUED"}]}`)
+	queryRsp, err := c.V3FapiaoQuery(ctx, "4200000444201910177461284488", nil)
+	if err != nil || queryRsp.Response.TotalCount != 1 || queryRsp.Response.FapiaoInformation[0].Status != "ISSUED" {
+		t.Fatalf("V3FapiaoQuery() = %+v, %v", queryRsp, err)
+	}
+	srv.check(t, http.MethodGet, "/v3/new-tax-control-fapiao/fapiao-applications/4200000444201910177461284488")
+
+	srv.reply(http.StatusOK, `{"fapiao_download_info_list":[{"fapiao_id":"20200701123456","download_url":"https://pay.wechatpay.cn/fapiao.pdf","status":"ISSUED"}]}`)
+	files := make(gopay.BodyMap)
+	files.Set("fapiao_id", "20200701123456")
+	filesRsp, err := c.V3FapiaoFiles(ctx, "4200000444201910177461284488", files)
+	if err != nil || len(filesRsp.Response.FapiaoDownloadInfoList) != 1 {
+		t.Fatalf("V3FapiaoFiles() = %+v, %v", filesRsp, err)
+	}
+	srv.check(t, http.MethodGet, "/v3/new-tax-control-fapiao/fapiao-applications/4200000444201910177461284488/fapiao-files?fapiao_id=20200701123456")
+
+	// 应答验签失败
+	srv.sign = testSignInfo(t, c, "SN1").HeaderSignature
+	if _, err = c.V3FapiaoFiles(ctx, "4200000444201910177461284488", files); !errors.Is(err, gopay.VerifySignatureErr) {
+		t.Fatalf("V3FapiaoFiles() with bad sign err: %v", err)
+	}
+}
+
+func TestV3FapiaoUploadFile(t *testing.T) {
+	srv, c := newAPIServer(t)
+	if _, err := c.V3FapiaoUploadFile(ctx, nil); !errors.Is(err, gopay.MissParamErr) {
+		t.Fatalf("V3FapiaoUploadFile(nil) err: %v", err)
+	}
+	if _, err := c.V3FapiaoUploadFile(ctx, &util.File{Name: "fapiao.pdf"}); !errors.Is(err, gopay.MissParamErr) {
+		t.Fatalf("V3FapiaoUploadFile(empty) err: %v", err)
+	}
+	if srv.method != "" {
+		t.Fatal("V3FapiaoUploadFile() with invalid file should not send request")
+	}
+
+	srv.reply(http.StatusOK, `{"fapiao_media_id":"ASNFZ4mrze/+3LqYdlQyEA=="}`)
+	content := []byte("%PDF-1.4 fapiao")
+	wxRsp, err := c.V3FapiaoUploadFile(ctx, &util.File{Name: "fapiao.pdf", Content: content})
+	if err != nil || wxRsp.Response.FapiaoMediaId != "ASNFZ4mrze/+3LqYdlQyEA==" {
+		t.Fatalf("V3FapiaoUploadFile() = %+v, %v", wxRsp, err)
+	}
+	srv.check(t, http.MethodPost, v3FapiaoUploadFile)
+	parts := multipartParts(t, srv.header, srv.raw)
+	meta := make(gopay.BodyMap)
+	if err = json.Unmarshal(parts["meta"], &meta); err != nil {
+		t.Fatal(err)
+	}
+	digest := sm3.Sum(content)
+	if meta.GetString("file_type") != "PDF" || meta.GetString("digest_alogrithm") != "SM3" || meta.GetString("digest") != hex.EncodeToString(digest[:]) {
+		t.Fatalf("V3FapiaoUploadFile() meta: %s", parts["meta"])
+	}
+	if !bytes.Equal(parts["file"], content) {
+		t.Fatalf("V3FapiaoUploadFile() file: %s", parts["file"])
+	}
+}
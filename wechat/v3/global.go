@@ -0,0 +1,335 @@
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/pkg/util"
+)
+
+// 跨境支付-APP下单API
+// 注意：amount.currency 为标价币种，如 HKD、USD 等；bm 中未设置 mchid 时，默认使用 client.Mchid
+// Code = 0 is success
+func (c *ClientV3) V3GlobalTransactionApp(ctx context.Context, bm gopay.BodyMap) (wxRsp *PrepayRsp, err error) {
+	if bm.GetString("mchid") == util.NULL {
+		bm.Set("mchid", c.Mchid)
+	}
+	authorization, err := c.authorization(MethodPost, v3GlobalApp, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(ctx, bm, v3GlobalApp, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &PrepayRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(Prepay)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 跨境支付-JSAPI下单API
+// 注意：amount.currency 为标价币种，如 HKD、USD 等；bm 中未设置 mchid 时，默认使用 client.Mchid
+// Code = 0 is success
+func (c *ClientV3) V3GlobalTransactionJsapi(ctx context.Context, bm gopay.BodyMap) (wxRsp *PrepayRsp, err error) {
+	if bm.GetString("mchid") == util.NULL {
+		bm.Set("mchid", c.Mchid)
+	}
+	authorization, err := c.authorization(MethodPost, v3GlobalJsapi, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(ctx, bm, v3GlobalJsapi, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &PrepayRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(Prepay)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 跨境支付-小程序下单API
+// 小程序与 JSAPI 使用相同的下单接口，调起支付参数请使用 client.PaySignOfApplet() 获取
+// 注意：amount.currency 为标价币种，如 HKD、USD 等；bm 中未设置 mchid 时，默认使用 client.Mchid
+// Code = 0 is success
+func (c *ClientV3) V3GlobalTransactionMiniProgram(ctx context.Context, bm gopay.BodyMap) (wxRsp *PrepayRsp, err error) {
+	if bm.GetString("mchid") == util.NULL {
+		bm.Set("mchid", c.Mchid)
+	}
+	authorization, err := c.authorization(MethodPost, v3GlobalJsapi, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(ctx, bm, v3GlobalJsapi, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &PrepayRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(Prepay)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 跨境支付-Native下单API
+// 注意：amount.currency 为标价币种，如 HKD、USD 等；bm 中未设置 mchid 时，默认使用 client.Mchid
+// Code = 0 is success
+func (c *ClientV3) V3GlobalTransactionNative(ctx context.Context, bm gopay.BodyMap) (wxRsp *NativeRsp, err error) {
+	if bm.GetString("mchid") == util.NULL {
+		bm.Set("mchid", c.Mchid)
+	}
+	authorization, err := c.authorization(MethodPost, v3GlobalNative, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(ctx, bm, v3GlobalNative, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &NativeRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(Native)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 跨境支付-H5下单API
+// 注意：amount.currency 为标价币种，如 HKD、USD 等；bm 中未设置 mchid 时，默认使用 client.Mchid
+// Code = 0 is success
+func (c *ClientV3) V3GlobalTransactionH5(ctx context.Context, bm gopay.BodyMap) (wxRsp *H5Rsp, err error) {
+	if bm.GetString("mchid") == util.NULL {
+		bm.Set("mchid", c.Mchid)
+	}
+	authorization, err := c.authorization(MethodPost, v3GlobalH5, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(ctx, bm, v3GlobalH5, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &H5Rsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(H5Url)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 跨境支付-查询订单API
+// 注意：bm 中参数为 query 参数，未设置 mchid 时，默认使用 client.Mchid；机构模式需传 sub_mchid
+// Code = 0 is success
+func (c *ClientV3) V3GlobalTransactionQueryOrder(ctx context.Context, orderNoType OrderNoType, orderNo string, bm gopay.BodyMap) (wxRsp *GlobalQueryOrderRsp, err error) {
+	if bm == nil {
+		bm = make(gopay.BodyMap)
+	}
+	if bm.GetString("mchid") == util.NULL {
+		bm.Set("mchid", c.Mchid)
+	}
+	var uri string
+	switch orderNoType {
+	case TransactionId:
+		uri = fmt.Sprintf(v3GlobalQueryOrderTransactionId, orderNo) + "?" + bm.EncodeURLParams()
+	case OutTradeNo:
+		uri = fmt.Sprintf(v3GlobalQueryOrderOutTradeNo, orderNo) + "?" + bm.EncodeURLParams()
+	default:
+		return nil, errors.New("unsupported order number type")
+	}
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(ctx, uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &GlobalQueryOrderRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(GlobalQueryOrder)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 跨境支付-关闭订单API
+// 注意：bm 未设置 mchid 时，默认使用 client.Mchid；机构模式需传 sub_mchid
+// Code = 0 is success
+func (c *ClientV3) V3GlobalTransactionCloseOrder(ctx context.Context, outTradeNo string, bm gopay.BodyMap) (wxRsp *CloseOrderRsp, err error) {
+	if bm == nil {
+		bm = make(gopay.BodyMap)
+	}
+	if bm.GetString("mchid") == util.NULL {
+		bm.Set("mchid", c.Mchid)
+	}
+	url := fmt.Sprintf(v3GlobalCloseOrder, outTradeNo)
+	authorization, err := c.authorization(MethodPost, url, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(ctx, bm, url, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &CloseOrderRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusNoContent {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 跨境支付-申请退款API
+// 注意：amount.currency 需与原订单标价币种一致；bm 中未设置 mchid 时，默认使用 client.Mchid
+// Code = 0 is success
+func (c *ClientV3) V3GlobalRefund(ctx context.Context, bm gopay.BodyMap) (wxRsp *GlobalRefundRsp, err error) {
+	if bm.GetString("mchid") == util.NULL {
+		bm.Set("mchid", c.Mchid)
+	}
+	authorization, err := c.authorization(MethodPost, v3GlobalRefund, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(ctx, bm, v3GlobalRefund, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &GlobalRefundRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(GlobalRefund)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 跨境支付-查询单笔退款API
+// 注意：bm 中参数为 query 参数，未设置 mchid 时，默认使用 client.Mchid；机构模式需传 sub_mchid
+// Code = 0 is success
+func (c *ClientV3) V3GlobalRefundQuery(ctx context.Context, outRefundNo string, bm gopay.BodyMap) (wxRsp *GlobalRefundRsp, err error) {
+	if bm == nil {
+		bm = make(gopay.BodyMap)
+	}
+	if bm.GetString("mchid") == util.NULL {
+		bm.Set("mchid", c.Mchid)
+	}
+	uri := fmt.Sprintf(v3GlobalRefundQuery, outRefundNo) + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(ctx, uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &GlobalRefundRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(GlobalRefund)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 跨境支付-查询汇率API
+// bm 参数：fx_type（SETTLEMENT_RATE：结算汇率）、currency_type（币种，如 USD）、date（日期，格式 YYYYMMDD）
+// 注意：bm 中参数为 query 参数
+// Code = 0 is success
+func (c *ClientV3) V3GlobalExchangeRate(ctx context.Context, bm gopay.BodyMap) (wxRsp *GlobalExchangeRateRsp, err error) {
+	uri := v3GlobalExchangeRate + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(ctx, uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &GlobalExchangeRateRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(GlobalExchangeRate)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 跨境支付-查询结算信息API
+// bm 参数：settle_state（SETTLED：已结算，UNSETTLED：未结算）、offset、limit、date_start、date_end
+// 注意：bm 中参数为 query 参数
+// Code = 0 is success
+func (c *ClientV3) V3GlobalSettlement(ctx context.Context, bm gopay.BodyMap) (wxRsp *GlobalSettlementRsp, err error) {
+	uri := v3GlobalSettlement + "?" + bm.EncodeURLParams()
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(ctx, uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &GlobalSettlementRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(GlobalSettlement)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
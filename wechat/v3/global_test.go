@@ -0,0 +1,172 @@
+package wechat
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/misu99/gopay"
+)
+
+func TestSetCountry(t *testing.T) {
+	c := &ClientV3{}
+	if c.BaseUrl() != v3BaseUrlCh {
+		t.Fatalf("default BaseUrl() = %s", c.BaseUrl())
+	}
+	for _, tt := range []struct {
+		country Country
+		want    string
+	}{
+		{China, v3BaseUrlCh},
+		{China2, v3BaseUrlCh2},
+		{SoutheastAsia, v3BaseUrlHk},
+		{Other, v3BaseUrlUs},
+		{Country(0), v3BaseUrlCh},
+	} {
+		if got := c.SetCountry(tt.country).BaseUrl(); got != tt.want {
+			t.Errorf("SetCountry(%d).BaseUrl() = %s, want %s", tt.country, got, tt.want)
+		}
+	}
+}
+
+func TestV3GlobalTransaction(t *testing.T) {
+	srv, c := newAPIServer(t)
+	newOrder := func() gopay.BodyMap {
+		bm := make(gopay.BodyMap)
+		bm.Set("appid", "wxdace645e0bc2cXXX").
+			Set("description", "Image形象店-深圳腾大-QQ公仔").
+			Set("out_trade_no", "1217752501201407033233368018").
+			Set("notify_url", "https://wxpay.wxutil.com/pub_v2/pay/notify.v2.php").
+			SetBodyMap("amount", func(bm gopay.BodyMap) {
+				bm.Set("total", 100).Set("currency", "HKD")
+			})
+		return bm
+	}
+
+	// 未设置 mchid 时默认使用 client.Mchid
+	srv.reply(http.StatusOK, `{"prepay_id":"wx26112221580621e9b071c00d9e093b0000"}`)
+	prepayRsp, err := c.V3GlobalTransactionApp(ctx, newOrder())
+	if err != nil || prepayRsp.Response.PrepayId != "wx26112221580621e9b071c00d9e093b0000" {
+		t.Fatalf("V3GlobalTransactionApp() = %+v, %v", prepayRsp, err)
+	}
+	srv.check(t, http.MethodPost, v3GlobalApp)
+	if srv.req.GetString("mchid") != c.Mchid || srv.req.GetString("out_trade_no") != "1217752501201407033233368018" {
+		t.Fatalf("V3GlobalTransactionApp() request body: %s", srv.raw)
+	}
+	// 机构模式传入的 mchid 保持不变
+	bm := newOrder()
+	bm.Set("mchid", "1900000100").Set("sub_mchid", "1900000109")
+	if _, err = c.V3GlobalTransactionJsapi(ctx, bm); err != nil {
+		t.Fatal(err)
+	}
+	srv.check(t, http.MethodPost, v3GlobalJsapi)
+	if srv.req.GetString("mchid") != "1900000100" || srv.req.GetString("sub_mchid") != "1900000109" {
+		t.Fatalf("V3GlobalTransactionJsapi() request body: %s", srv.raw)
+	}
+	if _, err = c.V3GlobalTransactionMiniProgram(ctx, newOrder()); err != nil {
+		t.Fatal(err)
+	}
+	srv.check(t, http.MethodPost, v3GlobalJsapi)
+
+	srv.reply(http.StatusOK, `{"code_url":"weixin://wxpay/bizpayurl?pr=p4lpSuKzz"}`)
+	nativeRsp, err := c.V3GlobalTransactionNative(ctx, newOrder())
+	if err != nil || nativeRsp.Response.CodeUrl != "weixin://wxpay/bizpayurl?pr=p4lpSuKzz" {
+		t.Fatalf("V3GlobalTransactionNative() = %+v, %v", nativeRsp, err)
+	}
+	srv.check(t, http.MethodPost, v3GlobalNative)
+
+	srv.reply(http.StatusOK, `{"h5_url":"https://wx.tenpay.com/cgi-bin/mmpayweb-bin/checkmweb?prepay_id=wx2916263004719461949c84457c735b0000"}`)
+	h5Rsp, err := c.V3GlobalTransactionH5(ctx, newOrder())
+	if err != nil || h5Rsp.Response.H5Url == "" {
+		t.Fatalf("V3GlobalTransactionH5() = %+v, %v", h5Rsp, err)
+	}
+	srv.check(t, http.MethodPost, v3GlobalH5)
+
+	// 查询订单，金额为多币种及汇率信息
+	srv.reply(http.StatusOK, `{"id":"1008450740201411110005820873","mchid":"1900000001","out_trade_no":"1217752501201407033233368018","transaction_id":"1008450740201411110005820873","trade_type":"APP","trade_state":"SUCCESS","amount":{"total":100,"currency":"HKD","payer_total":88,"payer_currency":"CNY","exchange_rate":{"type":"SETTLEMENT_RATE","rate":88000000}}}`)
+	queryRsp, err := c.V3GlobalTransactionQueryOrder(ctx, OutTradeNo, "1217752501201407033233368018", nil)
+	if err != nil || queryRsp.Response.TradeState != "SUCCESS" || queryRsp.Response.Amount.ExchangeRate.Rate != 88000000 || queryRsp.Response.Amount.PayerCurrency != "CNY" {
+		t.Fatalf("V3GlobalTransactionQueryOrder() = %+v, %v", queryRsp, err)
+	}
+	srv.check(t, http.MethodGet, "/v3/global/transactions/out-trade-no/1217752501201407033233368018?mchid=1900000001")
+	query := make(gopay.BodyMap)
+	query.Set("mchid", "1900000100").Set("sub_mchid", "1900000109")
+	if _, err = c.V3GlobalTransactionQueryOrder(ctx, TransactionId, "1008450740201411110005820873", query); err != nil {
+		t.Fatal(err)
+	}
+	srv.check(t, http.MethodGet, "/v3/global/transactions/id/1008450740201411110005820873?mchid=1900000100&sub_mchid=1900000109")
+	srv.method = ""
+	if _, err = c.V3GlobalTransactionQueryOrder(ctx, OrderNoType(0), "1217752501201407033233368018", nil); err == nil || srv.method != "" {
+		t.Fatalf("V3GlobalTransactionQueryOrder() with unsupported type err: %v, request: %s", err, srv.method)
+	}
+
+	srv.reply(http.StatusNoContent, "")
+	closeRsp, err := c.V3GlobalTransactionCloseOrder(ctx, "1217752501201407033233368018", nil)
+	if err != nil || closeRsp.Code != Success {
+		t.Fatalf("V3GlobalTransactionCloseOrder() = %+v, %v", closeRsp, err)
+	}
+	srv.check(t, http.MethodPost, "/v3/global/transactions/out-trade-no/1217752501201407033233368018/close")
+	if srv.req.GetString("mchid") != c.Mchid {
+		t.Fatalf("V3GlobalTransactionCloseOrder() request body: %s", srv.raw)
+	}
+
+	// 业务错误、应答验签失败
+	srv.reply(http.StatusBadRequest, `{"code":"INVALID_REQUEST","message":"币种不支持"}`)
+	if prepayRsp, err = c.V3GlobalTransactionApp(ctx, newOrder()); err != nil || prepayRsp.Code != http.StatusBadRequest || prepayRsp.Error == "" {
+		t.Fatalf("V3GlobalTransactionApp() = %+v, %v", prepayRsp, err)
+	}
+	srv.reply(http.StatusOK, `{"prepay_id":"wx26112221580621e9b071c00d9e093b0000"}`)
+	srv.sign = testSignInfo(t, c, "SN1").HeaderSignature
+	if _, err = c.V3GlobalTransactionApp(ctx, newOrder()); !errors.Is(err, gopay.VerifySignatureErr) {
+		t.Fatalf("V3GlobalTransactionApp() with bad sign err: %v", err)
+	}
+}
+
+func TestV3GlobalRefund(t *testing.T) {
+	srv, c := newAPIServer(t)
+	refund := `{"id":"50000000382019052709732678859","out_refund_no":"1217752501201407033233368018","transaction_id":"1008450740201411110005820873","out_trade_no":"1217752501201407033233368018","create_time":"2018-06-08T10:34:56+08:00","status":"PROCESSING","amount":{"total":100,"refund":100,"currency":"HKD","payer_refund":88,"payer_currency":"CNY"}}`
+	srv.reply(http.StatusOK, refund)
+
+	bm := make(gopay.BodyMap)
+	bm.Set("transaction_id", "1008450740201411110005820873").
+		Set("out_refund_no", "1217752501201407033233368018").
+		SetBodyMap("amount", func(bm gopay.BodyMap) {
+			bm.Set("refund", 100).Set("total", 100).Set("currency", "HKD")
+		})
+	wxRsp, err := c.V3GlobalRefund(ctx, bm)
+	if err != nil || wxRsp.Response.Status != "PROCESSING" || wxRsp.Response.Amount.PayerRefund != 88 {
+		t.Fatalf("V3GlobalRefund() = %+v, %v", wxRsp, err)
+	}
+	srv.check(t, http.MethodPost, v3GlobalRefund)
+	if srv.req.GetString("mchid") != c.Mchid {
+		t.Fatalf("V3GlobalRefund() request body: %s", srv.raw)
+	}
+
+	if wxRsp, err = c.V3GlobalRefundQuery(ctx, "1217752501201407033233368018", nil); err != nil || wxRsp.Response.Id != "50000000382019052709732678859" {
+		t.Fatalf("V3GlobalRefundQuery() = %+v, %v", wxRsp, err)
+	}
+	srv.check(t, http.MethodGet, "/v3/global/refunds/out-refund-no/1217752501201407033233368018?mchid=1900000001")
+
+	srv.reply(http.StatusOK, `{"fx_type":"SETTLEMENT_RATE","currency_type":"USD","rate_time":"2018-06-08T10:34:56+08:00","rate_value":652220000}`)
+	rate := make(gopay.BodyMap)
+	rate.Set("fx_type", "SETTLEMENT_RATE").Set("currency_type", "USD").Set("date", "20180608")
+	rateRsp, err := c.V3GlobalExchangeRate(ctx, rate)
+	if err != nil || rateRsp.Response.RateValue != 652220000 {
+		t.Fatalf("V3GlobalExchangeRate() = %+v, %v", rateRsp, err)
+	}
+	srv.check(t, http.MethodGet, v3GlobalExchangeRate+"?currency_type=USD&date=20180608&fx_type=SETTLEMENT_RATE")
+
+	srv.reply(http.StatusOK, `{"total_count":1,"offset":0,"limit":10,"data":[{"settle_start_date":"20180601","settle_end_date":"20180607","settle_state":"SETTLED","settle_fee":10000,"settle_currency":"USD"}]}`)
+	settle := make(gopay.BodyMap)
+	settle.Set("settle_state", "SETTLED").Set("offset", 0).Set("limit", 10)
+	settleRsp, err := c.V3GlobalSettlement(ctx, settle)
+	if err != nil || settleRsp.Response.TotalCount != 1 || settleRsp.Response.Data[0].SettleFee != 10000 {
+		t.Fatalf("V3GlobalSettlement() = %+v, %v", settleRsp, err)
+	}
+	srv.check(t, http.MethodGet, v3GlobalSettlement+"?limit=10&offset=0&settle_state=SETTLED")
+
+	srv.reply(http.StatusNotFound, `{"code":"RESOURCE_NOT_EXISTS","message":"退款单不存在"}`)
+	if wxRsp, err = c.V3GlobalRefundQuery(ctx, "1217752501201407033233368018", nil); err != nil || wxRsp.Code != http.StatusNotFound || wxRsp.Error == "" {
+		t.Fatalf("V3GlobalRefundQuery() = %+v, %v", wxRsp, err)
+	}
+}
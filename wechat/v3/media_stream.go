@@ -0,0 +1,174 @@
+package wechat
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/pkg/util"
+)
+
+const (
+	mediaImageMaxSize = 2 << 20 // 图片大小限制 2MB
+	mediaVideoMaxSize = 5 << 20 // 视频大小限制 5MB
+)
+
+// 图片上传API（流式上传）
+// 注意：图片不能超过2MB，sha256 上传时自动计算
+// file：util.NewFileReader() 或 util.OpenFile() 初始化
+// Code = 0 is success
+// 商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter2_1_1.shtml
+// 服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/apis/chapter2_1_1.shtml
+func (c *ClientV3) V3MediaUploadImageStream(ctx context.Context, file *util.FileReader) (wxRsp *MediaUploadRsp, err error) {
+	return c.mediaUploadStream(ctx, v3MediaUploadImage, file, mediaImageMaxSize)
+}
+
+// 视频上传API（流式上传）
+// 注意：媒体视频只支持avi、wmv、mpeg、mp4、mov、mkv、flv、f4v、m4v、rmvb格式，文件大小不能超过5M，sha256 上传时自动计算
+// file：util.NewFileReader() 或 util.OpenFile() 初始化
+// Code = 0 is success
+// 商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter2_1_2.shtml
+// 服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/apis/chapter2_1_2.shtml
+func (c *ClientV3) V3MediaUploadVideoStream(ctx context.Context, file *util.FileReader) (wxRsp *MediaUploadRsp, err error) {
+	return c.mediaUploadStream(ctx, v3MediaUploadVideo, file, mediaVideoMaxSize)
+}
+
+// 商户上传反馈图片API（流式上传）
+// 注意：图片不能超过2MB，sha256 上传时自动计算
+// file：util.NewFileReader() 或 util.OpenFile() 初始化
+// Code = 0 is success
+// 商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter10_2_10.shtml
+// 服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/apis/chapter10_2_10.shtml
+func (c *ClientV3) V3ComplaintUploadImageStream(ctx context.Context, file *util.FileReader) (wxRsp *MediaUploadRsp, err error) {
+	return c.mediaUploadStream(ctx, v3ComplaintUploadImage, file, mediaImageMaxSize)
+}
+
+// 图片上传（营销专用）（流式上传）
+// 注意：图片不能超过2MB，sha256 上传时自动计算
+// file：util.NewFileReader() 或 util.OpenFile() 初始化
+// Code = 0 is success
+// 商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter9_0_1.shtml
+// 服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/apis/chapter9_0_1.shtml
+func (c *ClientV3) V3FavorMediaUploadImageStream(ctx context.Context, file *util.FileReader) (wxRsp *MarketMediaUploadRsp, err error) {
+	res, si, bs, err := c.doProdPostFileStream(ctx, v3FavorMediaUploadImage, file, mediaImageMaxSize)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &MarketMediaUploadRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(MarketMediaUpload)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+func (c *ClientV3) mediaUploadStream(ctx context.Context, path string, file *util.FileReader, maxSize int64) (wxRsp *MediaUploadRsp, err error) {
+	res, si, bs, err := c.doProdPostFileStream(ctx, path, file, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &MediaUploadRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(MediaUpload)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 计算 sha256 后以流式 multipart 上传文件
+func (c *ClientV3) doProdPostFileStream(ctx context.Context, path string, file *util.FileReader, maxSize int64) (res *http.Response, si *SignInfo, bs []byte, err error) {
+	fileSha256, upload, cleanup, err := uploadFileSha256(file, maxSize)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer cleanup()
+
+	bmFile := make(gopay.BodyMap)
+	bmFile.Set("filename", file.Name).Set("sha256", fileSha256)
+	authorization, err := c.authorization(MethodPost, path, bmFile)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	bm := make(gopay.BodyMap)
+	bm.SetBodyMap("meta", func(bm gopay.BodyMap) {
+		bm.Set("filename", file.Name).Set("sha256", fileSha256)
+	}).SetFormFileReader("file", upload)
+	return c.doProdPostFile(ctx, bm, path, authorization)
+}
+
+// 流式计算上传文件的 sha256，并在上传前检查文件大小限制
+// file.Reader 实现 io.Seeker 时，计算后回到起始位置重新读取；否则边计算边写入临时文件，upload 指向该临时文件
+// 上传完成后需调用 cleanup 删除临时文件
+func uploadFileSha256(file *util.FileReader, maxSize int64) (fileSha256 string, upload *util.FileReader, cleanup func(), err error) {
+	cleanup = func() {}
+	if file == nil || file.Reader == nil {
+		return "", nil, cleanup, fmt.Errorf("[%w]: file", gopay.MissParamErr)
+	}
+	if file.Size > maxSize {
+		return "", nil, cleanup, fmt.Errorf("file size %d exceeds the limit of %d bytes", file.Size, maxSize)
+	}
+	h := sha256.New()
+	if seeker, ok := file.Reader.(io.Seeker); ok {
+		start, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return "", nil, cleanup, err
+		}
+		end, err := seeker.Seek(0, io.SeekEnd)
+		if err != nil {
+			return "", nil, cleanup, err
+		}
+		if size := end - start; size > maxSize {
+			return "", nil, cleanup, fmt.Errorf("file size %d exceeds the limit of %d bytes", size, maxSize)
+		}
+		if _, err = seeker.Seek(start, io.SeekStart); err != nil {
+			return "", nil, cleanup, err
+		}
+		n, err := io.Copy(h, file.Reader)
+		if err != nil {
+			return "", nil, cleanup, err
+		}
+		if _, err = seeker.Seek(start, io.SeekStart); err != nil {
+			return "", nil, cleanup, err
+		}
+		return hex.EncodeToString(h.Sum(nil)), util.NewFileReader(file.Name, file.Reader, n), cleanup, nil
+	}
+
+	tmp, err := os.CreateTemp("", "gopay-upload-*")
+	if err != nil {
+		return "", nil, cleanup, err
+	}
+	cleanup = func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}
+	n, err := io.Copy(io.MultiWriter(tmp, h), io.LimitReader(file.Reader, maxSize+1))
+	if err != nil {
+		cleanup()
+		return "", nil, func() {}, err
+	}
+	if n > maxSize {
+		cleanup()
+		return "", nil, func() {}, fmt.Errorf("file size exceeds the limit of %d bytes", maxSize)
+	}
+	if _, err = tmp.Seek(0, io.SeekStart); err != nil {
+		cleanup()
+		return "", nil, func() {}, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), util.NewFileReader(file.Name, tmp, n), cleanup, nil
+}
@@ -0,0 +1,133 @@
+package wechat
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/pkg/util"
+)
+
+// multipartParts 解析 multipart 请求，返回各字段内容
+func multipartParts(t *testing.T, header http.Header, body []byte) map[string][]byte {
+	t.Helper()
+	_, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := make(map[string][]byte)
+	r := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		p, err := r.NextPart()
+		if err == io.EOF {
+			return parts
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts[p.FormName()], _ = io.ReadAll(p)
+	}
+}
+
+// checkUploadMeta 校验 multipart 请求中 meta 的 filename、sha256 及 file 内容
+func checkUploadMeta(t *testing.T, srv *apiServer, name string, content []byte) {
+	t.Helper()
+	parts := multipartParts(t, srv.header, srv.raw)
+	meta := make(gopay.BodyMap)
+	if err := json.Unmarshal(parts["meta"], &meta); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(content)
+	if meta.GetString("filename") != name || meta.GetString("sha256") != hex.EncodeToString(sum[:]) {
+		t.Fatalf("upload meta: %s", parts["meta"])
+	}
+	if !bytes.Equal(parts["file"], content) {
+		t.Fatalf("upload file: %d bytes, want %d bytes", len(parts["file"]), len(content))
+	}
+}
+
+func TestV3MediaUploadStream(t *testing.T) {
+	srv, c := newAPIServer(t)
+	srv.reply(http.StatusOK, `{"media_id":"H1ihR9JUtVj-J7CJqBUY5ZOrG_Je75H-rKhq8Uffo8i6dPFjNnXlpZqKAT0LpTZxhZh7yH8dyZtDbBdH2hpXSufTyibuvHHd-1R7J9f4WvJ4"}`)
+	content := bytes.Repeat([]byte("gopay"), 1024)
+
+	// 实现 io.Seeker：从当前位置计算摘要后回到该位置上传
+	r := bytes.NewReader(append([]byte("skip"), content...))
+	_, _ = r.Seek(4, io.SeekStart)
+	wxRsp, err := c.V3MediaUploadImageStream(ctx, util.NewFileReader("logo.png", r, -1))
+	if err != nil || wxRsp.Response.MediaId == "" {
+		t.Fatalf("V3MediaUploadImageStream() = %+v, %v", wxRsp, err)
+	}
+	srv.check(t, http.MethodPost, v3MediaUploadImage)
+	checkUploadMeta(t, srv, "logo.png", content)
+
+	// 未实现 io.Seeker：经临时文件计算摘要后上传
+	if wxRsp, err = c.V3MediaUploadVideoStream(ctx, util.NewFileReader("video.mp4", io.MultiReader(bytes.NewReader(content)), -1)); err != nil {
+		t.Fatal(err)
+	}
+	srv.check(t, http.MethodPost, v3MediaUploadVideo)
+	checkUploadMeta(t, srv, "video.mp4", content)
+
+	// 通过文件路径上传
+	path := filepath.Join(t.TempDir(), "complaint.jpg")
+	if err = os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	file, err := util.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if _, err = c.V3ComplaintUploadImageStream(ctx, file); err != nil {
+		t.Fatal(err)
+	}
+	srv.check(t, http.MethodPost, v3ComplaintUploadImage)
+	checkUploadMeta(t, srv, "complaint.jpg", content)
+
+	srv.reply(http.StatusOK, `{"media_url":"https://wxpaylogo.qpic.cn/wxpaylogo/xxxxx/0"}`)
+	marketRsp, err := c.V3FavorMediaUploadImageStream(ctx, util.NewFileReader("favor.png", bytes.NewReader(content), int64(len(content))))
+	if err != nil || marketRsp.Response.MediaUrl == "" {
+		t.Fatalf("V3FavorMediaUploadImageStream() = %+v, %v", marketRsp, err)
+	}
+	srv.check(t, http.MethodPost, v3FavorMediaUploadImage)
+
+	srv.reply(http.StatusBadRequest, `{"code":"PARAM_ERROR","message":"图片格式不支持"}`)
+	if wxRsp, err = c.V3MediaUploadImageStream(ctx, util.NewFileReader("logo.png", bytes.NewReader(content), -1)); err != nil || wxRsp.Code != http.StatusBadRequest || wxRsp.Error == "" {
+		t.Fatalf("V3MediaUploadImageStream() = %+v, %v", wxRsp, err)
+	}
+}
+
+func TestV3MediaUploadStreamLimit(t *testing.T) {
+	srv, c := newAPIServer(t)
+	big := make([]byte, mediaImageMaxSize+1)
+	for _, tt := range []struct {
+		name string
+		file *util.FileReader
+		err  error
+	}{
+		{"nil", nil, gopay.MissParamErr},
+		{"nil reader", util.NewFileReader("logo.png", nil, -1), gopay.MissParamErr},
+		{"size", util.NewFileReader("logo.png", bytes.NewReader(nil), mediaImageMaxSize+1), nil},
+		{"seeker", util.NewFileReader("logo.png", bytes.NewReader(big), -1), nil},
+		{"reader", util.NewFileReader("logo.png", io.MultiReader(bytes.NewReader(big)), -1), nil},
+	} {
+		_, err := c.V3MediaUploadImageStream(ctx, tt.file)
+		if tt.err != nil && !errors.Is(err, tt.err) || tt.err == nil && (err == nil || !strings.Contains(err.Error(), "exceeds the limit")) {
+			t.Errorf("%s: V3MediaUploadImageStream() err: %v", tt.name, err)
+		}
+	}
+	if srv.method != "" {
+		t.Fatalf("invalid file should not send request, got %s %s", srv.method, srv.uri)
+	}
+}
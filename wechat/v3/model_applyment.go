@@ -40,6 +40,30 @@ type Apply4SubQuerySettlementRsp struct {
 	Error    string                    `json:"-"`
 }
 
+// 商户开户意愿确认提交申请单 Rsp
+type Apply4SubjectSubmitRsp struct {
+	Code     int                  `json:"-"`
+	SignInfo *SignInfo            `json:"-"`
+	Response *Apply4SubjectSubmit `json:"response,omitempty"`
+	Error    string               `json:"-"`
+}
+
+// 商户开户意愿确认查询申请单审核结果 Rsp
+type Apply4SubjectQueryStateRsp struct {
+	Code     int                      `json:"-"`
+	SignInfo *SignInfo                `json:"-"`
+	Response *Apply4SubjectQueryState `json:"response,omitempty"`
+	Error    string                   `json:"-"`
+}
+
+// 获取商户开户意愿确认状态 Rsp
+type Apply4SubjectAuthorizeStateRsp struct {
+	Code     int                          `json:"-"`
+	SignInfo *SignInfo                    `json:"-"`
+	Response *Apply4SubjectAuthorizeState `json:"response,omitempty"`
+	Error    string                       `json:"-"`
+}
+
 // =========================================================分割=========================================================
 
 type Apply4SubSubmit struct {
@@ -87,3 +111,57 @@ type V3Apply4SubMerchantsApplication struct {
 	VerifyFailReason string `json:"verify_fail_reason"` // 汇款验证失败原因
 	VerifyFinishTime string `json:"verify_finish_time"` // 审核结果更新时间
 }
+
+// 进件 超级管理员信息（contact_info），标记 sensitive 的字段请求时自动加密
+type ContactInfo struct {
+	ContactType                 string `json:"contact_type,omitempty"`                            // 超级管理员类型
+	ContactName                 string `json:"contact_name,omitempty" sensitive:"true"`           // 超级管理员姓名
+	ContactIdDocType            string `json:"contact_id_doc_type,omitempty"`                     // 超级管理员证件类型
+	ContactIdNumber             string `json:"contact_id_number,omitempty" sensitive:"true"`      // 超级管理员身份证件号码
+	ContactIdCardNumber         string `json:"contact_id_card_number,omitempty" sensitive:"true"` // 超级管理员身份证件号码（二级商户进件）
+	ContactIdDocCopy            string `json:"contact_id_doc_copy,omitempty"`                     // 超级管理员证件正面照片
+	ContactIdDocCopyBack        string `json:"contact_id_doc_copy_back,omitempty"`                // 超级管理员证件反面照片
+	ContactPeriodBegin          string `json:"contact_period_begin,omitempty"`                    // 超级管理员证件有效期开始时间
+	ContactPeriodEnd            string `json:"contact_period_end,omitempty"`                      // 超级管理员证件有效期结束时间
+	BusinessAuthorizationLetter string `json:"business_authorization_letter,omitempty"`           // 业务办理授权函
+	Openid                      string `json:"openid,omitempty" sensitive:"true"`                 // 超级管理员微信OpenID
+	MobilePhone                 string `json:"mobile_phone,omitempty" sensitive:"true"`           // 联系手机
+	ContactEmail                string `json:"contact_email,omitempty" sensitive:"true"`          // 联系邮箱
+}
+
+// 进件 身份证信息（id_card_info），标记 sensitive 的字段请求时自动加密
+type IdCardInfo struct {
+	IdCardCopy      string `json:"id_card_copy,omitempty"`                     // 身份证人像面照片
+	IdCardNational  string `json:"id_card_national,omitempty"`                 // 身份证国徽面照片
+	IdCardName      string `json:"id_card_name,omitempty" sensitive:"true"`    // 身份证姓名
+	IdCardNumber    string `json:"id_card_number,omitempty" sensitive:"true"`  // 身份证号码
+	IdCardAddress   string `json:"id_card_address,omitempty" sensitive:"true"` // 身份证居住地址
+	CardPeriodBegin string `json:"card_period_begin,omitempty"`                // 身份证有效期开始时间
+	CardPeriodEnd   string `json:"card_period_end,omitempty"`                  // 身份证有效期结束时间
+}
+
+// 进件 结算银行账户（bank_account_info / account_info），标记 sensitive 的字段请求时自动加密
+type BankAccountInfo struct {
+	BankAccountType string `json:"bank_account_type,omitempty"`               // 账户类型
+	AccountName     string `json:"account_name,omitempty" sensitive:"true"`   // 开户名称
+	AccountBank     string `json:"account_bank,omitempty"`                    // 开户银行
+	BankAddressCode string `json:"bank_address_code,omitempty"`               // 开户银行省市编码
+	BankBranchId    string `json:"bank_branch_id,omitempty"`                  // 开户银行联行号
+	BankName        string `json:"bank_name,omitempty"`                       // 开户银行全称（含支行）
+	AccountNumber   string `json:"account_number,omitempty" sensitive:"true"` // 银行账号
+}
+
+type Apply4SubjectSubmit struct {
+	ApplymentId int64 `json:"applyment_id"` // 微信支付申请单号
+}
+
+type Apply4SubjectQueryState struct {
+	ApplymentState string `json:"applyment_state"`         // 申请单状态：APPLYMENT_STATE_EDITTING、APPLYMENT_STATE_WAITTING_FOR_AUDIT、APPLYMENT_STATE_WAITTING_FOR_CONFIRM_CONTACT、APPLYMENT_STATE_WAITTING_FOR_CONFIRM_LEGALPERSON、APPLYMENT_STATE_PASSED、APPLYMENT_STATE_REJECTED、APPLYMENT_STATE_FREEZED、APPLYMENT_STATE_CANCELED
+	QrcodeData     string `json:"qrcode_data,omitempty"`   // 小程序码图片，base64编码
+	RejectParam    string `json:"reject_param,omitempty"`  // 驳回参数
+	RejectReason   string `json:"reject_reason,omitempty"` // 驳回原因
+}
+
+type Apply4SubjectAuthorizeState struct {
+	AuthorizeState string `json:"authorize_state"` // 授权状态：AUTHORIZE_STATE_UNAUTHORIZED：未授权，AUTHORIZE_STATE_AUTHORIZED：已授权
+}
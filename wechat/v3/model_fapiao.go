@@ -0,0 +1,208 @@
+package wechat
+
+// 创建电子发票卡券模板 Rsp
+type FapiaoCardTemplateRsp struct {
+	Code     int                 `json:"-"`
+	SignInfo *SignInfo           `json:"-"`
+	Response *FapiaoCardTemplate `json:"response,omitempty"`
+	Error    string              `json:"-"`
+}
+
+// 配置、查询开发选项 Rsp
+type FapiaoDevelopmentConfigRsp struct {
+	Code     int                      `json:"-"`
+	SignInfo *SignInfo                `json:"-"`
+	Response *FapiaoDevelopmentConfig `json:"response,omitempty"`
+	Error    string                   `json:"-"`
+}
+
+// 查询商户开票基础信息 Rsp
+type FapiaoMerchantBaseInfoRsp struct {
+	Code     int                     `json:"-"`
+	SignInfo *SignInfo               `json:"-"`
+	Response *FapiaoMerchantBaseInfo `json:"response,omitempty"`
+	Error    string                  `json:"-"`
+}
+
+// 获取商品和服务税收分类对照表 Rsp
+type FapiaoTaxCodesRsp struct {
+	Code     int             `json:"-"`
+	SignInfo *SignInfo       `json:"-"`
+	Response *FapiaoTaxCodes `json:"response,omitempty"`
+	Error    string          `json:"-"`
+}
+
+// 获取抬头填写链接 Rsp
+type FapiaoUserTitleUrlRsp struct {
+	Code     int                 `json:"-"`
+	SignInfo *SignInfo           `json:"-"`
+	Response *FapiaoUserTitleUrl `json:"response,omitempty"`
+	Error    string              `json:"-"`
+}
+
+// 获取用户填写的抬头 Rsp
+type FapiaoUserTitleRsp struct {
+	Code     int              `json:"-"`
+	SignInfo *SignInfo        `json:"-"`
+	Response *FapiaoUserTitle `json:"response,omitempty"`
+	Error    string           `json:"-"`
+}
+
+// 查询电子发票 Rsp
+type FapiaoQueryRsp struct {
+	Code     int          `json:"-"`
+	SignInfo *SignInfo    `json:"-"`
+	Response *FapiaoQuery `json:"response,omitempty"`
+	Error    string       `json:"-"`
+}
+
+// 获取发票下载信息 Rsp
+type FapiaoFilesRsp struct {
+	Code     int          `json:"-"`
+	SignInfo *SignInfo    `json:"-"`
+	Response *FapiaoFiles `json:"response,omitempty"`
+	Error    string       `json:"-"`
+}
+
+// 上传电子发票文件 Rsp
+type FapiaoUploadFileRsp struct {
+	Code     int               `json:"-"`
+	SignInfo *SignInfo         `json:"-"`
+	Response *FapiaoUploadFile `json:"response,omitempty"`
+	Error    string            `json:"-"`
+}
+
+// =========================================================分割=========================================================
+
+type FapiaoCardTemplate struct {
+	CardAppid string `json:"card_appid"` // 插卡公众号AppID
+	CardId    string `json:"card_id"`    // 卡券模板ID
+}
+
+type FapiaoDevelopmentConfig struct {
+	CallbackUrl    string `json:"callback_url"`               // 商户回调地址
+	ShowFapiaoCell bool   `json:"show_fapiao_cell,omitempty"` // 是否在支付成功页展示开票入口
+}
+
+type FapiaoMerchantBaseInfo struct {
+	SellerInformation *FapiaoSellerInformation `json:"seller_information"` // 销售方信息
+}
+
+type FapiaoSellerInformation struct {
+	TaxpayerId  string `json:"taxpayer_id"`            // 纳税人识别号
+	SellerName  string `json:"seller_name"`            // 销售方名称
+	Address     string `json:"address,omitempty"`      // 地址
+	Telephone   string `json:"telephone,omitempty"`    // 电话
+	BankName    string `json:"bank_name,omitempty"`    // 开户银行
+	BankAccount string `json:"bank_account,omitempty"` // 银行账号
+}
+
+type FapiaoTaxCodes struct {
+	Data       []*FapiaoTaxCode `json:"data,omitempty"` // 税收分类编码对照表
+	TotalCount int              `json:"total_count"`    // 总数
+	Offset     int              `json:"offset"`         // 分页开始位置
+	Limit      int              `json:"limit"`          // 分页大小
+}
+
+type FapiaoTaxCode struct {
+	TaxCode       string `json:"tax_code"`                 // 税局侧规定的商品和服务税收分类编码
+	GoodsCategory string `json:"goods_category,omitempty"` // 商品大类
+	GoodsName     string `json:"goods_name,omitempty"`     // 商品名称
+	GoodsId       int64  `json:"goods_id,omitempty"`       // 商户侧维护的商品编码
+	GoodsPrice    int64  `json:"goods_price,omitempty"`    // 商品单价，单位：分
+	TaxRate       int    `json:"tax_rate,omitempty"`       // 税率，单位为万分之一，如 600 表示 6%
+}
+
+type FapiaoUserTitleUrl struct {
+	Url string `json:"url"` // 抬头填写链接
+}
+
+type FapiaoUserTitle struct {
+	Type        string `json:"type"`                             // 购买方类型：INDIVIDUAL、ORGANIZATION
+	Name        string `json:"name"`                             // 名称
+	TaxpayerId  string `json:"taxpayer_id,omitempty"`            // 纳税人识别号
+	Address     string `json:"address,omitempty"`                // 地址
+	Telephone   string `json:"telephone,omitempty"`              // 电话
+	BankName    string `json:"bank_name,omitempty"`              // 开户银行
+	BankAccount string `json:"bank_account,omitempty"`           // 银行账号
+	Phone       string `json:"phone,omitempty" sensitive:"true"` // 手机号，已自动解密
+	Email       string `json:"email,omitempty" sensitive:"true"` // 邮箱，已自动解密
+}
+
+// 开具电子发票 buyer_information，Phone、Email 请求时自动加密
+type FapiaoBuyerInformation struct {
+	Type        string `json:"type"`                             // 购买方类型：INDIVIDUAL、ORGANIZATION
+	Name        string `json:"name"`                             // 名称
+	TaxpayerId  string `json:"taxpayer_id,omitempty"`            // 纳税人识别号
+	Address     string `json:"address,omitempty"`                // 地址
+	Telephone   string `json:"telephone,omitempty"`              // 电话
+	BankName    string `json:"bank_name,omitempty"`              // 开户银行
+	BankAccount string `json:"bank_account,omitempty"`           // 银行账号
+	Phone       string `json:"phone,omitempty" sensitive:"true"` // 手机号
+	Email       string `json:"email,omitempty" sensitive:"true"` // 邮箱
+}
+
+type FapiaoQuery struct {
+	TotalCount        int                  `json:"total_count"`                  // 发票数量
+	FapiaoInformation []*FapiaoInformation `json:"fapiao_information,omitempty"` // 发票信息
+}
+
+type FapiaoInformation struct {
+	FapiaoId          string                   `json:"fapiao_id"`                    // 商户发票单号
+	Status            string                   `json:"status"`                       // 发票状态：ISSUE_ACCEPTED、ISSUED、REVERSE_ACCEPTED、REVERSED
+	BlueFapiao        *FapiaoDetail            `json:"blue_fapiao,omitempty"`        // 蓝字发票信息
+	RedFapiao         *FapiaoDetail            `json:"red_fapiao,omitempty"`         // 红字发票信息
+	CardInformation   *FapiaoCardInformation   `json:"card_information,omitempty"`   // 发票卡券信息
+	TotalAmount       int64                    `json:"total_amount,omitempty"`       // 总价税合计，单位：分
+	TaxAmount         int64                    `json:"tax_amount,omitempty"`         // 总税额，单位：分
+	Amount            int64                    `json:"amount,omitempty"`             // 总金额，单位：分
+	SellerInformation *FapiaoSellerInformation `json:"seller_information,omitempty"` // 销售方信息
+	BuyerInformation  *FapiaoUserTitle         `json:"buyer_information,omitempty"`  // 购买方信息
+	Items             []*FapiaoItem            `json:"items,omitempty"`              // 发票行信息
+	Remark            string                   `json:"remark,omitempty"`             // 备注
+}
+
+type FapiaoDetail struct {
+	FapiaoCode   string `json:"fapiao_code"`           // 发票代码
+	FapiaoNumber string `json:"fapiao_number"`         // 发票号码
+	CheckCode    string `json:"check_code,omitempty"`  // 校验码
+	Password     string `json:"password,omitempty"`    // 密码
+	FapiaoTime   string `json:"fapiao_time,omitempty"` // 开票时间
+}
+
+type FapiaoCardInformation struct {
+	CardAppid  string `json:"card_appid"`  // 插卡公众号AppID
+	CardOpenid string `json:"card_openid"` // 插卡用户OpenID
+	CardId     string `json:"card_id"`     // 卡券模板ID
+	CardCode   string `json:"card_code"`   // 卡券code
+	CardStatus string `json:"card_status"` // 发票卡券状态：INSERT_ACCEPTED、INSERTED、DISCARD_ACCEPTED、DISCARDED
+}
+
+type FapiaoItem struct {
+	TaxCode       string `json:"tax_code"`                  // 税局侧规定的商品和服务税收分类编码
+	GoodsName     string `json:"goods_name,omitempty"`      // 商品名称
+	Specification string `json:"specification,omitempty"`   // 规格型号
+	Unit          string `json:"unit,omitempty"`            // 单位
+	Quantity      int64  `json:"quantity,omitempty"`        // 数量，单位为百万分之一
+	UnitPrice     int64  `json:"unit_price,omitempty"`      // 单价，单位为百万分之一分
+	Amount        int64  `json:"amount,omitempty"`          // 金额，单位：分
+	TaxAmount     int64  `json:"tax_amount,omitempty"`      // 税额，单位：分
+	TotalAmount   int64  `json:"total_amount,omitempty"`    // 价税合计，单位：分
+	TaxRate       int    `json:"tax_rate,omitempty"`        // 税率，单位为万分之一
+	TaxPreferMark string `json:"tax_prefer_mark,omitempty"` // 税收优惠政策标识
+	Discount      bool   `json:"discount,omitempty"`        // 是否是折扣行
+}
+
+type FapiaoFiles struct {
+	FapiaoDownloadInfoList []*FapiaoDownloadInfo `json:"fapiao_download_info_list,omitempty"` // 发票下载信息
+}
+
+type FapiaoDownloadInfo struct {
+	FapiaoId    string `json:"fapiao_id"`    // 商户发票单号
+	DownloadUrl string `json:"download_url"` // 发票文件下载地址
+	Status      string `json:"status"`       // 发票状态
+}
+
+type FapiaoUploadFile struct {
+	FapiaoMediaId string `json:"fapiao_media_id"` // 发票文件媒体ID
+}
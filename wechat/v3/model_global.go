@@ -0,0 +1,121 @@
+package wechat
+
+// 跨境支付查询订单 Rsp
+type GlobalQueryOrderRsp struct {
+	Code     int               `json:"-"`
+	SignInfo *SignInfo         `json:"-"`
+	Response *GlobalQueryOrder `json:"response,omitempty"`
+	Error    string            `json:"-"`
+}
+
+// 跨境支付申请退款、查询单笔退款 Rsp
+type GlobalRefundRsp struct {
+	Code     int           `json:"-"`
+	SignInfo *SignInfo     `json:"-"`
+	Response *GlobalRefund `json:"response,omitempty"`
+	Error    string        `json:"-"`
+}
+
+// 跨境支付查询汇率 Rsp
+type GlobalExchangeRateRsp struct {
+	Code     int                 `json:"-"`
+	SignInfo *SignInfo           `json:"-"`
+	Response *GlobalExchangeRate `json:"response,omitempty"`
+	Error    string              `json:"-"`
+}
+
+// 跨境支付查询结算信息 Rsp
+type GlobalSettlementRsp struct {
+	Code     int               `json:"-"`
+	SignInfo *SignInfo         `json:"-"`
+	Response *GlobalSettlement `json:"response,omitempty"`
+	Error    string            `json:"-"`
+}
+
+// =========================================================分割=========================================================
+
+type GlobalQueryOrder struct {
+	Id              string             `json:"id,omitempty"`               // 微信支付订单号（同 transaction_id）
+	Appid           string             `json:"appid,omitempty"`            // 直连模式商户的 appid
+	Mchid           string             `json:"mchid"`                      // 商户号（机构模式为机构商户号）
+	SubAppid        string             `json:"sub_appid,omitempty"`        // 子商户 appid【机构模式】
+	SubMchid        string             `json:"sub_mchid,omitempty"`        // 子商户号【机构模式】
+	OutTradeNo      string             `json:"out_trade_no"`               // 商户订单号
+	TransactionId   string             `json:"transaction_id"`             // 微信支付订单号
+	TradeType       string             `json:"trade_type"`                 // 交易类型：JSAPI、NATIVE、APP、MWEB
+	TradeState      string             `json:"trade_state"`                // 交易状态：SUCCESS、REFUND、NOTPAY、CLOSED、REVOKED、USERPAYING、PAYERROR
+	TradeStateDesc  string             `json:"trade_state_desc"`           // 交易状态描述
+	BankType        string             `json:"bank_type,omitempty"`        // 付款银行
+	Attach          string             `json:"attach"`                     // 附加数据
+	SuccessTime     string             `json:"success_time,omitempty"`     // 支付完成时间
+	Payer           *Payer             `json:"payer"`                      // 支付者信息
+	Amount          *GlobalAmount      `json:"amount,omitempty"`           // 订单金额信息（多币种）
+	SceneInfo       *SceneInfo         `json:"scene_info,omitempty"`       // 支付场景描述
+	PromotionDetail []*PromotionDetail `json:"promotion_detail,omitempty"` // 优惠功能
+}
+
+type GlobalAmount struct {
+	Total         int           `json:"total,omitempty"`          // 订单金额，标价币种的最小单位
+	Currency      string        `json:"currency,omitempty"`       // 标价币种，如 HKD、USD
+	PayerTotal    int           `json:"payer_total,omitempty"`    // 用户支付金额，支付币种的最小单位
+	PayerCurrency string        `json:"payer_currency,omitempty"` // 用户支付币种，如 CNY
+	ExchangeRate  *ExchangeRate `json:"exchange_rate,omitempty"`  // 汇率信息
+}
+
+type ExchangeRate struct {
+	Type string `json:"type"` // 汇率类型：SETTLEMENT_RATE：结算汇率
+	Rate int64  `json:"rate"` // 汇率值，为实际汇率乘以 10^8
+}
+
+type GlobalRefund struct {
+	Id            string              `json:"id"`                       // 微信支付退款单号
+	OutRefundNo   string              `json:"out_refund_no"`            // 商户退款单号
+	TransactionId string              `json:"transaction_id,omitempty"` // 微信支付订单号
+	OutTradeNo    string              `json:"out_trade_no,omitempty"`   // 商户订单号
+	Channel       string              `json:"channel,omitempty"`        // 退款渠道：ORIGINAL、BALANCE
+	RecvAccount   string              `json:"recv_account,omitempty"`   // 退款入账账户
+	FundSource    string              `json:"fund_source,omitempty"`    // 退款资金来源
+	SuccessTime   string              `json:"success_time,omitempty"`   // 退款成功时间
+	CreateTime    string              `json:"create_time"`              // 退款创建时间
+	Status        string              `json:"status"`                   // 退款状态：SUCCESS、REFUNDCLOSE、PROCESSING、ABNORMAL
+	Amount        *GlobalRefundAmount `json:"amount"`                   // 退款金额信息（多币种）
+}
+
+type GlobalRefundAmount struct {
+	Total              int           `json:"total"`                         // 原订单金额，标价币种的最小单位
+	Refund             int           `json:"refund"`                        // 退款金额，标价币种的最小单位
+	Currency           string        `json:"currency"`                      // 标价币种
+	PayerRefund        int           `json:"payer_refund,omitempty"`        // 用户退款金额，支付币种的最小单位
+	PayerCurrency      string        `json:"payer_currency,omitempty"`      // 用户支付币种
+	SettlementRefund   int           `json:"settlement_refund,omitempty"`   // 结算币种退款金额
+	SettlementCurrency string        `json:"settlement_currency,omitempty"` // 结算币种
+	ExchangeRate       *ExchangeRate `json:"exchange_rate,omitempty"`       // 汇率信息
+}
+
+type GlobalExchangeRate struct {
+	FxType       string `json:"fx_type"`       // 汇率类型：SETTLEMENT_RATE：结算汇率
+	CurrencyType string `json:"currency_type"` // 币种
+	RateTime     string `json:"rate_time"`     // 汇率时间
+	RateValue    int64  `json:"rate_value"`    // 汇率值，为实际汇率乘以 10^8
+}
+
+type GlobalSettlement struct {
+	TotalCount int                     `json:"total_count"` // 总记录数
+	Offset     int                     `json:"offset"`      // 分页起始位置
+	Limit      int                     `json:"limit"`       // 分页大小
+	Data       []*GlobalSettlementItem `json:"data"`        // 结算信息列表
+}
+
+type GlobalSettlementItem struct {
+	SettleStartDate string `json:"settle_start_date"`         // 结算开始日期
+	SettleEndDate   string `json:"settle_end_date"`           // 结算结束日期
+	SettleState     string `json:"settle_state"`              // 结算状态：SETTLED：已结算，UNSETTLED：未结算
+	SettleTime      string `json:"settle_time,omitempty"`     // 结算时间
+	SettleFee       int    `json:"settle_fee"`                // 结算金额，结算币种的最小单位
+	UnsettledFee    int    `json:"unsettled_fee,omitempty"`   // 未结算金额
+	SettleCurrency  string `json:"settle_currency"`           // 结算币种
+	PayNetFee       int    `json:"pay_net_fee,omitempty"`     // 交易净额
+	PoundageFee     int    `json:"poundage_fee,omitempty"`    // 手续费
+	RefundFee       int    `json:"refund_fee,omitempty"`      // 退款金额
+	TransactionFee  int    `json:"transaction_fee,omitempty"` // 交易金额
+}
@@ -32,6 +32,14 @@ type PartnerIncomeRecordRsp struct {
 	Error    string               `json:"-"`
 }
 
+// 商户违规通知回调地址 Rsp
+type ViolationNotifyUrlRsp struct {
+	Code     int                 `json:"-"`
+	SignInfo *SignInfo           `json:"-"`
+	Response *ViolationNotifyUrl `json:"response,omitempty"`
+	Error    string              `json:"-"`
+}
+
 // =========================================================分割=========================================================
 
 type EcommerceBalance struct {
@@ -93,3 +101,8 @@ type PartnerIncomeData struct {
 	BankAccountNumber string `json:"bank_account_number"` // 四位掩码+付款方银行卡尾号后四位
 	RechargeRemark    string `json:"recharge_remark"`     // 银行备注
 }
+
+type ViolationNotifyUrl struct {
+	Mchid     string `json:"mchid"`      // 商户号
+	NotifyUrl string `json:"notify_url"` // 通知地址
+}
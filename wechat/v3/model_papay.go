@@ -0,0 +1,39 @@
+package wechat
+
+// 预签约 Rsp
+type PapayPreSignRsp struct {
+	Code     int           `json:"-"`
+	SignInfo *SignInfo     `json:"-"`
+	Response *PapayPreSign `json:"response,omitempty"`
+	Error    string        `json:"-"`
+}
+
+// 查询签约 Rsp
+type PapayContractRsp struct {
+	Code     int            `json:"-"`
+	SignInfo *SignInfo      `json:"-"`
+	Response *PapayContract `json:"response,omitempty"`
+	Error    string         `json:"-"`
+}
+
+// =========================================================分割=========================================================
+
+type PapayPreSign struct {
+	PreEntrustwebId string `json:"pre_entrustweb_id,omitempty"` // 预签约ID，APP、JSAPI、小程序拉起签约页面时使用
+	RedirectUrl     string `json:"redirect_url,omitempty"`      // 签约跳转链接，H5签约时返回
+}
+
+type PapayContract struct {
+	Appid                  string                  `json:"appid"`
+	Mchid                  string                  `json:"mchid"`
+	ContractId             string                  `json:"contract_id"`                        // 委托代扣协议ID
+	PlanId                 int                     `json:"plan_id"`                            // 委托代扣协议模板ID
+	OutContractCode        string                  `json:"out_contract_code"`                  // 商户签约协议号
+	Openid                 string                  `json:"openid"`                             // 用户标识
+	ContractDisplayAccount string                  `json:"contract_display_account,omitempty"` // 签约用户展示名称
+	ContractState          string                  `json:"contract_state"`                     // 协议状态：ONGOING、TERMINATED
+	ContractSignedTime     string                  `json:"contract_signed_time"`               // 协议签署时间
+	ContractExpiredTime    string                  `json:"contract_expired_time,omitempty"`    // 协议到期时间
+	ContractTerminatedTime string                  `json:"contract_terminated_time,omitempty"` // 协议解约时间
+	ContractTerminateInfo  *PapayContractTerminate `json:"contract_terminate_info,omitempty"`  // 解约信息
+}
@@ -79,6 +79,46 @@ type ProfitShareBillsRsp struct {
 	Error    string            `json:"-"`
 }
 
+// 查询连锁品牌最大分账比例 Rsp
+type BrandProfitShareConfigRsp struct {
+	Code     int                     `json:"-"`
+	SignInfo *SignInfo               `json:"-"`
+	Response *BrandProfitShareConfig `json:"response,omitempty"`
+	Error    string                  `json:"-"`
+}
+
+// 添加、删除连锁品牌分账接收方 Rsp
+type BrandProfitShareReceiverRsp struct {
+	Code     int                       `json:"-"`
+	SignInfo *SignInfo                 `json:"-"`
+	Response *BrandProfitShareReceiver `json:"response,omitempty"`
+	Error    string                    `json:"-"`
+}
+
+// 请求、查询连锁品牌分账 Rsp
+type BrandProfitShareOrderRsp struct {
+	Code     int                    `json:"-"`
+	SignInfo *SignInfo              `json:"-"`
+	Response *BrandProfitShareOrder `json:"response,omitempty"`
+	Error    string                 `json:"-"`
+}
+
+// 请求、查询连锁品牌分账回退 Rsp
+type BrandProfitShareReturnRsp struct {
+	Code     int                     `json:"-"`
+	SignInfo *SignInfo               `json:"-"`
+	Response *BrandProfitShareReturn `json:"response,omitempty"`
+	Error    string                  `json:"-"`
+}
+
+// 完结连锁品牌分账 Rsp
+type BrandProfitShareFinishRsp struct {
+	Code     int                     `json:"-"`
+	SignInfo *SignInfo               `json:"-"`
+	Response *BrandProfitShareFinish `json:"response,omitempty"`
+	Error    string                  `json:"-"`
+}
+
 // =========================================================分割=========================================================
 
 type ProfitShareOrder struct {
@@ -181,3 +221,45 @@ type ProfitShareBills struct {
 	HashType    string `json:"hash_type"`    // 哈希类型 原始账单（gzip需要解压缩）的摘要算法，用于校验文件的完整性
 	HashValue   string `json:"hash_value"`   // 哈希值	供下一步请求账单文件的下载地址，该地址30s内有效。
 }
+
+type BrandProfitShareConfig struct {
+	BrandMchid string `json:"brand_mchid"` // 品牌主商户号
+	MaxRatio   int    `json:"max_ratio"`   // 最大分账比例 (单位万分比，比如2000表示20%)
+}
+
+type BrandProfitShareReceiver struct {
+	BrandMchid string `json:"brand_mchid"` // 品牌主商户号
+	Type       string `json:"type"`        // 分账接收方类型：MERCHANT_ID、PERSONAL_OPENID、PERSONAL_SUB_OPENID
+	Account    string `json:"account"`     // 分账接收方账号
+}
+
+type BrandProfitShareOrder struct {
+	BrandMchid    string                   `json:"brand_mchid,omitempty"` // 品牌主商户号
+	SubMchid      string                   `json:"sub_mchid"`             // 特约商户号，即分账的出资商户
+	TransactionId string                   `json:"transaction_id"`        // 微信订单号
+	OutOrderNo    string                   `json:"out_order_no"`          // 商户分账单号
+	OrderId       string                   `json:"order_id"`              // 微信分账单号
+	Status        string                   `json:"status"`                // 分账单状态：PROCESSING：处理中，FINISHED：处理完成
+	Receivers     []*ProfitSharingReceiver `json:"receivers,omitempty"`   // 分账接收方列表
+}
+
+type BrandProfitShareReturn struct {
+	SubMchid    string `json:"sub_mchid"`             // 特约商户号，分账回退的接收商户
+	OrderId     string `json:"order_id"`              // 微信分账单号
+	OutOrderNo  string `json:"out_order_no"`          // 商户分账单号
+	OutReturnNo string `json:"out_return_no"`         // 商户回退单号
+	ReturnNo    string `json:"return_no"`             // 微信回退单号
+	ReturnMchid string `json:"return_mchid"`          // 回退方商户号
+	Amount      int    `json:"amount"`                // 回退金额，单位为分
+	Description string `json:"description"`           // 回退描述
+	Result      string `json:"result"`                // 回退结果：PROCESSING：处理中，SUCCESS：已成功，FAILED：已失败
+	FailReason  string `json:"fail_reason,omitempty"` // 失败原因
+	FinishTime  string `json:"finish_time"`           // 完成时间
+}
+
+type BrandProfitShareFinish struct {
+	SubMchid      string `json:"sub_mchid"`      // 特约商户号
+	TransactionId string `json:"transaction_id"` // 微信订单号
+	OutOrderNo    string `json:"out_order_no"`   // 商户分账单号
+	OrderId       string `json:"order_id"`       // 微信分账单号
+}
@@ -169,20 +169,20 @@ type PartnerTransferQuery struct {
 }
 
 type TransferDetailQuery struct {
-	Mchid          string `json:"mchid"`                 // 微信支付分配的商户号
-	OutBatchNo     string `json:"out_batch_no"`          // 商户系统内部的商家批次单号
-	BatchId        string `json:"batch_id"`              // 微信批次单号，微信商家转账系统返回的唯一标识
-	Appid          string `json:"appid"`                 // 申请商户号的appid或商户号绑定的appid（企业号corpid即为此appid）
-	OutDetailNo    string `json:"out_detail_no"`         // 商家明细单号
-	DetailId       string `json:"detail_id"`             // 微信明细单号
-	DetailStatus   string `json:"detail_status"`         // 明细状态：PROCESSING：转账中，SUCCESS：转账成功，FAIL：转账失败
-	TransferAmount int    `json:"transfer_amount"`       // 转账金额单位为分
-	TransferRemark string `json:"transfer_remark"`       // 单条转账备注（微信用户会收到该备注），UTF8编码，最多允许32个字符
-	FailReason     string `json:"fail_reason,omitempty"` // 如果转账失败则有失败原因
-	Openid         string `json:"openid"`                // 用户在直连商户appid下的唯一标识
-	UserName       string `json:"user_name"`             // 收款方姓名（加密）
-	InitiateTime   string `json:"initiate_time"`         // 转账发起的时间
-	UpdateTime     string `json:"update_time"`           // 明细最后一次状态变更的时间
+	Mchid          string `json:"mchid"`                      // 微信支付分配的商户号
+	OutBatchNo     string `json:"out_batch_no"`               // 商户系统内部的商家批次单号
+	BatchId        string `json:"batch_id"`                   // 微信批次单号，微信商家转账系统返回的唯一标识
+	Appid          string `json:"appid"`                      // 申请商户号的appid或商户号绑定的appid（企业号corpid即为此appid）
+	OutDetailNo    string `json:"out_detail_no"`              // 商家明细单号
+	DetailId       string `json:"detail_id"`                  // 微信明细单号
+	DetailStatus   string `json:"detail_status"`              // 明细状态：PROCESSING：转账中，SUCCESS：转账成功，FAIL：转账失败
+	TransferAmount int    `json:"transfer_amount"`            // 转账金额单位为分
+	TransferRemark string `json:"transfer_remark"`            // 单条转账备注（微信用户会收到该备注），UTF8编码，最多允许32个字符
+	FailReason     string `json:"fail_reason,omitempty"`      // 如果转账失败则有失败原因
+	Openid         string `json:"openid"`                     // 用户在直连商户appid下的唯一标识
+	UserName       string `json:"user_name" sensitive:"true"` // 收款方姓名（加密，可使用 client.V3DecryptSensitive() 解密）
+	InitiateTime   string `json:"initiate_time"`              // 转账发起的时间
+	UpdateTime     string `json:"update_time"`                // 明细最后一次状态变更的时间
 }
 
 type PartnerTransferDetail struct {
@@ -235,19 +235,19 @@ type PartnerTransferMerchantQuery struct {
 }
 
 type TransferMerchantDetail struct {
-	OutBatchNo     string `json:"out_batch_no"`          // 商户系统内部的商家批次单号
-	BatchId        string `json:"batch_id"`              // 微信批次单号，微信商家转账系统返回的唯一标识
-	Appid          string `json:"appid"`                 // 申请商户号的appid或商户号绑定的appid（企业号corpid即为此appid）
-	OutDetailNo    string `json:"out_detail_no"`         // 商家明细单号
-	DetailId       string `json:"detail_id"`             // 微信明细单号
-	DetailStatus   string `json:"detail_status"`         // 明细状态：PROCESSING：转账中，SUCCESS：转账成功，FAIL：转账失败
-	TransferAmount int    `json:"transfer_amount"`       // 转账金额单位为分
-	TransferRemark string `json:"transfer_remark"`       // 单条转账备注（微信用户会收到该备注），UTF8编码，最多允许32个字符
-	FailReason     string `json:"fail_reason,omitempty"` // 如果转账失败则有失败原因
-	Openid         string `json:"openid"`                // 用户在直连商户appid下的唯一标识
-	UserName       string `json:"user_name"`             // 收款方姓名（加密）
-	InitiateTime   string `json:"initiate_time"`         // 转账发起的时间
-	UpdateTime     string `json:"update_time"`           // 明细最后一次状态变更的时间
+	OutBatchNo     string `json:"out_batch_no"`               // 商户系统内部的商家批次单号
+	BatchId        string `json:"batch_id"`                   // 微信批次单号，微信商家转账系统返回的唯一标识
+	Appid          string `json:"appid"`                      // 申请商户号的appid或商户号绑定的appid（企业号corpid即为此appid）
+	OutDetailNo    string `json:"out_detail_no"`              // 商家明细单号
+	DetailId       string `json:"detail_id"`                  // 微信明细单号
+	DetailStatus   string `json:"detail_status"`              // 明细状态：PROCESSING：转账中，SUCCESS：转账成功，FAIL：转账失败
+	TransferAmount int    `json:"transfer_amount"`            // 转账金额单位为分
+	TransferRemark string `json:"transfer_remark"`            // 单条转账备注（微信用户会收到该备注），UTF8编码，最多允许32个字符
+	FailReason     string `json:"fail_reason,omitempty"`      // 如果转账失败则有失败原因
+	Openid         string `json:"openid"`                     // 用户在直连商户appid下的唯一标识
+	UserName       string `json:"user_name" sensitive:"true"` // 收款方姓名（加密，可使用 client.V3DecryptSensitive() 解密）
+	InitiateTime   string `json:"initiate_time"`              // 转账发起的时间
+	UpdateTime     string `json:"update_time"`                // 明细最后一次状态变更的时间
 }
 
 type PartnerTransferMerchantDetail struct {
@@ -310,3 +310,95 @@ type TransferDetailReceiptQuery struct {
 	HashValue       string `json:"hash_value,omitempty"`       // 电子回单文件的hash值，用于下载之后验证文件的完整、正确性，回单状态为：FINISHED时返回。
 	DownloadUrl     string `json:"download_url,omitempty"`     // 电子回单文件的下载地址，回单状态为：FINISHED时返回
 }
+
+// 发起商家转账 transfer_detail_list 明细，UserName、UserIdCard 请求时自动加密
+type TransferDetailInput struct {
+	OutDetailNo    string `json:"out_detail_no"`                           // 商家明细单号
+	TransferAmount int    `json:"transfer_amount"`                         // 转账金额单位为分
+	TransferRemark string `json:"transfer_remark"`                         // 单条转账备注
+	Openid         string `json:"openid"`                                  // 用户在直连商户appid下的唯一标识
+	UserName       string `json:"user_name,omitempty" sensitive:"true"`    // 收款用户姓名
+	UserIdCard     string `json:"user_id_card,omitempty" sensitive:"true"` // 收款用户身份证
+}
+
+// 发起转账（商家转账新版） Rsp
+type TransferBillsRsp struct {
+	Code     int            `json:"-"`
+	SignInfo *SignInfo      `json:"-"`
+	Response *TransferBills `json:"response,omitempty"`
+	Error    string         `json:"-"`
+}
+
+// 撤销转账（商家转账新版） Rsp
+type TransferBillsCancelRsp struct {
+	Code     int                  `json:"-"`
+	SignInfo *SignInfo            `json:"-"`
+	Response *TransferBillsCancel `json:"response,omitempty"`
+	Error    string               `json:"-"`
+}
+
+// 查询转账单（商家转账新版） Rsp
+type TransferBillsQueryRsp struct {
+	Code     int                 `json:"-"`
+	SignInfo *SignInfo           `json:"-"`
+	Response *TransferBillsQuery `json:"response,omitempty"`
+	Error    string              `json:"-"`
+}
+
+// 申请、查询电子回单（商家转账新版） Rsp
+type TransferBillsReceiptRsp struct {
+	Code     int                   `json:"-"`
+	SignInfo *SignInfo             `json:"-"`
+	Response *TransferBillsReceipt `json:"response,omitempty"`
+	Error    string                `json:"-"`
+}
+
+// =========================================================分割=========================================================
+
+type TransferBills struct {
+	OutBillNo      string `json:"out_bill_no"`           // 商户单号
+	TransferBillNo string `json:"transfer_bill_no"`      // 微信转账单号
+	CreateTime     string `json:"create_time"`           // 单据创建时间
+	State          string `json:"state"`                 // 单据状态：ACCEPTED、PROCESSING、WAIT_USER_CONFIRM、TRANSFERING、SUCCESS、FAIL、CANCELING、CANCELLED
+	FailReason     string `json:"fail_reason,omitempty"` // 失败原因
+	PackageInfo    string `json:"package_info"`          // 跳转领取页面的package信息，state 为 WAIT_USER_CONFIRM 时返回
+}
+
+type TransferBillsCancel struct {
+	OutBillNo      string `json:"out_bill_no"`      // 商户单号
+	TransferBillNo string `json:"transfer_bill_no"` // 微信转账单号
+	State          string `json:"state"`            // 单据状态：CANCELING、CANCELLED
+	UpdateTime     string `json:"update_time"`      // 最后一次单据状态变更时间
+}
+
+type TransferBillsQuery struct {
+	MchId          string `json:"mch_id"`                               // 商户号
+	OutBillNo      string `json:"out_bill_no"`                          // 商户单号
+	TransferBillNo string `json:"transfer_bill_no"`                     // 微信转账单号
+	Appid          string `json:"appid"`                                // 商户AppID
+	State          string `json:"state"`                                // 单据状态
+	TransferAmount int    `json:"transfer_amount"`                      // 转账金额，单位为分
+	TransferRemark string `json:"transfer_remark"`                      // 转账备注
+	FailReason     string `json:"fail_reason,omitempty"`                // 失败原因
+	Openid         string `json:"openid,omitempty"`                     // 收款用户OpenID
+	UserName       string `json:"user_name,omitempty" sensitive:"true"` // 收款用户姓名，已自动解密
+	CreateTime     string `json:"create_time"`                          // 单据创建时间
+	UpdateTime     string `json:"update_time"`                          // 最后一次状态变更时间
+}
+
+type TransferBillsReceipt struct {
+	State       string `json:"state"`                  // 电子回单状态：ACCEPTED、FINISHED、FAILED
+	CreateTime  string `json:"create_time,omitempty"`  // 电子回单申请单创建时间
+	UpdateTime  string `json:"update_time,omitempty"`  // 最后一次状态变更时间
+	HashType    string `json:"hash_type,omitempty"`    // 电子回单文件的hash方法，状态为 FINISHED 时返回
+	HashValue   string `json:"hash_value,omitempty"`   // 电子回单文件的hash值，状态为 FINISHED 时返回
+	DownloadUrl string `json:"download_url,omitempty"` // 电子回单文件的下载地址，状态为 FINISHED 时返回
+	FailReason  string `json:"fail_reason,omitempty"`  // 失败原因，状态为 FAILED 时返回
+}
+
+// 小程序、JSAPI 调起用户确认收款（wx.requestMerchantTransfer）所需参数
+type TransferBillsConfirmParams struct {
+	MchId   string `json:"mchId"`
+	AppId   string `json:"appId"`
+	Package string `json:"package"`
+}
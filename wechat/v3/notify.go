@@ -10,6 +10,7 @@ import (
 	"net/http"
 
 	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/pkg/sm2"
 	"github.com/misu99/gopay/pkg/xlog"
 )
 
@@ -152,6 +153,122 @@ type BusifavorAttachInfo struct {
 	ActivityID      string `json:"activity_id"`        // 会员活动ID
 }
 
+type V3DecryptTransferBatchResult struct {
+	Mchid         string `json:"mchid"`          // 商户号
+	OutBatchNo    string `json:"out_batch_no"`   // 商家批次单号
+	BatchId       string `json:"batch_id"`       // 微信批次单号
+	BatchStatus   string `json:"batch_status"`   // 批次状态：FINISHED、CLOSED
+	TotalNum      int    `json:"total_num"`      // 批次总笔数
+	TotalAmount   int    `json:"total_amount"`   // 批次总金额
+	SuccessAmount int    `json:"success_amount"` // 转账成功金额
+	SuccessNum    int    `json:"success_num"`    // 转账成功笔数
+	FailAmount    int    `json:"fail_amount"`    // 转账失败金额
+	FailNum       int    `json:"fail_num"`       // 转账失败笔数
+	UpdateTime    string `json:"update_time"`    // 批次更新时间
+	CloseReason   string `json:"close_reason"`   // 批次关闭原因
+}
+
+type V3DecryptTransferBillsResult struct {
+	MchId          string `json:"mch_id"`           // 商户号
+	OutBillNo      string `json:"out_bill_no"`      // 商户单号
+	TransferBillNo string `json:"transfer_bill_no"` // 微信转账单号
+	State          string `json:"state"`            // 单据状态：SUCCESS、FAIL、CANCELLED
+	TransferAmount int    `json:"transfer_amount"`  // 转账金额，单位为分
+	Openid         string `json:"openid"`           // 收款用户OpenID
+	FailReason     string `json:"fail_reason"`      // 失败原因
+	CreateTime     string `json:"create_time"`      // 单据创建时间
+	UpdateTime     string `json:"update_time"`      // 最后一次状态变更时间
+}
+
+type V3DecryptComplaintResult struct {
+	ComplaintId string `json:"complaint_id"` // 投诉单号
+	ActionType  string `json:"action_type"`  // 动作类型
+}
+
+type V3DecryptFapiaoResult struct {
+	Mchid             string               `json:"mchid"`                        // 商户号
+	SubMchid          string               `json:"sub_mchid,omitempty"`          // 子商户号
+	FapiaoApplyId     string               `json:"fapiao_apply_id"`              // 发票申请单号
+	ApplyTime         string               `json:"apply_time,omitempty"`         // 用户发起开票申请时间
+	FapiaoInformation []*FapiaoInformation `json:"fapiao_information,omitempty"` // 发票信息，发票开具、冲红、插卡通知时返回
+}
+
+type V3DecryptPapayContractResult struct {
+	Appid                  string                  `json:"appid"`
+	Mchid                  string                  `json:"mchid"`
+	OutContractCode        string                  `json:"out_contract_code"`        // 商户签约协议号
+	PlanId                 int                     `json:"plan_id"`                  // 委托代扣协议模板ID
+	Openid                 string                  `json:"openid"`                   // 用户标识
+	ContractId             string                  `json:"contract_id"`              // 委托代扣协议ID
+	ContractState          string                  `json:"contract_state"`           // 协议状态：ONGOING、TERMINATED
+	ContractSignedTime     string                  `json:"contract_signed_time"`     // 协议签署时间
+	ContractTerminatedTime string                  `json:"contract_terminated_time"` // 协议解约时间
+	ContractTerminateInfo  *PapayContractTerminate `json:"contract_terminate_info"`  // 解约信息
+}
+
+type V3DecryptPapayPayResult struct {
+	Appid           string             `json:"appid"`
+	Mchid           string             `json:"mchid"`
+	OutTradeNo      string             `json:"out_trade_no"`
+	TransactionId   string             `json:"transaction_id"`
+	TradeType       string             `json:"trade_type"` // 交易类型：PAP
+	TradeState      string             `json:"trade_state"`
+	TradeStateDesc  string             `json:"trade_state_desc"`
+	BankType        string             `json:"bank_type"`
+	Attach          string             `json:"attach"`
+	SuccessTime     string             `json:"success_time"`
+	ContractId      string             `json:"contract_id"` // 委托代扣协议ID
+	Payer           *Payer             `json:"payer"`
+	Amount          *Amount            `json:"amount"`
+	PromotionDetail []*PromotionDetail `json:"promotion_detail"`
+}
+
+type PapayContractTerminate struct {
+	ContractTerminateMode   string `json:"contract_terminate_mode"`   // 解约方式
+	ContractTerminateRemark string `json:"contract_terminate_remark"` // 解约备注
+}
+
+type V3DecryptBrandProfitShareResult struct {
+	BrandMchid    string    `json:"brand_mchid"`    // 品牌主商户号
+	SubMchid      string    `json:"sub_mchid"`      // 特约商户号
+	TransactionId string    `json:"transaction_id"` // 微信订单号
+	OrderId       string    `json:"order_id"`       // 微信分账/回退单号
+	OutOrderNo    string    `json:"out_order_no"`   // 商户分账/回退单号
+	Receiver      *Receiver `json:"receiver"`
+	SuccessTime   string    `json:"success_time"` // 成功时间
+}
+
+type V3DecryptViolationResult struct {
+	SubMchid          string `json:"sub_mchid"`          // 特约商户号
+	CompanyName       string `json:"company_name"`       // 公司名称
+	RecordId          string `json:"record_id"`          // 通知ID
+	PunishPlan        string `json:"punish_plan"`        // 处罚方案
+	PunishTime        string `json:"punish_time"`        // 处罚时间
+	PunishDescription string `json:"punish_description"` // 处罚方案描述
+	RiskType          string `json:"risk_type"`          // 风险类型
+	RiskDescription   string `json:"risk_description"`   // 风险描述
+}
+
+type V3DecryptGlobalResult struct {
+	Id              string             `json:"id"`
+	Appid           string             `json:"appid"`
+	Mchid           string             `json:"mchid"`
+	SubAppid        string             `json:"sub_appid"`
+	SubMchid        string             `json:"sub_mchid"`
+	OutTradeNo      string             `json:"out_trade_no"`
+	TransactionId   string             `json:"transaction_id"`
+	TradeType       string             `json:"trade_type"`
+	TradeState      string             `json:"trade_state"`
+	TradeStateDesc  string             `json:"trade_state_desc"`
+	BankType        string             `json:"bank_type"`
+	Attach          string             `json:"attach"`
+	SuccessTime     string             `json:"success_time"`
+	Payer           *Payer             `json:"payer"`
+	Amount          *GlobalAmount      `json:"amount"`
+	SceneInfo       *SceneInfo         `json:"scene_info"`
+	PromotionDetail []*PromotionDetail `json:"promotion_detail"`
+}
+
 type V3NotifyReq struct {
 	Id           string    `json:"id"`
 	CreateTime   string    `json:"create_time"`
@@ -218,10 +335,36 @@ func (v *V3NotifyReq) VerifySignByPKMap(wxPublicKeyMap map[string]*rsa.PublicKey
 	return errors.New("verify notify sign, bug SignInfo or wxPublicKeyMap is nil")
 }
 
+// 国密异步通知验签
+// wxPublicKeyMap：微信国密平台证书公钥，通过 client.WxSM2PublicKeyMap() 获取
+func (v *V3NotifyReq) VerifySignBySM2PKMap(wxPublicKeyMap map[string]*sm2.PublicKey) (err error) {
+	if v.SignInfo != nil && wxPublicKeyMap != nil {
+		return V3VerifySignBySM2PK(v.SignInfo.HeaderTimestamp, v.SignInfo.HeaderNonce, v.SignInfo.SignBody, v.SignInfo.HeaderSignature, wxPublicKeyMap[v.SignInfo.HeaderSerial])
+	}
+	return errors.New("verify notify sign, bug SignInfo or wxPublicKeyMap is nil")
+}
+
+// 国密 AEAD_SM4_GCM 解密回调中的加密信息到 result
+func (v *V3NotifyReq) decryptSM4(apiV3Key string, result any) (err error) {
+	decrypt, err := V3DecryptNotifyCipherTextToBytesSM4(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(decrypt, result); err != nil {
+		return fmt.Errorf("json.Unmarshal(%s), err:%w", string(decrypt), err)
+	}
+	return nil
+}
+
 // 解密 普通支付 回调中的加密信息
+// 国密回调（algorithm 为 AEAD_SM4_GCM）自动使用 SM4 解密
 func (v *V3NotifyReq) DecryptCipherText(apiV3Key string) (result *V3DecryptResult, err error) {
 	if v.Resource != nil {
-		result, err = V3DecryptNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		if v.Resource.Algorithm == AlgorithmSM4GCM {
+			err = v.decryptSM4(apiV3Key, &result)
+		} else {
+			result, err = V3DecryptNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		}
 		if err != nil {
 			bytes, _ := json.Marshal(v)
 			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%w)", string(bytes), err)
@@ -234,7 +377,11 @@ func (v *V3NotifyReq) DecryptCipherText(apiV3Key string) (result *V3DecryptResul
 // 解密 服务商支付 回调中的加密信息
 func (v *V3NotifyReq) DecryptPartnerCipherText(apiV3Key string) (result *V3DecryptPartnerResult, err error) {
 	if v.Resource != nil {
-		result, err = V3DecryptPartnerNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		if v.Resource.Algorithm == AlgorithmSM4GCM {
+			err = v.decryptSM4(apiV3Key, &result)
+		} else {
+			result, err = V3DecryptPartnerNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		}
 		if err != nil {
 			bytes, _ := json.Marshal(v)
 			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%w)", string(bytes), err)
@@ -247,7 +394,11 @@ func (v *V3NotifyReq) DecryptPartnerCipherText(apiV3Key string) (result *V3Decry
 // 解密 普通退款 回调中的加密信息
 func (v *V3NotifyReq) DecryptRefundCipherText(apiV3Key string) (result *V3DecryptRefundResult, err error) {
 	if v.Resource != nil {
-		result, err = V3DecryptRefundNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		if v.Resource.Algorithm == AlgorithmSM4GCM {
+			err = v.decryptSM4(apiV3Key, &result)
+		} else {
+			result, err = V3DecryptRefundNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		}
 		if err != nil {
 			bytes, _ := json.Marshal(v)
 			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%w)", string(bytes), err)
@@ -260,7 +411,11 @@ func (v *V3NotifyReq) DecryptRefundCipherText(apiV3Key string) (result *V3Decryp
 // 解密 服务商退款 回调中的加密信息
 func (v *V3NotifyReq) DecryptPartnerRefundCipherText(apiV3Key string) (result *V3DecryptPartnerRefundResult, err error) {
 	if v.Resource != nil {
-		result, err = V3DecryptPartnerRefundNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		if v.Resource.Algorithm == AlgorithmSM4GCM {
+			err = v.decryptSM4(apiV3Key, &result)
+		} else {
+			result, err = V3DecryptPartnerRefundNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		}
 		if err != nil {
 			bytes, _ := json.Marshal(v)
 			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%w)", string(bytes), err)
@@ -273,7 +428,11 @@ func (v *V3NotifyReq) DecryptPartnerRefundCipherText(apiV3Key string) (result *V
 // 解密 合单支付 回调中的加密信息
 func (v *V3NotifyReq) DecryptCombineCipherText(apiV3Key string) (result *V3DecryptCombineResult, err error) {
 	if v.Resource != nil {
-		result, err = V3DecryptCombineNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		if v.Resource.Algorithm == AlgorithmSM4GCM {
+			err = v.decryptSM4(apiV3Key, &result)
+		} else {
+			result, err = V3DecryptCombineNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		}
 		if err != nil {
 			bytes, _ := json.Marshal(v)
 			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%w)", string(bytes), err)
@@ -286,7 +445,11 @@ func (v *V3NotifyReq) DecryptCombineCipherText(apiV3Key string) (result *V3Decry
 // 解密 支付分 回调中的加密信息
 func (v *V3NotifyReq) DecryptScoreCipherText(apiV3Key string) (result *V3DecryptScoreResult, err error) {
 	if v.Resource != nil {
-		result, err = V3DecryptScoreNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		if v.Resource.Algorithm == AlgorithmSM4GCM {
+			err = v.decryptSM4(apiV3Key, &result)
+		} else {
+			result, err = V3DecryptScoreNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		}
 		if err != nil {
 			bytes, _ := json.Marshal(v)
 			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%w)", string(bytes), err)
@@ -299,7 +462,11 @@ func (v *V3NotifyReq) DecryptScoreCipherText(apiV3Key string) (result *V3Decrypt
 // 解密分账动账回调中的加密信息
 func (v *V3NotifyReq) DecryptProfitShareCipherText(apiV3Key string) (result *V3DecryptProfitShareResult, err error) {
 	if v.Resource != nil {
-		result, err = V3DecryptProfitShareNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		if v.Resource.Algorithm == AlgorithmSM4GCM {
+			err = v.decryptSM4(apiV3Key, &result)
+		} else {
+			result, err = V3DecryptProfitShareNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		}
 		if err != nil {
 			bytes, _ := json.Marshal(v)
 			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%w)", string(bytes), err)
@@ -312,7 +479,130 @@ func (v *V3NotifyReq) DecryptProfitShareCipherText(apiV3Key string) (result *V3D
 // 解密商家券回调中的加密信息
 func (v *V3NotifyReq) DecryptBusifavorCipherText(apiV3Key string) (result *V3DecryptBusifavorResult, err error) {
 	if v.Resource != nil {
-		result, err = V3DecryptBusifavorNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		if v.Resource.Algorithm == AlgorithmSM4GCM {
+			err = v.decryptSM4(apiV3Key, &result)
+		} else {
+			result, err = V3DecryptBusifavorNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		}
+		if err != nil {
+			bytes, _ := json.Marshal(v)
+			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%w)", string(bytes), err)
+		}
+		return result, nil
+	}
+	return nil, errors.New("notify data Resource is nil")
+}
+
+// 解密商家转账（新版）回调中的加密信息
+func (v *V3NotifyReq) DecryptTransferBillsCipherText(apiV3Key string) (result *V3DecryptTransferBillsResult, err error) {
+	if v.Resource != nil {
+		if v.Resource.Algorithm == AlgorithmSM4GCM {
+			err = v.decryptSM4(apiV3Key, &result)
+		} else {
+			result, err = V3DecryptTransferBillsNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		}
+		if err != nil {
+			bytes, _ := json.Marshal(v)
+			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%w)", string(bytes), err)
+		}
+		return result, nil
+	}
+	return nil, errors.New("notify data Resource is nil")
+}
+
+// 解密委托代扣签约、解约回调中的加密信息
+func (v *V3NotifyReq) DecryptPapayContractCipherText(apiV3Key string) (result *V3DecryptPapayContractResult, err error) {
+	if v.Resource != nil {
+		if v.Resource.Algorithm == AlgorithmSM4GCM {
+			err = v.decryptSM4(apiV3Key, &result)
+		} else {
+			result, err = V3DecryptPapayContractNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		}
+		if err != nil {
+			bytes, _ := json.Marshal(v)
+			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%w)", string(bytes), err)
+		}
+		return result, nil
+	}
+	return nil, errors.New("notify data Resource is nil")
+}
+
+// 解密委托代扣扣款回调中的加密信息
+func (v *V3NotifyReq) DecryptPapayPayCipherText(apiV3Key string) (result *V3DecryptPapayPayResult, err error) {
+	if v.Resource != nil {
+		if v.Resource.Algorithm == AlgorithmSM4GCM {
+			err = v.decryptSM4(apiV3Key, &result)
+		} else {
+			result, err = V3DecryptPapayPayNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		}
+		if err != nil {
+			bytes, _ := json.Marshal(v)
+			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%w)", string(bytes), err)
+		}
+		return result, nil
+	}
+	return nil, errors.New("notify data Resource is nil")
+}
+
+// 解密电子发票回调中的加密信息
+func (v *V3NotifyReq) DecryptFapiaoCipherText(apiV3Key string) (result *V3DecryptFapiaoResult, err error) {
+	if v.Resource != nil {
+		if v.Resource.Algorithm == AlgorithmSM4GCM {
+			err = v.decryptSM4(apiV3Key, &result)
+		} else {
+			result, err = V3DecryptFapiaoNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		}
+		if err != nil {
+			bytes, _ := json.Marshal(v)
+			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%w)", string(bytes), err)
+		}
+		return result, nil
+	}
+	return nil, errors.New("notify data Resource is nil")
+}
+
+// 解密连锁品牌分账动账回调中的加密信息
+func (v *V3NotifyReq) DecryptBrandProfitShareCipherText(apiV3Key string) (result *V3DecryptBrandProfitShareResult, err error) {
+	if v.Resource != nil {
+		if v.Resource.Algorithm == AlgorithmSM4GCM {
+			err = v.decryptSM4(apiV3Key, &result)
+		} else {
+			result, err = V3DecryptBrandProfitShareNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		}
+		if err != nil {
+			bytes, _ := json.Marshal(v)
+			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%w)", string(bytes), err)
+		}
+		return result, nil
+	}
+	return nil, errors.New("notify data Resource is nil")
+}
+
+// 解密商户违规通知回调中的加密信息
+func (v *V3NotifyReq) DecryptViolationCipherText(apiV3Key string) (result *V3DecryptViolationResult, err error) {
+	if v.Resource != nil {
+		if v.Resource.Algorithm == AlgorithmSM4GCM {
+			err = v.decryptSM4(apiV3Key, &result)
+		} else {
+			result, err = V3DecryptViolationNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		}
+		if err != nil {
+			bytes, _ := json.Marshal(v)
+			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%w)", string(bytes), err)
+		}
+		return result, nil
+	}
+	return nil, errors.New("notify data Resource is nil")
+}
+
+// 解密跨境支付回调中的加密信息
+func (v *V3NotifyReq) DecryptGlobalCipherText(apiV3Key string) (result *V3DecryptGlobalResult, err error) {
+	if v.Resource != nil {
+		if v.Resource.Algorithm == AlgorithmSM4GCM {
+			err = v.decryptSM4(apiV3Key, &result)
+		} else {
+			result, err = V3DecryptGlobalNotifyCipherText(v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, apiV3Key)
+		}
 		if err != nil {
 			bytes, _ := json.Marshal(v)
 			return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%w)", string(bytes), err)
@@ -0,0 +1,166 @@
+package wechat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/misu99/gopay"
+)
+
+// V3NotifyEvent 解密后的回调通知事件
+type V3NotifyEvent struct {
+	EventType    string        // 通知类型，如 TRANSACTION.SUCCESS
+	OriginalType string        // 原始回调类型，如 transaction
+	NotifyReq    *V3NotifyReq  // 原始回调请求
+	Result       any           // 根据 event_type 解析的结构体指针，如 *V3DecryptResult；未知类型为 nil
+	BodyMap      gopay.BodyMap // 解密后的原始数据
+}
+
+// 解密回调中的加密信息，并根据 event_type、original_type 解析到对应的结构体
+// 未知类型的 event.Result 为 nil，解密后的数据通过 event.BodyMap 获取
+func (v *V3NotifyReq) DecryptEvent(apiV3Key string) (event *V3NotifyEvent, err error) {
+	if v.Resource == nil {
+		return nil, errors.New("notify data Resource is nil")
+	}
+	decrypt, err := v3DecryptResource(v.Resource.Algorithm, v.Resource.Ciphertext, v.Resource.Nonce, v.Resource.AssociatedData, []byte(apiV3Key))
+	if err != nil {
+		bytes, _ := json.Marshal(v)
+		return nil, fmt.Errorf("V3NotifyReq(%s) decrypt cipher text error(%w)", string(bytes), err)
+	}
+	event = &V3NotifyEvent{
+		EventType:    v.EventType,
+		OriginalType: v.Resource.OriginalType,
+		NotifyReq:    v,
+		BodyMap:      make(gopay.BodyMap),
+	}
+	if err = json.Unmarshal(decrypt, &event.BodyMap); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(%s), err:%w", string(decrypt), err)
+	}
+	if event.Result = newNotifyEventResult(event.EventType, event.OriginalType, event.BodyMap); event.Result != nil {
+		if err = json.Unmarshal(decrypt, event.Result); err != nil {
+			return nil, fmt.Errorf("json.Unmarshal(%s), err:%w", string(decrypt), err)
+		}
+	}
+	return event, nil
+}
+
+// 根据 event_type、original_type 及解密后的字段确定回调数据结构体
+func newNotifyEventResult(eventType, originalType string, bm gopay.BodyMap) any {
+	_, isPartner := bm["sp_mchid"]
+	switch {
+	case strings.HasPrefix(eventType, "TRANSACTION.") || originalType == "transaction":
+		if _, ok := bm["combine_out_trade_no"]; ok {
+			return new(V3DecryptCombineResult)
+		}
+		if _, ok := bm["contract_id"]; ok {
+			return new(V3DecryptPapayPayResult)
+		}
+		if amount, ok := bm["amount"].(map[string]any); ok {
+			if _, ok = amount["exchange_rate"]; ok {
+				return new(V3DecryptGlobalResult)
+			}
+		}
+		if isPartner {
+			return new(V3DecryptPartnerResult)
+		}
+		return new(V3DecryptResult)
+	case strings.HasPrefix(eventType, "REFUND.") || originalType == "refund":
+		if isPartner {
+			return new(V3DecryptPartnerRefundResult)
+		}
+		return new(V3DecryptRefundResult)
+	case eventType == EventPayScoreUserPaid || eventType == EventPayScoreUserConfirm:
+		return new(V3DecryptScoreResult)
+	case strings.HasPrefix(eventType, "PROFITSHARING") || originalType == "profitsharing":
+		if _, ok := bm["brand_mchid"]; ok {
+			return new(V3DecryptBrandProfitShareResult)
+		}
+		return new(V3DecryptProfitShareResult)
+	case eventType == EventCouponSend:
+		return new(V3DecryptBusifavorResult)
+	case strings.HasPrefix(eventType, "MCHTRANSFER.BATCH."):
+		return new(V3DecryptTransferBatchResult)
+	case strings.HasPrefix(eventType, "MCHTRANSFER.BILL."):
+		return new(V3DecryptTransferBillsResult)
+	case strings.HasPrefix(eventType, "COMPLAINT."):
+		return new(V3DecryptComplaintResult)
+	case strings.HasPrefix(eventType, "FAPIAO."):
+		return new(V3DecryptFapiaoResult)
+	case strings.HasPrefix(eventType, "VIOLATION."):
+		return new(V3DecryptViolationResult)
+	case strings.HasPrefix(eventType, "PAPAY."):
+		return new(V3DecryptPapayContractResult)
+	}
+	return nil
+}
+
+// V3NotifyHandler 回调通知事件处理函数
+type V3NotifyHandler func(event *V3NotifyEvent) error
+
+// V3NotifyDispatcher 回调通知事件分发器
+// 验签、解密回调后，按 event_type 分发到注册的处理函数
+type V3NotifyDispatcher struct {
+	client   *ClientV3
+	mu       sync.RWMutex
+	handlers map[string]V3NotifyHandler
+	fallback V3NotifyHandler
+}
+
+// NewV3NotifyDispatcher 初始化回调通知事件分发器
+// client：用于回调验签（client.VerifyNotifySign()）及解密（client.ApiV3Key）
+func NewV3NotifyDispatcher(client *ClientV3) (d *V3NotifyDispatcher) {
+	return &V3NotifyDispatcher{
+		client:   client,
+		handlers: make(map[string]V3NotifyHandler),
+	}
+}
+
+// On 注册 event_type 对应的处理函数，如 wechat.EventTransactionSuccess
+func (d *V3NotifyDispatcher) On(eventType string, handler V3NotifyHandler) *V3NotifyDispatcher {
+	d.mu.Lock()
+	d.handlers[eventType] = handler
+	d.mu.Unlock()
+	return d
+}
+
+// OnUnknown 注册未找到 event_type 处理函数时的兜底处理函数，可通过 event.BodyMap 获取解密后的数据
+func (d *V3NotifyDispatcher) OnUnknown(handler V3NotifyHandler) *V3NotifyDispatcher {
+	d.mu.Lock()
+	d.fallback = handler
+	d.mu.Unlock()
+	return d
+}
+
+// Dispatch 验签、解密回调通知，并分发到对应的处理函数
+// 未注册处理函数且未设置兜底处理函数时，忽略该通知并返回 event
+func (d *V3NotifyDispatcher) Dispatch(notifyReq *V3NotifyReq) (event *V3NotifyEvent, err error) {
+	if err = d.client.VerifyNotifySign(notifyReq); err != nil {
+		return nil, err
+	}
+	if event, err = notifyReq.DecryptEvent(string(d.client.ApiV3Key)); err != nil {
+		return nil, err
+	}
+	d.mu.RLock()
+	handler, ok := d.handlers[event.EventType]
+	if !ok {
+		handler = d.fallback
+	}
+	d.mu.RUnlock()
+	if handler == nil {
+		return event, nil
+	}
+	return event, handler(event)
+}
+
+// DispatchRequest 解析微信回调请求，并验签、解密、分发到对应的处理函数
+func (d *V3NotifyDispatcher) DispatchRequest(req *http.Request) (event *V3NotifyEvent, err error) {
+	notifyReq, err := V3ParseNotify(req)
+	if err != nil {
+		return nil, err
+	}
+	return d.Dispatch(notifyReq)
+}
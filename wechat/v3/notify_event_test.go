@@ -0,0 +1,183 @@
+package wechat
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// 模拟微信回调：使用 APIv3Key 加密 resource，并使用与 publicPKCS1 对应的私钥签名
+func testNotifyBody(eventType, originalType, plaintext string) string {
+	block, _ := aes.NewCipher([]byte(testAPIv3Key))
+	gcm, _ := cipher.NewGCM(block)
+	ciphertext := gcm.Seal(nil, []byte("fdasflkja484"), []byte(plaintext), []byte(originalType))
+	return fmt.Sprintf(`{"id":"EV-2018022511223320873","create_time":"2015-05-20T13:29:35+08:00","resource_type":"encrypt-resource","event_type":"%s","summary":"通知","resource":{"original_type":"%s","algorithm":"AEAD_AES_256_GCM","ciphertext":"%s","associated_data":"%s","nonce":"fdasflkja484"}}`,
+		eventType, originalType, base64.StdEncoding.EncodeToString(ciphertext), originalType)
+}
+
+func testNotifyRequest(t *testing.T, c *ClientV3, serialNo, body string) *http.Request {
+	t.Helper()
+	ts, nonce := "1554208460", "593BEC0C930BF1AFEB40B4A08C8FB242"
+	sign, err := c.rsaSign(ts + "\n" + nonce + "\n" + body + "\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest(http.MethodPost, "/notify", strings.NewReader(body))
+	req.Header.Set(HeaderTimestamp, ts)
+	req.Header.Set(HeaderNonce, nonce)
+	req.Header.Set(HeaderSerial, serialNo)
+	req.Header.Set(HeaderSignature, sign)
+	return req
+}
+
+func testNotifyReq(t *testing.T, c *ClientV3, serialNo, eventType, originalType, plaintext string) *V3NotifyReq {
+	t.Helper()
+	notifyReq, err := V3ParseNotify(testNotifyRequest(t, c, serialNo, testNotifyBody(eventType, originalType, plaintext)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return notifyReq
+}
+
+func TestV3NotifyReqDecryptEvent(t *testing.T) {
+	tests := []struct {
+		eventType    string
+		originalType string
+		plaintext    string
+		want         any
+	}{
+		{EventTransactionSuccess, "transaction", `{"mchid":"1230000109","appid":"wxd678efh567hg6787","out_trade_no":"1217752501201407033233368018","trade_state":"SUCCESS","amount":{"total":100,"currency":"CNY"}}`, &V3DecryptResult{}},
+		{EventTransactionSuccess, "transaction", `{"sp_mchid":"1230000109","sub_mchid":"1900000109","out_trade_no":"1217752501201407033233368018","trade_state":"SUCCESS"}`, &V3DecryptPartnerResult{}},
+		{EventTransactionSuccess, "transaction", `{"combine_appid":"wxd678efh567hg6787","combine_mchid":"1230000109","combine_out_trade_no":"20150806125346","sub_orders":[]}`, &V3DecryptCombineResult{}},
+		{EventTransactionSuccess, "transaction", `{"mchid":"1230000109","out_trade_no":"1217752501201407033233368018","trade_type":"PAP","contract_id":"Wx15463511252015071056489715"}`, &V3DecryptPapayPayResult{}},
+		{EventTransactionSuccess, "transaction", `{"mchid":"1230000109","out_trade_no":"1217752501201407033233368018","amount":{"total":100,"currency":"USD","exchange_rate":{"type":"SETTLEMENT_RATE","rate":650000000}}}`, &V3DecryptGlobalResult{}},
+		{EventRefundSuccess, "refund", `{"mchid":"1900000100","out_trade_no":"20150806125346","refund_id":"50200207182018070300011301001","refund_status":"SUCCESS"}`, &V3DecryptRefundResult{}},
+		{EventRefundAbnormal, "refund", `{"sp_mchid":"1900000100","sub_mchid":"1900000109","out_refund_no":"1217752501201407033233368018","refund_status":"ABNORMAL"}`, &V3DecryptPartnerRefundResult{}},
+		{EventPayScoreUserPaid, "payscore", `{"appid":"wxd678efh567hg6787","mchid":"1230000109","out_order_no":"1234323JKHDFE1243252","state":"DONE"}`, &V3DecryptScoreResult{}},
+		{"PROFITSHARING.SUCCESS", "profitsharing", `{"mchid":"1900000100","transaction_id":"4200000000000000000000000000","order_id":"3008450740201411110007820472","out_order_no":"P20150806125346"}`, &V3DecryptProfitShareResult{}},
+		{"PROFITSHARING.SUCCESS", "profitsharing", `{"brand_mchid":"1900000108","sub_mchid":"1900000109","transaction_id":"4200000000000000000000000000","out_order_no":"P20150806125346"}`, &V3DecryptBrandProfitShareResult{}},
+		{EventCouponSend, "busifavor", `{"event_type":"EVENT_TYPE_BUSICOUPON_SEND","coupon_code":"sxxe34343434","stock_id":"128888000000001","send_time":"2019-12-30T13:29:35+08:00"}`, &V3DecryptBusifavorResult{}},
+		{EventTransferBatchFinished, "mch_payment", `{"mchid":"1900001109","out_batch_no":"bfatestnotify000033","batch_id":"131000007026709999520922023081519403795655","batch_status":"FINISHED","total_num":2}`, &V3DecryptTransferBatchResult{}},
+		{EventTransferBillFinished, "mch_payment", `{"mch_id":"1900001109","out_bill_no":"plfk2020042013","transfer_bill_no":"1330000071100999991182020050700019480001","state":"SUCCESS","transfer_amount":2000}`, &V3DecryptTransferBillsResult{}},
+		{EventComplaintCreate, "payment", `{"complaint_id":"200201820200101080076610000","action_type":"CREATE_COMPLAINT"}`, &V3DecryptComplaintResult{}},
+		{EventFapiaoIssued, "fapiao", `{"mchid":"1900001109","fapiao_apply_id":"4200000444201910177461284488","apply_time":"2020-07-01T12:00:00+08:00"}`, &V3DecryptFapiaoResult{}},
+		{EventViolationPunish, "violation", `{"sub_mchid":"1900001109","company_name":"腾讯","record_id":"200201820200101080076610000","punish_plan":"暂停收款"}`, &V3DecryptViolationResult{}},
+		{EventPapayContractSign, "papay", `{"appid":"wxd678efh567hg6787","mchid":"1230000109","out_contract_code":"1234323JKHDFE1243252","plan_id":123,"contract_id":"Wx15463511252015071056489715","contract_state":"ONGOING"}`, &V3DecryptPapayContractResult{}},
+		{"UNKNOWN.EVENT", "unknown", `{"foo":"bar"}`, nil},
+	}
+	c := newTestClientV3(t, "1230000109", "")
+	for _, tt := range tests {
+		notifyReq := testNotifyReq(t, c, "SN1", tt.eventType, tt.originalType, tt.plaintext)
+		event, err := notifyReq.DecryptEvent(testAPIv3Key)
+		if err != nil {
+			t.Fatalf("DecryptEvent(%s) err: %v", tt.eventType, err)
+		}
+		if fmt.Sprintf("%T", event.Result) != fmt.Sprintf("%T", tt.want) {
+			t.Errorf("DecryptEvent(%s, %s) Result = %T, want %T", tt.eventType, tt.plaintext, event.Result, tt.want)
+		}
+		if event.EventType != tt.eventType || event.OriginalType != tt.originalType || len(event.BodyMap) == 0 || event.NotifyReq != notifyReq {
+			t.Errorf("DecryptEvent(%s) = %+v", tt.eventType, event)
+		}
+	}
+	// APIv3Key 错误
+	notifyReq := testNotifyReq(t, c, "SN1", EventTransactionSuccess, "transaction", `{}`)
+	if _, err := notifyReq.DecryptEvent(strings.Repeat("0", 32)); err == nil {
+		t.Fatal("DecryptEvent() with wrong key should return error")
+	}
+	if _, err := (&V3NotifyReq{}).DecryptEvent(testAPIv3Key); err == nil {
+		t.Fatal("DecryptEvent() without resource should return error")
+	}
+}
+
+func TestV3NotifyReqDecryptCipherText(t *testing.T) {
+	c := newTestClientV3(t, "1230000109", "")
+	papay := testNotifyReq(t, c, "SN1", EventPapayContractSign, "papay", `{"mchid":"1230000109","plan_id":123,"contract_id":"Wx15463511252015071056489715","contract_state":"ONGOING"}`)
+	if rsp, err := papay.DecryptPapayContractCipherText(testAPIv3Key); err != nil || rsp.ContractId != "Wx15463511252015071056489715" || rsp.PlanId != 123 {
+		t.Errorf("DecryptPapayContractCipherText() = %+v, %v", rsp, err)
+	}
+	pay := testNotifyReq(t, c, "SN1", EventTransactionSuccess, "transaction", `{"mchid":"1230000109","trade_type":"PAP","contract_id":"Wx15463511252015071056489715"}`)
+	if rsp, err := pay.DecryptPapayPayCipherText(testAPIv3Key); err != nil || rsp.ContractId != "Wx15463511252015071056489715" {
+		t.Errorf("DecryptPapayPayCipherText() = %+v, %v", rsp, err)
+	}
+	fapiao := testNotifyReq(t, c, "SN1", EventFapiaoIssued, "fapiao", `{"mchid":"1900001109","fapiao_apply_id":"4200000444201910177461284488"}`)
+	if rsp, err := fapiao.DecryptFapiaoCipherText(testAPIv3Key); err != nil || rsp.FapiaoApplyId != "4200000444201910177461284488" {
+		t.Errorf("DecryptFapiaoCipherText() = %+v, %v", rsp, err)
+	}
+	brand := testNotifyReq(t, c, "SN1", "PROFITSHARING.SUCCESS", "profitsharing", `{"brand_mchid":"1900000108","sub_mchid":"1900000109","out_order_no":"P20150806125346"}`)
+	if rsp, err := brand.DecryptBrandProfitShareCipherText(testAPIv3Key); err != nil || rsp.BrandMchid != "1900000108" {
+		t.Errorf("DecryptBrandProfitShareCipherText() = %+v, %v", rsp, err)
+	}
+	violation := testNotifyReq(t, c, "SN1", EventViolationPunish, "violation", `{"sub_mchid":"1900001109","record_id":"200201820200101080076610000"}`)
+	if rsp, err := violation.DecryptViolationCipherText(testAPIv3Key); err != nil || rsp.RecordId != "200201820200101080076610000" {
+		t.Errorf("DecryptViolationCipherText() = %+v, %v", rsp, err)
+	}
+	global := testNotifyReq(t, c, "SN1", EventTransactionSuccess, "transaction", `{"mchid":"1230000109","amount":{"total":100,"currency":"USD","exchange_rate":{"type":"SETTLEMENT_RATE","rate":650000000}}}`)
+	if rsp, err := global.DecryptGlobalCipherText(testAPIv3Key); err != nil || rsp.Amount == nil || rsp.Amount.ExchangeRate == nil || rsp.Amount.ExchangeRate.Rate != 650000000 {
+		t.Errorf("DecryptGlobalCipherText() = %+v, %v", rsp, err)
+	}
+	// APIv3Key 错误、缺少 resource
+	if _, err := papay.DecryptPapayContractCipherText(strings.Repeat("0", 32)); err == nil {
+		t.Error("DecryptPapayContractCipherText() with wrong key should return error")
+	}
+	if _, err := (&V3NotifyReq{}).DecryptPapayPayCipherText(testAPIv3Key); err == nil {
+		t.Error("DecryptPapayPayCipherText() without resource should return error")
+	}
+}
+
+func TestV3NotifyDispatcher(t *testing.T) {
+	c := newTestClientV3(t, "1230000109", "")
+	if err := c.setPlatformCerts("SN1", map[string]string{"SN1": publicPKCS1}, true); err != nil {
+		t.Fatal(err)
+	}
+	var (
+		paid    *V3DecryptResult
+		unknown string
+	)
+	errRefund := errors.New("refund handler error")
+	d := NewV3NotifyDispatcher(c).
+		On(EventTransactionSuccess, func(event *V3NotifyEvent) error {
+			paid = event.Result.(*V3DecryptResult)
+			return nil
+		}).
+		On(EventRefundSuccess, func(event *V3NotifyEvent) error { return errRefund })
+
+	// 注册的处理函数
+	event, err := d.Dispatch(testNotifyReq(t, c, "SN1", EventTransactionSuccess, "transaction", `{"mchid":"1230000109","out_trade_no":"1217752501201407033233368018","trade_state":"SUCCESS"}`))
+	if err != nil || paid == nil || paid.OutTradeNo != "1217752501201407033233368018" || event.Result != paid {
+		t.Fatalf("Dispatch() = %+v, %v, paid: %+v", event, err, paid)
+	}
+	// 处理函数返回的错误
+	if _, err = d.Dispatch(testNotifyReq(t, c, "SN1", EventRefundSuccess, "refund", `{"out_refund_no":"1217752501201407033233368018"}`)); !errors.Is(err, errRefund) {
+		t.Fatalf("Dispatch() err: %v, want %v", err, errRefund)
+	}
+	// 未注册处理函数且无兜底处理函数时忽略
+	if event, err = d.Dispatch(testNotifyReq(t, c, "SN1", EventComplaintCreate, "payment", `{"complaint_id":"200201820200101080076610000"}`)); err != nil || event == nil {
+		t.Fatalf("Dispatch() = %+v, %v", event, err)
+	}
+	// 兜底处理函数
+	d.OnUnknown(func(event *V3NotifyEvent) error {
+		unknown = event.BodyMap.GetString("complaint_id")
+		return nil
+	})
+	if _, err = d.Dispatch(testNotifyReq(t, c, "SN1", EventComplaintCreate, "payment", `{"complaint_id":"200201820200101080076610000"}`)); err != nil || unknown != "200201820200101080076610000" {
+		t.Fatalf("Dispatch() err: %v, unknown: %s", err, unknown)
+	}
+	// 验签失败不解密、不分发
+	paid = nil
+	notifyReq := testNotifyReq(t, c, "SN1", EventTransactionSuccess, "transaction", `{"out_trade_no":"1217752501201407033233368018"}`)
+	notifyReq.SignInfo.SignBody += " "
+	if event, err = d.Dispatch(notifyReq); err == nil || event != nil || paid != nil {
+		t.Fatalf("Dispatch() with bad sign = %+v, %v", event, err)
+	}
+
+	// DispatchRequest
+	req := testNotifyRequest(t, c, "SN1", testNotifyBody(EventTransactionSuccess, "transaction", `{"out_trade_no":"20150806125346"}`))
+	if _, err = d.DispatchRequest(req); err != nil || paid == nil || paid.OutTradeNo != "20150806125346" {
+		t.Fatalf("DispatchRequest() err: %v, paid: %+v", err, paid)
+	}
+}
@@ -0,0 +1,276 @@
+package wechat
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/misu99/gopay"
+)
+
+const defaultPageLimit = 10 // 默认分页大小
+
+// PageFetcher 获取从 offset 开始、最多 limit 条的一页数据
+// totalCount：总条数，未返回时传 0
+type PageFetcher[T any] func(ctx context.Context, offset, limit int) (items []T, totalCount int, err error)
+
+// Pager 分页迭代器，自动翻页直到取完 total_count 条数据
+//
+//	pager := client.V3ComplaintListPager(ctx, bm).SetLimit(50)
+//	for pager.Next() {
+//		item := pager.Item()
+//	}
+//	if err := pager.Err(); err != nil {
+//	}
+type Pager[T any] struct {
+	ctx         context.Context
+	fetch       PageFetcher[T]
+	limit       int
+	maxLimit    int // 接口允许的最大分页大小，0 表示不限制
+	concurrency int
+
+	buf    []T
+	cur    T
+	offset int
+	total  int // 总条数，-1 表示未知
+	done   bool
+	err    error
+}
+
+// NewPager 初始化分页迭代器
+func NewPager[T any](ctx context.Context, fetch PageFetcher[T]) (p *Pager[T]) {
+	return &Pager[T]{
+		ctx:         ctx,
+		fetch:       fetch,
+		limit:       defaultPageLimit,
+		concurrency: 1,
+		total:       -1,
+	}
+}
+
+// SetLimit 设置分页大小，默认 10，超过接口文档的最大值时按最大值处理
+func (p *Pager[T]) SetLimit(limit int) *Pager[T] {
+	if limit > 0 {
+		p.limit = limit
+	}
+	if p.maxLimit > 0 && p.limit > p.maxLimit {
+		p.limit = p.maxLimit
+	}
+	return p
+}
+
+// Limit 实际使用的分页大小
+func (p *Pager[T]) Limit() int {
+	return p.limit
+}
+
+// 设置接口允许的最大分页大小
+func (p *Pager[T]) setMaxLimit(maxLimit int) *Pager[T] {
+	p.maxLimit = maxLimit
+	return p.SetLimit(p.limit)
+}
+
+// SetConcurrency 设置并发请求页数，默认 1
+// 获取到首页 total_count 后，后续分页按该并发数同时请求，返回顺序不变
+func (p *Pager[T]) SetConcurrency(n int) *Pager[T] {
+	if n > 0 {
+		p.concurrency = n
+	}
+	return p
+}
+
+// Next 移动到下一条数据，没有更多数据或出错时返回 false
+func (p *Pager[T]) Next() bool {
+	for len(p.buf) == 0 {
+		if p.done || p.err != nil {
+			return false
+		}
+		p.err = p.load()
+	}
+	p.cur, p.buf = p.buf[0], p.buf[1:]
+	return true
+}
+
+// Item 当前数据
+func (p *Pager[T]) Item() T {
+	return p.cur
+}
+
+// Err 翻页过程中的错误
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// TotalCount 总条数，获取到首页前为 -1
+func (p *Pager[T]) TotalCount() int {
+	return p.total
+}
+
+// All 获取剩余全部数据
+func (p *Pager[T]) All() (items []T, err error) {
+	for p.Next() {
+		items = append(items, p.Item())
+	}
+	return items, p.Err()
+}
+
+func (p *Pager[T]) load() (err error) {
+	if err = p.ctx.Err(); err != nil {
+		return err
+	}
+	if p.total < 0 || p.concurrency <= 1 {
+		items, total, err := p.fetch(p.ctx, p.offset, p.limit)
+		if err != nil {
+			return err
+		}
+		p.page(items, total)
+		return nil
+	}
+	n := (p.total - p.offset + p.limit - 1) / p.limit
+	if n > p.concurrency {
+		n = p.concurrency
+	}
+	var (
+		wg      sync.WaitGroup
+		results = make([][]T, n)
+		errs    = make([]error, n)
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _, errs[i] = p.fetch(p.ctx, p.offset+i*p.limit, p.limit)
+		}(i)
+	}
+	wg.Wait()
+	for i := 0; i < n; i++ {
+		if errs[i] != nil {
+			return errs[i]
+		}
+		if p.page(results[i], 0); p.done {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (p *Pager[T]) page(items []T, total int) {
+	// 部分接口仅 offset=0 时返回 total_count，沿用首页的值
+	if total > 0 && p.total < 0 {
+		p.total = total
+	}
+	p.buf = append(p.buf, items...)
+	p.offset += p.limit
+	switch {
+	case len(items) == 0:
+		p.done = true
+	case p.total >= 0 && p.offset >= p.total:
+		p.done = true
+	case p.total < 0 && len(items) < p.limit:
+		p.done = true
+	}
+}
+
+// 复制 bm 并设置分页参数，避免并发请求时修改调用方的 bm
+func pageBodyMap(bm gopay.BodyMap, offset, limit int) gopay.BodyMap {
+	pbm := make(gopay.BodyMap, len(bm)+2)
+	for k, v := range bm {
+		pbm[k] = v
+	}
+	pbm.Set("offset", offset).Set("limit", limit)
+	return pbm
+}
+
+func pageRspErr(api string, code int, errMsg string) error {
+	return fmt.Errorf("%s: code[%d], error: %s", api, code, errMsg)
+}
+
+// V3ComplaintListPager 查询投诉单列表 分页迭代器
+// bm：除 offset、limit 外的查询参数，分页大小最大 50
+func (c *ClientV3) V3ComplaintListPager(ctx context.Context, bm gopay.BodyMap) *Pager[*ComplaintListItem] {
+	return NewPager(ctx, func(ctx context.Context, offset, limit int) ([]*ComplaintListItem, int, error) {
+		wxRsp, err := c.V3ComplaintList(ctx, pageBodyMap(bm, offset, limit))
+		if err != nil {
+			return nil, 0, err
+		}
+		if wxRsp.Code != Success {
+			return nil, 0, pageRspErr("V3ComplaintList", wxRsp.Code, wxRsp.Error)
+		}
+		return wxRsp.Response.Data, wxRsp.Response.TotalCount, nil
+	}).setMaxLimit(50)
+}
+
+// V3FavorBatchListPager 条件查询代金券批次列表 分页迭代器
+// bm：除 offset、limit 外的查询参数，分页大小最大 10（该接口 offset 为页码，自动换算）
+func (c *ClientV3) V3FavorBatchListPager(ctx context.Context, bm gopay.BodyMap) *Pager[*FavorBatch] {
+	return NewPager(ctx, func(ctx context.Context, offset, limit int) ([]*FavorBatch, int, error) {
+		wxRsp, err := c.V3FavorBatchList(ctx, pageBodyMap(bm, offset/limit, limit))
+		if err != nil {
+			return nil, 0, err
+		}
+		if wxRsp.Code != Success {
+			return nil, 0, pageRspErr("V3FavorBatchList", wxRsp.Code, wxRsp.Error)
+		}
+		return wxRsp.Response.Data, wxRsp.Response.TotalCount, nil
+	}).setMaxLimit(10)
+}
+
+// V3BusiFavorUserCouponsPager 根据过滤条件查询用户券 分页迭代器
+// bm：除 offset、limit 外的查询参数，分页大小最大 50
+func (c *ClientV3) V3BusiFavorUserCouponsPager(ctx context.Context, openid string, bm gopay.BodyMap) *Pager[*BusiUserCoupon] {
+	return NewPager(ctx, func(ctx context.Context, offset, limit int) ([]*BusiUserCoupon, int, error) {
+		wxRsp, err := c.V3BusiFavorUserCoupons(ctx, openid, pageBodyMap(bm, offset, limit))
+		if err != nil {
+			return nil, 0, err
+		}
+		if wxRsp.Code != Success {
+			return nil, 0, pageRspErr("V3BusiFavorUserCoupons", wxRsp.Code, wxRsp.Error)
+		}
+		return wxRsp.Response.Data, wxRsp.Response.TotalCount, nil
+	}).setMaxLimit(50)
+}
+
+// V3PartnershipsListPager 查询合作关系列表 分页迭代器
+// bm：除 offset、limit 外的查询参数，分页大小最大 50
+func (c *ClientV3) V3PartnershipsListPager(ctx context.Context, bm gopay.BodyMap) *Pager[*Partnerships] {
+	return NewPager(ctx, func(ctx context.Context, offset, limit int) ([]*Partnerships, int, error) {
+		wxRsp, err := c.V3PartnershipsList(ctx, pageBodyMap(bm, offset, limit))
+		if err != nil {
+			return nil, 0, err
+		}
+		if wxRsp.Code != Success {
+			return nil, 0, pageRspErr("V3PartnershipsList", wxRsp.Code, wxRsp.Error)
+		}
+		return wxRsp.Response.Data, wxRsp.Response.TotalCount, nil
+	}).setMaxLimit(50)
+}
+
+// V3EcommerceIncomeRecordPager 特约商户银行来账查询 分页迭代器
+// bm：除 offset、limit 外的查询参数，分页大小最大 100
+func (c *ClientV3) V3EcommerceIncomeRecordPager(ctx context.Context, bm gopay.BodyMap) *Pager[*PartnerIncomeData] {
+	return NewPager(ctx, func(ctx context.Context, offset, limit int) ([]*PartnerIncomeData, int, error) {
+		wxRsp, err := c.V3EcommerceIncomeRecord(ctx, pageBodyMap(bm, offset, limit))
+		if err != nil {
+			return nil, 0, err
+		}
+		if wxRsp.Code != Success {
+			return nil, 0, pageRspErr("V3EcommerceIncomeRecord", wxRsp.Code, wxRsp.Error)
+		}
+		return wxRsp.Response.Data, wxRsp.Response.TotalCount, nil
+	}).setMaxLimit(100)
+}
+
+// V3BankSearchBranchListPager 查询支行列表 分页迭代器
+// 分页大小最大 200
+func (c *ClientV3) V3BankSearchBranchListPager(ctx context.Context, bankAliasCode string, cityCode int) *Pager[*BankBranchInfo] {
+	return NewPager(ctx, func(ctx context.Context, offset, limit int) ([]*BankBranchInfo, int, error) {
+		wxRsp, err := c.V3BankSearchBranchList(ctx, bankAliasCode, cityCode, limit, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		if wxRsp.Code != Success {
+			return nil, 0, pageRspErr("V3BankSearchBranchList", wxRsp.Code, wxRsp.Error)
+		}
+		return wxRsp.Response.Data, wxRsp.Response.TotalCount, nil
+	}).setMaxLimit(200)
+}
@@ -0,0 +1,191 @@
+package wechat
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeFetcher 模拟分页接口，data 为全部数据
+type fakeFetcher struct {
+	data      []int
+	total     func(offset int) int // 返回的 total_count
+	failAt    int                  // offset 为该值时返回错误，-1 不出错
+	mu        sync.Mutex
+	offsets   []int
+	limits    []int
+	inFlight  int32
+	maxFlight int32
+}
+
+var errFetch = errors.New("fetch error")
+
+func newFakeFetcher(n int, total func(offset int) int) *fakeFetcher {
+	f := &fakeFetcher{total: total, failAt: -1}
+	for i := 0; i < n; i++ {
+		f.data = append(f.data, i)
+	}
+	return f
+}
+
+func (f *fakeFetcher) fetch(ctx context.Context, offset, limit int) ([]int, int, error) {
+	if n := atomic.AddInt32(&f.inFlight, 1); n > atomic.LoadInt32(&f.maxFlight) {
+		atomic.StoreInt32(&f.maxFlight, n)
+	}
+	defer atomic.AddInt32(&f.inFlight, -1)
+	time.Sleep(5 * time.Millisecond)
+	f.mu.Lock()
+	f.offsets = append(f.offsets, offset)
+	f.limits = append(f.limits, limit)
+	f.mu.Unlock()
+	if offset == f.failAt {
+		return nil, 0, errFetch
+	}
+	var items []int
+	for i := offset; i < offset+limit && i < len(f.data); i++ {
+		items = append(items, f.data[i])
+	}
+	return items, f.total(offset), nil
+}
+
+func (f *fakeFetcher) calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.offsets)
+}
+
+func checkItems(t *testing.T, items []int, n int) {
+	t.Helper()
+	if len(items) != n {
+		t.Fatalf("len(items) = %d, want %d", len(items), n)
+	}
+	for i, v := range items {
+		if v != i {
+			t.Fatalf("items[%d] = %d, want %d", i, v, i)
+		}
+	}
+}
+
+func TestPager(t *testing.T) {
+	tests := []struct {
+		name        string
+		n           int
+		total       func(offset int) int
+		concurrency int
+		wantCalls   int
+	}{
+		{"known total", 25, func(int) int { return 25 }, 1, 3},
+		{"known total on first page only", 25, func(offset int) int {
+			if offset == 0 {
+				return 25
+			}
+			return 0
+		}, 1, 3},
+		{"unknown total short last page", 25, func(int) int { return 0 }, 1, 3},
+		{"unknown total full last page", 20, func(int) int { return 0 }, 1, 3},
+		{"empty", 0, func(int) int { return 0 }, 1, 1},
+		{"concurrency", 95, func(int) int { return 95 }, 4, 10},
+		{"concurrency unknown total", 25, func(int) int { return 0 }, 4, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeFetcher(tt.n, tt.total)
+			p := NewPager(context.Background(), f.fetch).SetConcurrency(tt.concurrency)
+			if p.TotalCount() != -1 {
+				t.Fatalf("TotalCount() = %d before first page", p.TotalCount())
+			}
+			items, err := p.All()
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkItems(t, items, tt.n)
+			if f.calls() != tt.wantCalls {
+				t.Fatalf("calls = %d (offsets %v), want %d", f.calls(), f.offsets, tt.wantCalls)
+			}
+			if p.Next() || f.calls() != tt.wantCalls {
+				t.Fatal("Next() after done should not fetch")
+			}
+		})
+	}
+
+	// 并发请求
+	f := newFakeFetcher(95, func(int) int { return 95 })
+	if _, err := NewPager(context.Background(), f.fetch).SetConcurrency(4).All(); err != nil {
+		t.Fatal(err)
+	}
+	if f.maxFlight < 2 || f.maxFlight > 4 {
+		t.Fatalf("max in-flight = %d, want 2..4", f.maxFlight)
+	}
+}
+
+func TestPagerError(t *testing.T) {
+	// 并发批次中间页出错：出错页之前的数据正常返回，之后不再请求
+	f := newFakeFetcher(95, func(int) int { return 95 })
+	f.failAt = 40
+	p := NewPager(context.Background(), f.fetch).SetConcurrency(3)
+	items, err := p.All()
+	if !errors.Is(err, errFetch) {
+		t.Fatalf("All() err: %v", err)
+	}
+	checkItems(t, items, 40)
+	calls := f.calls()
+	if p.Next() || f.calls() != calls || !errors.Is(p.Err(), errFetch) {
+		t.Fatal("Next() after error should not fetch")
+	}
+
+	// 顺序请求出错
+	f = newFakeFetcher(25, func(int) int { return 0 })
+	f.failAt = 10
+	items, err = NewPager(context.Background(), f.fetch).All()
+	if !errors.Is(err, errFetch) {
+		t.Fatalf("All() err: %v", err)
+	}
+	checkItems(t, items, 10)
+
+	// context 取消
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	f = newFakeFetcher(25, func(int) int { return 25 })
+	if _, err = NewPager(ctx, f.fetch).All(); !errors.Is(err, context.Canceled) || f.calls() != 0 {
+		t.Fatalf("All() err: %v, calls: %d", err, f.calls())
+	}
+}
+
+func TestPagerLimit(t *testing.T) {
+	f := newFakeFetcher(25, func(int) int { return 25 })
+	p := NewPager(context.Background(), f.fetch).setMaxLimit(10).SetLimit(50)
+	if p.Limit() != 10 {
+		t.Fatalf("Limit() = %d, want 10", p.Limit())
+	}
+	if _, err := p.All(); err != nil {
+		t.Fatal(err)
+	}
+	for _, limit := range f.limits {
+		if limit != 10 {
+			t.Fatalf("fetch limit = %d, want 10", limit)
+		}
+	}
+
+	c := &ClientV3{}
+	for _, tt := range []struct {
+		name  string
+		limit int
+		want  int
+	}{
+		{"V3ComplaintListPager", c.V3ComplaintListPager(ctx, nil).SetLimit(100).Limit(), 50},
+		{"V3FavorBatchListPager", c.V3FavorBatchListPager(ctx, nil).SetLimit(100).Limit(), 10},
+		{"V3BusiFavorUserCouponsPager", c.V3BusiFavorUserCouponsPager(ctx, "openid", nil).SetLimit(100).Limit(), 50},
+		{"V3PartnershipsListPager", c.V3PartnershipsListPager(ctx, nil).SetLimit(100).Limit(), 50},
+		{"V3EcommerceIncomeRecordPager", c.V3EcommerceIncomeRecordPager(ctx, nil).SetLimit(1000).Limit(), 100},
+		{"V3BankSearchBranchListPager", c.V3BankSearchBranchListPager(ctx, "1000009561", 110000).SetLimit(1000).Limit(), 200},
+		{"V3FapiaoTaxCodesPager", c.V3FapiaoTaxCodesPager(ctx).SetLimit(100).Limit(), 20},
+		{"default", c.V3ComplaintListPager(ctx, nil).Limit(), defaultPageLimit},
+	} {
+		if tt.limit != tt.want {
+			t.Errorf("%s Limit() = %d, want %d", tt.name, tt.limit, tt.want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/misu99/gopay"
+)
+
+// APP预签约API
+// Code = 0 is success
+func (c *ClientV3) V3PapayPreSignApp(ctx context.Context, bm gopay.BodyMap) (*PapayPreSignRsp, error) {
+	return c.papayPreSign(ctx, v3PapayPreSignApp, bm)
+}
+
+// JSAPI预签约API
+// Code = 0 is success
+func (c *ClientV3) V3PapayPreSignJsapi(ctx context.Context, bm gopay.BodyMap) (*PapayPreSignRsp, error) {
+	return c.papayPreSign(ctx, v3PapayPreSignJsapi, bm)
+}
+
+// H5预签约API
+// Code = 0 is success
+func (c *ClientV3) V3PapayPreSignH5(ctx context.Context, bm gopay.BodyMap) (*PapayPreSignRsp, error) {
+	return c.papayPreSign(ctx, v3PapayPreSignH5, bm)
+}
+
+// 小程序预签约API
+// Code = 0 is success
+func (c *ClientV3) V3PapayPreSignMiniProgram(ctx context.Context, bm gopay.BodyMap) (*PapayPreSignRsp, error) {
+	return c.papayPreSign(ctx, v3PapayPreSignMiniProgram, bm)
+}
+
+func (c *ClientV3) papayPreSign(ctx context.Context, url string, bm gopay.BodyMap) (*PapayPreSignRsp, error) {
+	authorization, err := c.authorization(MethodPost, url, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(ctx, bm, url, authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	wxRsp := &PapayPreSignRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(PapayPreSign)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 通过协议号查询签约API
+// 注意：bm 中 appid 为 query 参数
+// Code = 0 is success
+func (c *ClientV3) V3PapayContractQuery(ctx context.Context, contractId string, bm gopay.BodyMap) (*PapayContractRsp, error) {
+	uri := fmt.Sprintf(v3PapayContractQuery, contractId) + "?" + bm.EncodeURLParams()
+	return c.papayContractQuery(ctx, uri)
+}
+
+// 通过商户签约号查询签约API
+// 注意：bm 中 appid 为 query 参数
+// Code = 0 is success
+func (c *ClientV3) V3PapayContractQueryByCode(ctx context.Context, planId int, outContractCode string, bm gopay.BodyMap) (*PapayContractRsp, error) {
+	uri := fmt.Sprintf(v3PapayContractQueryByCode, planId, outContractCode) + "?" + bm.EncodeURLParams()
+	return c.papayContractQuery(ctx, uri)
+}
+
+func (c *ClientV3) papayContractQuery(ctx context.Context, uri string) (*PapayContractRsp, error) {
+	authorization, err := c.authorization(MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(ctx, uri, authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	wxRsp := &PapayContractRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(PapayContract)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 申请解约API
+// 注意：解约结果以 PAPAY.TERMINATE 回调通知或查询签约结果为准
+// Code = 0 is success
+func (c *ClientV3) V3PapayContractTerminate(ctx context.Context, contractId string, bm gopay.BodyMap) (wxRsp *EmptyRsp, err error) {
+	url := fmt.Sprintf(v3PapayContractTerminate, contractId)
+	authorization, err := c.authorization(MethodPost, url, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(ctx, bm, url, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &EmptyRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 申请扣款API
+// 注意：扣款前需先调用 client.V3EntrustPayNotify() 发送预扣费通知，扣款结果以回调通知（notifyReq.DecryptPapayPayCipherText()）或查询订单结果为准
+// Code = 0 is success
+func (c *ClientV3) V3PapayTransaction(ctx context.Context, bm gopay.BodyMap) (wxRsp *EmptyRsp, err error) {
+	authorization, err := c.authorization(MethodPost, v3PapayTransactionsApply, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(ctx, bm, v3PapayTransactionsApply, authorization)
+	if err != nil {
+		return nil, err
+	}
+	wxRsp = &EmptyRsp{Code: Success, SignInfo: si}
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
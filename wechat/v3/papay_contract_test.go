@@ -0,0 +1,110 @@
+package wechat
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/misu99/gopay"
+)
+
+func TestV3PapayPreSign(t *testing.T) {
+	srv, c := newAPIServer(t)
+	bm := make(gopay.BodyMap)
+	bm.Set("appid", "wxd678efh567hg6787").
+		Set("plan_id", 12535).
+		Set("out_contract_code", "100001256").
+		Set("contract_display_account", "微信代扣")
+
+	srv.reply(http.StatusOK, `{"pre_entrustweb_id":"5778aadY9nltAsZzXixCkFIGYnV2V"}`)
+	for _, tt := range []struct {
+		uri string
+		fn  func() (*PapayPreSignRsp, error)
+	}{
+		{v3PapayPreSignApp, func() (*PapayPreSignRsp, error) { return c.V3PapayPreSignApp(ctx, bm) }},
+		{v3PapayPreSignJsapi, func() (*PapayPreSignRsp, error) { return c.V3PapayPreSignJsapi(ctx, bm) }},
+		{v3PapayPreSignMiniProgram, func() (*PapayPreSignRsp, error) { return c.V3PapayPreSignMiniProgram(ctx, bm) }},
+	} {
+		wxRsp, err := tt.fn()
+		if err != nil || wxRsp.Code != Success || wxRsp.Response.PreEntrustwebId != "5778aadY9nltAsZzXixCkFIGYnV2V" {
+			t.Fatalf("%s = %+v, %v", tt.uri, wxRsp, err)
+		}
+		srv.check(t, http.MethodPost, tt.uri)
+		if srv.req.GetString("out_contract_code") != "100001256" {
+			t.Fatalf("%s request body: %s", tt.uri, srv.raw)
+		}
+	}
+
+	srv.reply(http.StatusOK, `{"redirect_url":"https://wx.tenpay.com/cgi-bin/mmpayweb-bin/checkmweb?prepay_id=wx20161110163838f231619da20804912345"}`)
+	wxRsp, err := c.V3PapayPreSignH5(ctx, bm)
+	if err != nil || wxRsp.Response.RedirectUrl == "" {
+		t.Fatalf("V3PapayPreSignH5() = %+v, %v", wxRsp, err)
+	}
+	srv.check(t, http.MethodPost, v3PapayPreSignH5)
+
+	// 业务错误
+	srv.reply(http.StatusBadRequest, `{"code":"PARAM_ERROR","message":"plan_id 不存在"}`)
+	if wxRsp, err = c.V3PapayPreSignApp(ctx, bm); err != nil || wxRsp.Code != http.StatusBadRequest || wxRsp.Error == "" {
+		t.Fatalf("V3PapayPreSignApp() = %+v, %v", wxRsp, err)
+	}
+	// 应答验签失败
+	srv.reply(http.StatusOK, `{"pre_entrustweb_id":"5778aadY9nltAsZzXixCkFIGYnV2V"}`)
+	srv.sign = testSignInfo(t, c, "SN1").HeaderSignature
+	if _, err = c.V3PapayPreSignApp(ctx, bm); !errors.Is(err, gopay.VerifySignatureErr) {
+		t.Fatalf("V3PapayPreSignApp() with bad sign err: %v", err)
+	}
+}
+
+func TestV3PapayContract(t *testing.T) {
+	srv, c := newAPIServer(t)
+	contract := `{"appid":"wxd678efh567hg6787","mchid":"1230000109","contract_id":"Wx15463511252015071056489715","plan_id":12535,"out_contract_code":"100001256","openid":"oUpF8uMuAJO_M2pxb1Q9zNjWeS6o","contract_state":"TERMINATED","contract_signed_time":"2015-05-20T13:29:35+08:00","contract_terminated_time":"2015-06-20T13:29:35+08:00","contract_terminate_info":{"contract_terminate_mode":"USER","contract_terminate_remark":"用户解约"}}`
+	srv.reply(http.StatusOK, contract)
+
+	bm := make(gopay.BodyMap)
+	bm.Set("appid", "wxd678efh567hg6787")
+	wxRsp, err := c.V3PapayContractQuery(ctx, "Wx15463511252015071056489715", bm)
+	if err != nil || wxRsp.Response.ContractState != "TERMINATED" || wxRsp.Response.ContractTerminateInfo == nil || wxRsp.Response.ContractTerminateInfo.ContractTerminateMode != "USER" {
+		t.Fatalf("V3PapayContractQuery() = %+v, %v", wxRsp, err)
+	}
+	srv.check(t, http.MethodGet, "/v3/papay/sign/contracts/contract-id/Wx15463511252015071056489715?appid=wxd678efh567hg6787")
+
+	if wxRsp, err = c.V3PapayContractQueryByCode(ctx, 12535, "100001256", bm); err != nil || wxRsp.Response.PlanId != 12535 {
+		t.Fatalf("V3PapayContractQueryByCode() = %+v, %v", wxRsp, err)
+	}
+	srv.check(t, http.MethodGet, "/v3/papay/sign/contracts/plan-id/12535/out-contract-code/100001256?appid=wxd678efh567hg6787")
+
+	srv.reply(http.StatusNotFound, `{"code":"RESOURCE_NOT_EXISTS","message":"签约协议不存在"}`)
+	if wxRsp, err = c.V3PapayContractQuery(ctx, "Wx15463511252015071056489715", bm); err != nil || wxRsp.Code != http.StatusNotFound {
+		t.Fatalf("V3PapayContractQuery() = %+v, %v", wxRsp, err)
+	}
+
+	// 申请解约、申请扣款成功时无应答 body
+	srv.reply(http.StatusNoContent, "")
+	terminate := make(gopay.BodyMap)
+	terminate.Set("appid", "wxd678efh567hg6787").Set("contract_termination_remark", "用户申请解约")
+	emptyRsp, err := c.V3PapayContractTerminate(ctx, "Wx15463511252015071056489715", terminate)
+	if err != nil || emptyRsp.Code != Success {
+		t.Fatalf("V3PapayContractTerminate() = %+v, %v", emptyRsp, err)
+	}
+	srv.check(t, http.MethodPost, "/v3/papay/sign/contracts/contract-id/Wx15463511252015071056489715/terminate")
+
+	pay := make(gopay.BodyMap)
+	pay.Set("appid", "wxd678efh567hg6787").
+		Set("out_trade_no", "1217752501201407033233368018").
+		Set("contract_id", "Wx15463511252015071056489715").
+		SetBodyMap("amount", func(bm gopay.BodyMap) {
+			bm.Set("total", 100).Set("currency", "CNY")
+		})
+	if emptyRsp, err = c.V3PapayTransaction(ctx, pay); err != nil || emptyRsp.Code != Success {
+		t.Fatalf("V3PapayTransaction() = %+v, %v", emptyRsp, err)
+	}
+	srv.check(t, http.MethodPost, v3PapayTransactionsApply)
+	if srv.req.GetString("contract_id") != "Wx15463511252015071056489715" {
+		t.Fatalf("V3PapayTransaction() request body: %s", srv.raw)
+	}
+
+	srv.reply(http.StatusForbidden, `{"code":"NO_AUTH","message":"签约协议已解除"}`)
+	if emptyRsp, err = c.V3PapayTransaction(ctx, pay); err != nil || emptyRsp.Code != http.StatusForbidden || emptyRsp.Error == "" {
+		t.Fatalf("V3PapayTransaction() = %+v, %v", emptyRsp, err)
+	}
+}
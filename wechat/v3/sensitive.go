@@ -0,0 +1,227 @@
+package wechat
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/pkg/sm2"
+)
+
+// 敏感信息字段标签：标记 sensitive:"true" 的 string 字段，请求时自动加密；应答中的加密字段可使用 client.V3DecryptSensitive() 解密
+const sensitiveTag = "sensitive"
+
+// 加密敏感信息时使用的平台证书快照，保证加密所用证书与请求 Header 中 Wechatpay-Serial 一致
+type sensitiveCipher struct {
+	serialNo string
+	rsaKey   *rsa.PublicKey
+	sm2Key   *sm2.PublicKey
+}
+
+func (s *sensitiveCipher) encrypt(text string) (cipherText string, err error) {
+	var cipherByte []byte
+	if s.sm2Key != nil {
+		if cipherByte, err = sm2.EncryptASN1(rand.Reader, s.sm2Key, []byte(text)); err != nil {
+			return "", fmt.Errorf("sm2.EncryptASN1：%w", err)
+		}
+		return base64.StdEncoding.EncodeToString(cipherByte), nil
+	}
+	if cipherByte, err = rsa.EncryptOAEP(sha1.New(), rand.Reader, s.rsaKey, []byte(text), nil); err != nil {
+		return "", fmt.Errorf("rsa.EncryptOAEP：%w", err)
+	}
+	return base64.StdEncoding.EncodeToString(cipherByte), nil
+}
+
+// 获取当前平台证书快照
+func (c *ClientV3) sensitiveCipher() (s *sensitiveCipher, err error) {
+	c.rwMu.RLock()
+	defer c.rwMu.RUnlock()
+	if c.signType == SignTypeSM2 {
+		if c.wxSM2PublicKey == nil || c.WxSerialNo == "" {
+			return nil, errors.New("WxSM2PublicKey or WxSerialNo is null")
+		}
+		return &sensitiveCipher{serialNo: c.WxSerialNo, sm2Key: c.wxSM2PublicKey}, nil
+	}
+	if c.wxPublicKey == nil || c.WxSerialNo == "" {
+		return nil, errors.New("WxPublicKey or WxSerialNo is null")
+	}
+	return &sensitiveCipher{serialNo: c.WxSerialNo, rsaKey: c.wxPublicKey}, nil
+}
+
+// V3EncryptSensitive 加密 v 中标记 sensitive:"true" 的字段
+// 不修改 v，返回加密后的深拷贝 encrypted（类型与 v 相同），v 中敏感字段需为明文，重试请求时可直接复用 v
+// v：结构体指针，或 gopay.BodyMap（值为结构体、结构体指针或其切片时递归处理）
+// bmKeys：v 为 gopay.BodyMap 时，需要加密的顶层 string 字段，如 user_name
+// wxSerialNo：加密所用的微信平台证书序列号，请求 Header 中 Wechatpay-Serial 需使用该值；无需加密的字段时返回 client.WxSerialNo
+func (c *ClientV3) V3EncryptSensitive(v any, bmKeys ...string) (encrypted any, wxSerialNo string, err error) {
+	if v == nil {
+		return nil, "", errors.New("v can't be nil")
+	}
+	var s *sensitiveCipher
+	encrypt := func(text string) (string, error) {
+		if s == nil {
+			if s, err = c.sensitiveCipher(); err != nil {
+				return "", err
+			}
+		}
+		return s.encrypt(text)
+	}
+	cp := deepCopy(reflect.ValueOf(v))
+	if bm, ok := cp.Interface().(gopay.BodyMap); ok {
+		for _, key := range bmKeys {
+			text, ok := bm[key].(string)
+			if !ok || text == "" {
+				continue
+			}
+			cipherText, err := encrypt(text)
+			if err != nil {
+				return nil, "", fmt.Errorf("%s: %w", key, err)
+			}
+			bm[key] = cipherText
+		}
+	}
+	if err = walkSensitive(cp, encrypt); err != nil {
+		return nil, "", err
+	}
+	if s == nil {
+		c.rwMu.RLock()
+		wxSerialNo = c.WxSerialNo
+		c.rwMu.RUnlock()
+		return cp.Interface(), wxSerialNo, nil
+	}
+	return cp.Interface(), s.serialNo, nil
+}
+
+// 加密 bm 中的敏感信息，返回加密后的副本，调用方传入的 bm 保持明文
+func (c *ClientV3) encryptSensitiveBodyMap(bm gopay.BodyMap, bmKeys ...string) (encrypted gopay.BodyMap, wxSerialNo string, err error) {
+	v, wxSerialNo, err := c.V3EncryptSensitive(bm, bmKeys...)
+	if err != nil {
+		return nil, "", err
+	}
+	return v.(gopay.BodyMap), wxSerialNo, nil
+}
+
+// V3DecryptSensitive 使用商户私钥解密 v 中标记 sensitive:"true" 的字段（原地修改）
+func (c *ClientV3) V3DecryptSensitive(v any) (err error) {
+	return walkSensitive(reflect.ValueOf(v), c.V3DecryptText)
+}
+
+// 递归遍历标记 sensitive:"true" 的非空 string 字段
+func walkSensitive(v reflect.Value, fn func(text string) (string, error)) (err error) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return walkSensitive(v.Elem(), fn)
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			field, fv := t.Field(i), v.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Tag.Get(sensitiveTag) != "true" {
+				if err = walkSensitive(fv, fn); err != nil {
+					return err
+				}
+				continue
+			}
+			if fv.Kind() != reflect.String || fv.String() == "" || !fv.CanSet() {
+				continue
+			}
+			text, err := fn(fv.String())
+			if err != nil {
+				return fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+			}
+			fv.SetString(text)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err = walkSensitive(v.Index(i), fn); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			elem := v.MapIndex(key)
+			if elem.Kind() == reflect.Interface && !elem.IsNil() {
+				elem = elem.Elem()
+			}
+			// map 中的结构体值不可寻址，拷贝处理后回写
+			if elem.Kind() == reflect.Struct {
+				cp := reflect.New(elem.Type()).Elem()
+				cp.Set(elem)
+				if err = walkSensitive(cp, fn); err != nil {
+					return err
+				}
+				v.SetMapIndex(key, cp)
+				continue
+			}
+			if err = walkSensitive(elem, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// 深拷贝 v，仅复制可导出字段引用的数据，不可导出字段按值复制
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.New(v.Type().Elem())
+		cp.Elem().Set(deepCopy(v.Elem()))
+		return cp
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(deepCopy(v.Elem()))
+		return cp
+	case reflect.Struct:
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if cp.Field(i).CanSet() {
+				cp.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return cp
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return cp
+	case reflect.Array:
+		cp := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return cp
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			cp.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return cp
+	}
+	return v
+}
@@ -0,0 +1,181 @@
+package wechat
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/misu99/gopay"
+)
+
+type sensitiveInner struct {
+	Name  string `sensitive:"true"`
+	Plain string
+}
+
+type sensitiveOuter struct {
+	Name   string `sensitive:"true"`
+	Empty  string `sensitive:"true"`
+	Plain  string
+	Inner  sensitiveInner
+	Ptr    *sensitiveInner
+	Nil    *sensitiveInner
+	List   []*sensitiveInner
+	Array  [1]sensitiveInner
+	Map    map[string]sensitiveInner
+	PtrMap map[string]*sensitiveInner
+	Any    any
+	secret string `sensitive:"true"`
+}
+
+func newSensitiveOuter() *sensitiveOuter {
+	return &sensitiveOuter{
+		Name:   "name",
+		Plain:  "plain",
+		Inner:  sensitiveInner{Name: "inner", Plain: "plain"},
+		Ptr:    &sensitiveInner{Name: "ptr"},
+		List:   []*sensitiveInner{{Name: "list0"}, nil, {Name: "list2"}},
+		Array:  [1]sensitiveInner{{Name: "array"}},
+		Map:    map[string]sensitiveInner{"k": {Name: "map", Plain: "plain"}},
+		PtrMap: map[string]*sensitiveInner{"k": {Name: "ptrmap"}},
+		Any:    &sensitiveInner{Name: "any"},
+		secret: "secret",
+	}
+}
+
+func TestWalkSensitive(t *testing.T) {
+	prefix := func(text string) (string, error) { return "enc:" + text, nil }
+
+	v := newSensitiveOuter()
+	if err := walkSensitive(reflect.ValueOf(v), prefix); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range [][2]string{
+		{v.Name, "enc:name"},
+		{v.Inner.Name, "enc:inner"},
+		{v.Ptr.Name, "enc:ptr"},
+		{v.List[0].Name, "enc:list0"},
+		{v.List[2].Name, "enc:list2"},
+		{v.Array[0].Name, "enc:array"},
+		{v.Map["k"].Name, "enc:map"},
+		{v.PtrMap["k"].Name, "enc:ptrmap"},
+		{v.Any.(*sensitiveInner).Name, "enc:any"},
+	} {
+		if got, want := tt[0], tt[1]; got != want {
+			t.Errorf("walkSensitive() got %s, want %s", got, want)
+		}
+	}
+	if v.Empty != "" || v.Plain != "plain" || v.Inner.Plain != "plain" || v.Map["k"].Plain != "plain" || v.secret != "secret" {
+		t.Errorf("walkSensitive() changed non-sensitive field: %+v", v)
+	}
+
+	// BodyMap 中的结构体值不可寻址，拷贝处理后回写
+	bm := make(gopay.BodyMap)
+	bm.Set("contact_info", sensitiveInner{Name: "contact"}).
+		Set("list", []sensitiveInner{{Name: "list"}}).
+		Set("name", "top")
+	if err := walkSensitive(reflect.ValueOf(bm), prefix); err != nil {
+		t.Fatal(err)
+	}
+	if bm["contact_info"].(sensitiveInner).Name != "enc:contact" || bm["list"].([]sensitiveInner)[0].Name != "enc:list" || bm.GetString("name") != "top" {
+		t.Errorf("walkSensitive(BodyMap) = %+v", bm)
+	}
+
+	// 错误返回字段路径
+	errFn := errors.New("encrypt error")
+	err := walkSensitive(reflect.ValueOf(&sensitiveOuter{Inner: sensitiveInner{Name: "inner"}}), func(string) (string, error) { return "", errFn })
+	if !errors.Is(err, errFn) || !strings.Contains(err.Error(), "sensitiveInner.Name") {
+		t.Fatalf("walkSensitive() err: %v", err)
+	}
+}
+
+func TestV3EncryptSensitive(t *testing.T) {
+	c := newTestClientV3(t, "1900000001", "")
+	// 未获取平台证书时无法加密
+	if _, _, err := c.V3EncryptSensitive(newSensitiveOuter()); err == nil {
+		t.Fatal("V3EncryptSensitive() without platform cert should return error")
+	}
+	// 无需加密的字段时不依赖平台证书
+	if _, _, err := c.V3EncryptSensitive(&sensitiveInner{Plain: "plain"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.setPlatformCerts("SN1", map[string]string{"SN1": publicPKCS1}, true); err != nil {
+		t.Fatal(err)
+	}
+
+	bm := make(gopay.BodyMap)
+	bm.Set("user_name", "张三").
+		Set("contact_info", &ContactInfo{ContactName: "李四", MobilePhone: "13800138000"}).
+		Set("transfer_detail_list", []*TransferDetailInput{{OutDetailNo: "x23zy545Bd5436", UserName: "王五"}})
+	// 重试时复用同一个 bm，每次均加密明文
+	for i := 0; i < 2; i++ {
+		encrypted, wxSerialNo, err := c.encryptSensitiveBodyMap(bm, "user_name")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if wxSerialNo != "SN1" {
+			t.Fatalf("wxSerialNo = %s", wxSerialNo)
+		}
+		for _, tt := range [][2]string{
+			{encrypted.GetString("user_name"), "张三"},
+			{encrypted["contact_info"].(*ContactInfo).ContactName, "李四"},
+			{encrypted["contact_info"].(*ContactInfo).MobilePhone, "13800138000"},
+			{encrypted["transfer_detail_list"].([]*TransferDetailInput)[0].UserName, "王五"},
+		} {
+			if text, err := c.V3DecryptText(tt[0]); err != nil || text != tt[1] {
+				t.Fatalf("V3DecryptText() = %s, %v, want %s", text, err, tt[1])
+			}
+		}
+		if encrypted["transfer_detail_list"].([]*TransferDetailInput)[0].OutDetailNo != "x23zy545Bd5436" {
+			t.Fatalf("encrypted = %+v", encrypted)
+		}
+	}
+	// 调用方的数据保持明文
+	if bm.GetString("user_name") != "张三" || bm["contact_info"].(*ContactInfo).ContactName != "李四" || bm["transfer_detail_list"].([]*TransferDetailInput)[0].UserName != "王五" {
+		t.Fatalf("V3EncryptSensitive() modified input: %+v", bm)
+	}
+
+	// 结构体指针
+	v := newSensitiveOuter()
+	encrypted, _, err := c.V3EncryptSensitive(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev := encrypted.(*sensitiveOuter)
+	if ev == v || ev.Ptr == v.Ptr || v.Name != "name" || v.Ptr.Name != "ptr" || v.Map["k"].Name != "map" || v.PtrMap["k"].Name != "ptrmap" {
+		t.Fatalf("V3EncryptSensitive() modified input: %+v", v)
+	}
+	if text, err := c.V3DecryptText(ev.Map["k"].Name); err != nil || text != "map" || ev.Plain != "plain" || ev.secret != "secret" {
+		t.Fatalf("V3DecryptText() = %s, %v, encrypted: %+v", text, err, ev)
+	}
+	// 解密应答
+	if err = c.V3DecryptSensitive(ev); err != nil || ev.Name != "name" || ev.List[2].Name != "list2" {
+		t.Fatalf("V3DecryptSensitive() err: %v, %+v", err, ev)
+	}
+}
+
+// 已有的投诉、转账明细查询接口保持返回密文，由调用方按需解密
+func TestV3DecryptSensitiveOptIn(t *testing.T) {
+	srv, c := newAPIServer(t)
+	phone, err := V3EncryptText("13900000000", []byte(publicPKCS1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.reply(http.StatusOK, `{"complaint_id":"200201820200101080076610000","payer_phone":"`+phone+`"}`)
+	wxRsp, err := c.V3ComplaintDetail(ctx, "200201820200101080076610000")
+	if err != nil || wxRsp.Response.PayerPhone != phone {
+		t.Fatalf("V3ComplaintDetail() = %+v, %v", wxRsp, err)
+	}
+	if err = c.V3DecryptSensitive(wxRsp.Response); err != nil || wxRsp.Response.PayerPhone != "13900000000" {
+		t.Fatalf("V3DecryptSensitive() = %s, %v", wxRsp.Response.PayerPhone, err)
+	}
+
+	// 无法解密的字段不影响查询结果
+	srv.reply(http.StatusOK, `{"out_detail_no":"x23zy545Bd5436","user_name":"bad cipher text"}`)
+	detail, err := c.V3TransferMerchantDetail(ctx, "plfk2020042013", "x23zy545Bd5436")
+	if err != nil || detail.Code != Success || detail.Response.UserName != "bad cipher text" {
+		t.Fatalf("V3TransferMerchantDetail() = %+v, %v", detail, err)
+	}
+}
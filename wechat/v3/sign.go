@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/misu99/gopay"
+	"github.com/misu99/gopay/pkg/sm2"
 	"github.com/misu99/gopay/pkg/util"
 	"github.com/misu99/gopay/pkg/xlog"
 	"github.com/misu99/gopay/pkg/xpem"
@@ -56,6 +57,20 @@ func V3VerifySignByPK(timestamp, nonce, signBody, sign string, wxPublicKey *rsa.
 	return nil
 }
 
+// 微信V3 国密版本验签（同步）
+// wxPublicKey：微信国密平台证书公钥，通过 client.WxSM2PublicKeyMap() 获取，然后根据 signInfo.HeaderSerial 获取相应的公钥
+func V3VerifySignBySM2PK(timestamp, nonce, signBody, sign string, wxPublicKey *sm2.PublicKey) (err error) {
+	if wxPublicKey == nil || wxPublicKey.X == nil {
+		return fmt.Errorf("[%w]: %v", gopay.VerifySignatureErr, "wxPublicKey is nil")
+	}
+	str := timestamp + "\n" + nonce + "\n" + signBody + "\n"
+	signBytes, _ := base64.StdEncoding.DecodeString(sign)
+	if !sm2.VerifyASN1(wxPublicKey, []byte(str), nil, signBytes) {
+		return fmt.Errorf("[%w]: %v", gopay.VerifySignatureErr, "sm2 verify failed")
+	}
+	return nil
+}
+
 // PaySignOfJSAPI 获取 JSAPI 支付所需要的参数
 // 文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter3_1_4.shtml
 func (c *ClientV3) PaySignOfJSAPI(appid, prepayid string) (jsapi *JSAPIPayParams, err error) {
@@ -64,7 +79,7 @@ func (c *ClientV3) PaySignOfJSAPI(appid, prepayid string) (jsapi *JSAPIPayParams
 	pkg := "prepay_id=" + prepayid
 
 	_str := appid + "\n" + ts + "\n" + nonceStr + "\n" + pkg + "\n"
-	sign, err := c.rsaSign(_str)
+	sign, err := c.sign(_str)
 	if err != nil {
 		return nil, err
 	}
@@ -74,7 +89,7 @@ func (c *ClientV3) PaySignOfJSAPI(appid, prepayid string) (jsapi *JSAPIPayParams
 		TimeStamp: ts,
 		NonceStr:  nonceStr,
 		Package:   pkg,
-		SignType:  SignTypeRSA,
+		SignType:  c.signType,
 		PaySign:   sign,
 	}
 	return jsapi, nil
@@ -87,7 +102,7 @@ func (c *ClientV3) PaySignOfApp(appid, prepayid string) (app *AppPayParams, err
 	nonceStr := util.RandomString(32)
 
 	_str := appid + "\n" + ts + "\n" + nonceStr + "\n" + prepayid + "\n"
-	sign, err := c.rsaSign(_str)
+	sign, err := c.sign(_str)
 	if err != nil {
 		return nil, err
 	}
@@ -157,6 +172,26 @@ func (c *ClientV3) PaySignOfAppletScore(mchId, pkg string) (extraData *AppletSco
 	return extraData, nil
 }
 
+// TransferBillsConfirmParams 获取 小程序、JSAPI 调起用户确认收款（wx.requestMerchantTransfer）所需要的参数
+// appid：发起转账时的 appid
+// packageInfo：发起转账（client.V3TransferBills()）返回的 package_info
+func (c *ClientV3) TransferBillsConfirmParams(appid, packageInfo string) (params *TransferBillsConfirmParams) {
+	return &TransferBillsConfirmParams{
+		MchId:   c.Mchid,
+		AppId:   appid,
+		Package: packageInfo,
+	}
+}
+
+// Authorization 获取 v3 请求鉴权 Header（Authorization）的值
+// 适用于自行发起请求或调试时查看签名结果
+// method：请求方法，如 MethodGet、MethodPost
+// path：请求的 URI（包含 query 参数），如 /v3/pay/transactions/id/xxx?mchid=xxx
+// bm：请求 body，GET 请求传 nil
+func (c *ClientV3) Authorization(method, path string, bm gopay.BodyMap) (string, error) {
+	return c.authorization(method, path, bm)
+}
+
 // v3 鉴权请求Header
 func (c *ClientV3) authorization(method, path string, bm gopay.BodyMap) (string, error) {
 	var (
@@ -173,11 +208,38 @@ func (c *ClientV3) authorization(method, path string, bm gopay.BodyMap) (string,
 	if c.DebugSwitch == gopay.DebugOn {
 		xlog.Debugf("Wechat_V3_SignString:\n%s", _str)
 	}
-	sign, err := c.rsaSign(_str)
+	sign, err := c.sign(_str)
 	if err != nil {
 		return "", err
 	}
-	return Authorization + ` mchid="` + c.Mchid + `",nonce_str="` + nonceStr + `",timestamp="` + ts + `",serial_no="` + c.SerialNo + `",signature="` + sign + `"`, nil
+	return c.authorizationType() + ` mchid="` + c.Mchid + `",nonce_str="` + nonceStr + `",timestamp="` + ts + `",serial_no="` + c.SerialNo + `",signature="` + sign + `"`, nil
+}
+
+// 认证类型，国密模式为 WECHATPAY2-SM2-WITH-SM3
+func (c *ClientV3) authorizationType() string {
+	if c.signType == SignTypeSM2 {
+		return AuthorizationSM2
+	}
+	return Authorization
+}
+
+// 根据客户端签名类型签名
+func (c *ClientV3) sign(str string) (string, error) {
+	if c.signType == SignTypeSM2 {
+		return c.sm2Sign(str)
+	}
+	return c.rsaSign(str)
+}
+
+func (c *ClientV3) sm2Sign(str string) (string, error) {
+	if c.sm2PrivateKey == nil {
+		return "", errors.New("sm2PrivateKey can't be nil")
+	}
+	result, err := sm2.SignASN1(rand.Reader, c.sm2PrivateKey, []byte(str), nil)
+	if err != nil {
+		return util.NULL, fmt.Errorf("[%w]: %+v", gopay.SignatureErr, err)
+	}
+	return base64.StdEncoding.EncodeToString(result), nil
 }
 
 func (c *ClientV3) rsaSign(str string) (string, error) {
@@ -195,17 +257,40 @@ func (c *ClientV3) rsaSign(str string) (string, error) {
 
 // 自动同步请求验签
 func (c *ClientV3) verifySyncSign(si *SignInfo) (err error) {
-	if !c.autoSign {
+	if !c.isAutoSign() {
 		return nil
 	}
+	return c.verifySign(si)
+}
+
+// VerifyNotifySign 使用 client 已获取的平台证书（或微信支付公钥）对异步通知验签
+// 通知的 Wechatpay-Serial 未知时，立即刷新平台证书后再验签
+func (c *ClientV3) VerifyNotifySign(notifyReq *V3NotifyReq) (err error) {
+	if notifyReq == nil || notifyReq.SignInfo == nil {
+		return errors.New("verify notify sign, bug SignInfo is nil")
+	}
+	return c.verifySign(notifyReq.SignInfo)
+}
+
+func (c *ClientV3) verifySign(si *SignInfo) (err error) {
 	if si == nil {
 		return errors.New("auto verify sign, but SignInfo is nil")
 	}
+	if c.signType == SignTypeSM2 {
+		return c.verifySyncSignSM2(si)
+	}
 	c.rwMu.RLock()
+	wxPublicKeyID, onlyPublicKey := c.wxPublicKeyID, c.onlyPublicKey
 	wxPublicKey, exist := c.SnCertMap[si.HeaderSerial]
+	if !exist && c.wxPayPublicKey != nil && si.HeaderSerial == wxPublicKeyID {
+		wxPublicKey, exist = c.wxPayPublicKey, true
+	}
 	c.rwMu.RUnlock()
+	if !exist && onlyPublicKey {
+		return fmt.Errorf("[%w]: Wechatpay-Serial [%s] not match wechat pay public key id [%s]", gopay.VerifySignatureErr, si.HeaderSerial, wxPublicKeyID)
+	}
 	if !exist {
-		err = c.AutoVerifySign(false)
+		err = c.refreshCerts(si.HeaderSerial)
 		if err != nil {
 			return fmt.Errorf("[get all public key err]: %v", err)
 		}
@@ -225,3 +310,53 @@ func (c *ClientV3) verifySyncSign(si *SignInfo) (err error) {
 	}
 	return nil
 }
+
+// 国密模式自动同步请求验签
+func (c *ClientV3) verifySyncSignSM2(si *SignInfo) (err error) {
+	c.rwMu.RLock()
+	wxPublicKey, exist := c.SnSM2CertMap[si.HeaderSerial]
+	c.rwMu.RUnlock()
+	if !exist {
+		err = c.refreshCerts(si.HeaderSerial)
+		if err != nil {
+			return fmt.Errorf("[get all public key err]: %v", err)
+		}
+		c.rwMu.RLock()
+		wxPublicKey, exist = c.SnSM2CertMap[si.HeaderSerial]
+		c.rwMu.RUnlock()
+		if !exist {
+			return errors.New("auto verify sign, but public key not found")
+		}
+	}
+	return V3VerifySignBySM2PK(si.HeaderTimestamp, si.HeaderNonce, si.SignBody, si.HeaderSignature, wxPublicKey)
+}
+
+// 遇到未知的 Wechatpay-Serial 时，立即刷新平台证书
+// 未绑定证书管理器时，同样限制最小刷新间隔，避免伪造的序列号频繁触发请求
+func (c *ClientV3) refreshCerts(serialNo string) (err error) {
+	if m := c.CertManager(); m != nil {
+		return m.refreshForSerial(c.ctx, serialNo)
+	}
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	if c.hasPlatformCert(serialNo) {
+		return nil
+	}
+	if !c.lastOnDemand.IsZero() && time.Since(c.lastOnDemand) < defaultCertOnDemandInterval {
+		return fmt.Errorf("unknown Wechatpay-Serial [%s], refresh too frequently", serialNo)
+	}
+	c.lastOnDemand = time.Now()
+	return c.AutoVerifySign(false)
+}
+
+// 是否已存在该序列号的平台证书
+func (c *ClientV3) hasPlatformCert(serialNo string) (exist bool) {
+	c.rwMu.RLock()
+	defer c.rwMu.RUnlock()
+	if c.signType == SignTypeSM2 {
+		_, exist = c.SnSM2CertMap[serialNo]
+		return exist
+	}
+	_, exist = c.SnCertMap[serialNo]
+	return exist
+}
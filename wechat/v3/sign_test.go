@@ -7,6 +7,7 @@ import (
 )
 
 func TestPaySignOfJSAPIp(t *testing.T) {
+	requireClient(t)
 	jsapi, err := client.PaySignOfJSAPI("appid", "prepayid")
 	if err != nil {
 		xlog.Error(err)
@@ -16,6 +17,7 @@ func TestPaySignOfJSAPIp(t *testing.T) {
 }
 
 func TestPaySignOfApp(t *testing.T) {
+	requireClient(t)
 	app, err := client.PaySignOfApp("appid", "prepayid")
 	if err != nil {
 		xlog.Error(err)
@@ -25,6 +27,7 @@ func TestPaySignOfApp(t *testing.T) {
 }
 
 func TestPaySignOfApplet(t *testing.T) {
+	requireClient(t)
 	applet, err := client.PaySignOfApplet("appid", "prepayid")
 	if err != nil {
 		xlog.Error(err)
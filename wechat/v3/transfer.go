@@ -10,15 +10,19 @@ import (
 )
 
 // 发起商家转账API
-// 注意：入参加密字段数据加密：client.V3EncryptText()
+// 注意：入参加密字段数据加密：client.V3EncryptText()，或 transfer_detail_list 使用 []*TransferDetailInput，user_name 自动加密
 // Code = 0 is success
 // 商户文档：https://pay.weixin.qq.com/wiki/doc/apiv3/apis/chapter4_3_1.shtml
 func (c *ClientV3) V3Transfer(ctx context.Context, bm gopay.BodyMap) (*TransferRsp, error) {
+	bm, wxSerialNo, err := c.encryptSensitiveBodyMap(bm)
+	if err != nil {
+		return nil, err
+	}
 	authorization, err := c.authorization(MethodPost, v3Transfer, bm)
 	if err != nil {
 		return nil, err
 	}
-	res, si, bs, err := c.doProdPost(ctx, bm, v3Transfer, authorization)
+	res, si, bs, err := c.doProdPostWithHeader(ctx, map[string]string{HeaderSerial: wxSerialNo}, bm, v3Transfer, authorization)
 	if err != nil {
 		return nil, err
 	}
@@ -37,15 +41,19 @@ func (c *ClientV3) V3Transfer(ctx context.Context, bm gopay.BodyMap) (*TransferR
 }
 
 // 发起批量转账API（服务商）
-// 注意：入参加密字段数据加密：client.V3EncryptText()
+// 注意：入参加密字段数据加密：client.V3EncryptText()，或 transfer_detail_list 使用 []*TransferDetailInput，user_name 自动加密
 // Code = 0 is success
 // 服务商文档：https://pay.weixin.qq.com/wiki/doc/apiv3_partner/Offline/apis/chapter4_3_1.shtml
 func (c *ClientV3) V3PartnerTransfer(ctx context.Context, bm gopay.BodyMap) (*TransferRsp, error) {
+	bm, wxSerialNo, err := c.encryptSensitiveBodyMap(bm)
+	if err != nil {
+		return nil, err
+	}
 	authorization, err := c.authorization(MethodPost, v3PartnerTransfer, bm)
 	if err != nil {
 		return nil, err
 	}
-	res, si, bs, err := c.doProdPost(ctx, bm, v3PartnerTransfer, authorization)
+	res, si, bs, err := c.doProdPostWithHeader(ctx, map[string]string{HeaderSerial: wxSerialNo}, bm, v3PartnerTransfer, authorization)
 	if err != nil {
 		return nil, err
 	}
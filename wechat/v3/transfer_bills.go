@@ -0,0 +1,170 @@
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/misu99/gopay"
+)
+
+// 发起转账API（商家转账新版）
+// 注意：user_name 传入明文，请求时对 bm 的副本自动加密，无需调用 client.V3EncryptText()（否则会重复加密）
+// 注意：bm 不会被修改，失败重试时可直接复用同一个 bm
+// 注意：需用户确认收款时（state = WAIT_USER_CONFIRM），使用 client.TransferBillsConfirmParams() 获取小程序/JSAPI 调起用户确认收款所需参数
+// Code = 0 is success
+// 商户文档：https://pay.weixin.qq.com/doc/v3/merchant/4012716434
+func (c *ClientV3) V3TransferBills(ctx context.Context, bm gopay.BodyMap) (*TransferBillsRsp, error) {
+	bm, wxSerialNo, err := c.encryptSensitiveBodyMap(bm, "user_name")
+	if err != nil {
+		return nil, err
+	}
+	authorization, err := c.authorization(MethodPost, v3TransferBills, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPostWithHeader(ctx, map[string]string{HeaderSerial: wxSerialNo}, bm, v3TransferBills, authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	wxRsp := &TransferBillsRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(TransferBills)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 撤销转账API（商家转账新版）
+// Code = 0 is success
+func (c *ClientV3) V3TransferBillsCancel(ctx context.Context, outBillNo string) (*TransferBillsCancelRsp, error) {
+	url := fmt.Sprintf(v3TransferBillsCancel, outBillNo)
+	bm := make(gopay.BodyMap)
+	authorization, err := c.authorization(MethodPost, url, bm)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdPost(ctx, bm, url, authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	wxRsp := &TransferBillsCancelRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(TransferBillsCancel)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
+
+// 商户单号查询转账单API（商家转账新版）
+// 注意：返回的 user_name 自动解密
+// Code = 0 is success
+func (c *ClientV3) V3TransferBillsQuery(ctx context.Context, outBillNo string) (*TransferBillsQueryRsp, error) {
+	return c.transferBillsQuery(ctx, fmt.Sprintf(v3TransferBillsQuery, outBillNo))
+}
+
+// 微信单号查询转账单API（商家转账新版）
+// 注意：返回的 user_name 自动解密
+// Code = 0 is success
+func (c *ClientV3) V3TransferBillsQueryByBillNo(ctx context.Context, transferBillNo string) (*TransferBillsQueryRsp, error) {
+	return c.transferBillsQuery(ctx, fmt.Sprintf(v3TransferBillsQueryByBillNo, transferBillNo))
+}
+
+func (c *ClientV3) transferBillsQuery(ctx context.Context, url string) (*TransferBillsQueryRsp, error) {
+	authorization, err := c.authorization(MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, si, bs, err := c.doProdGet(ctx, url, authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	wxRsp := &TransferBillsQueryRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(TransferBillsQuery)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	if err = c.verifySyncSign(si); err != nil {
+		return wxRsp, err
+	}
+	return wxRsp, c.V3DecryptSensitive(wxRsp.Response)
+}
+
+// 商户单号申请电子回单API（商家转账新版）
+// Code = 0 is success
+func (c *ClientV3) V3TransferBillsReceipt(ctx context.Context, outBillNo string) (*TransferBillsReceiptRsp, error) {
+	bm := make(gopay.BodyMap)
+	bm.Set("out_bill_no", outBillNo)
+	return c.transferBillsReceipt(ctx, MethodPost, v3TransferBillsReceipt, bm)
+}
+
+// 微信单号申请电子回单API（商家转账新版）
+// Code = 0 is success
+func (c *ClientV3) V3TransferBillsReceiptByBillNo(ctx context.Context, transferBillNo string) (*TransferBillsReceiptRsp, error) {
+	bm := make(gopay.BodyMap)
+	bm.Set("transfer_bill_no", transferBillNo)
+	return c.transferBillsReceipt(ctx, MethodPost, v3TransferBillsReceiptByBillNo, bm)
+}
+
+// 商户单号查询电子回单API（商家转账新版）
+// Code = 0 is success
+func (c *ClientV3) V3TransferBillsReceiptQuery(ctx context.Context, outBillNo string) (*TransferBillsReceiptRsp, error) {
+	return c.transferBillsReceipt(ctx, MethodGet, fmt.Sprintf(v3TransferBillsReceiptQuery, outBillNo), nil)
+}
+
+// 微信单号查询电子回单API（商家转账新版）
+// Code = 0 is success
+func (c *ClientV3) V3TransferBillsReceiptQueryByBillNo(ctx context.Context, transferBillNo string) (*TransferBillsReceiptRsp, error) {
+	return c.transferBillsReceipt(ctx, MethodGet, fmt.Sprintf(v3TransferBillsReceiptQueryByBillNo, transferBillNo), nil)
+}
+
+func (c *ClientV3) transferBillsReceipt(ctx context.Context, method, url string, bm gopay.BodyMap) (*TransferBillsReceiptRsp, error) {
+	authorization, err := c.authorization(method, url, bm)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		res *http.Response
+		si  *SignInfo
+		bs  []byte
+	)
+	if method == MethodPost {
+		res, si, bs, err = c.doProdPost(ctx, bm, url, authorization)
+	} else {
+		res, si, bs, err = c.doProdGet(ctx, url, authorization)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	wxRsp := &TransferBillsReceiptRsp{Code: Success, SignInfo: si}
+	wxRsp.Response = new(TransferBillsReceipt)
+	if err = json.Unmarshal(bs, wxRsp.Response); err != nil {
+		return nil, fmt.Errorf("[%w]: %v, bytes: %s", gopay.UnmarshalErr, err, string(bs))
+	}
+	if res.StatusCode != http.StatusOK {
+		wxRsp.Code = res.StatusCode
+		wxRsp.Error = string(bs)
+		return wxRsp, nil
+	}
+	return wxRsp, c.verifySyncSign(si)
+}
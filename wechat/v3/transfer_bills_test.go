@@ -0,0 +1,61 @@
+package wechat
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/misu99/gopay"
+)
+
+func TestV3TransferBillsRetry(t *testing.T) {
+	var (
+		userNames []string
+		serialNos []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bs, _ := io.ReadAll(r.Body)
+		bm := make(gopay.BodyMap)
+		_ = json.Unmarshal(bs, &bm)
+		userNames = append(userNames, bm.GetString("user_name"))
+		serialNos = append(serialNos, r.Header.Get(HeaderSerial))
+		if len(userNames) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"code":"SYSTEM_ERROR","message":"系统错误，请稍后重试"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"out_bill_no":"plfk2020042013","transfer_bill_no":"1330000071100999991182020050700019480001","create_time":"2015-05-20T13:29:35.120+08:00","state":"ACCEPTED"}`))
+	}))
+	defer srv.Close()
+	c := newTestClientV3(t, "1900000001", srv.URL)
+	if err := c.setPlatformCerts("SN1", map[string]string{"SN1": publicPKCS1}, true); err != nil {
+		t.Fatal(err)
+	}
+
+	bm := make(gopay.BodyMap)
+	bm.Set("appid", "wxf636efh567hg4356").
+		Set("out_bill_no", "plfk2020042013").
+		Set("transfer_scene_id", "1000").
+		Set("openid", "o-MYE42l80oelYMDE34nYD456Xoy").
+		Set("user_name", "张三").
+		Set("transfer_amount", 400000).
+		Set("transfer_remark", "2020年4月报销")
+	// 第一次请求失败后，复用同一个 bm 重试
+	wxRsp, err := c.V3TransferBills(ctx, bm)
+	if err != nil || wxRsp.Code != http.StatusInternalServerError {
+		t.Fatalf("V3TransferBills() = %+v, %v", wxRsp, err)
+	}
+	if wxRsp, err = c.V3TransferBills(ctx, bm); err != nil || wxRsp.Code != Success || wxRsp.Response.State != "ACCEPTED" {
+		t.Fatalf("V3TransferBills() = %+v, %v", wxRsp, err)
+	}
+	if bm.GetString("user_name") != "张三" {
+		t.Fatalf("V3TransferBills() modified bm user_name: %s", bm.GetString("user_name"))
+	}
+	for i, cipherText := range userNames {
+		if text, err := c.V3DecryptText(cipherText); err != nil || text != "张三" || serialNos[i] != "SN1" {
+			t.Fatalf("request %d user_name: %s, %v, Wechatpay-Serial: %s", i, text, err, serialNos[i])
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package wechat
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/misu99/gopay"
+)
+
+func TestV3ViolationNotifyUrl(t *testing.T) {
+	srv, c := newAPIServer(t)
+	const notifyUrl = "https://www.weixin.qq.com/notify/violation"
+	srv.reply(http.StatusOK, `{"mchid":"1900000001","notify_url":"`+notifyUrl+`"}`)
+
+	for _, tt := range []struct {
+		name   string
+		method string
+		fn     func() (*ViolationNotifyUrlRsp, error)
+	}{
+		{"V3ViolationNotifyUrlCreate", http.MethodPost, func() (*ViolationNotifyUrlRsp, error) { return c.V3ViolationNotifyUrlCreate(ctx, notifyUrl) }},
+		{"V3ViolationNotifyUrlQuery", http.MethodGet, func() (*ViolationNotifyUrlRsp, error) { return c.V3ViolationNotifyUrlQuery(ctx) }},
+		{"V3ViolationNotifyUrlUpdate", http.MethodPut, func() (*ViolationNotifyUrlRsp, error) { return c.V3ViolationNotifyUrlUpdate(ctx, notifyUrl) }},
+	} {
+		wxRsp, err := tt.fn()
+		if err != nil || wxRsp.Response.NotifyUrl != notifyUrl || wxRsp.Response.Mchid != "1900000001" {
+			t.Fatalf("%s() = %+v, %v", tt.name, wxRsp, err)
+		}
+		srv.check(t, tt.method, "/v3/merchant-risk-manage/violation-notifications")
+		if tt.method != http.MethodGet && srv.req.GetString("notify_url") != notifyUrl {
+			t.Fatalf("%s() request body: %s", tt.name, srv.raw)
+		}
+	}
+
+	srv.reply(http.StatusNoContent, "")
+	emptyRsp, err := c.V3ViolationNotifyUrlDelete(ctx)
+	if err != nil || emptyRsp.Code != Success {
+		t.Fatalf("V3ViolationNotifyUrlDelete() = %+v, %v", emptyRsp, err)
+	}
+	srv.check(t, http.MethodDelete, "/v3/merchant-risk-manage/violation-notifications")
+
+	// 业务错误、应答验签失败
+	srv.reply(http.StatusNotFound, `{"code":"RESOURCE_NOT_EXISTS","message":"回调地址不存在"}`)
+	wxRsp, err := c.V3ViolationNotifyUrlQuery(ctx)
+	if err != nil || wxRsp.Code != http.StatusNotFound || wxRsp.Error == "" {
+		t.Fatalf("V3ViolationNotifyUrlQuery() = %+v, %v", wxRsp, err)
+	}
+	srv.reply(http.StatusOK, `{"mchid":"1900000001","notify_url":"`+notifyUrl+`"}`)
+	srv.sign = testSignInfo(t, c, "SN1").HeaderSignature
+	if _, err = c.V3ViolationNotifyUrlQuery(ctx); !errors.Is(err, gopay.VerifySignatureErr) {
+		t.Fatalf("V3ViolationNotifyUrlQuery() with bad sign err: %v", err)
+	}
+}